@@ -1079,6 +1079,110 @@ func TargetsToBuildOpt(m map[string]*Target, inp *Input) (map[string]build.Optio
 	return m2, nil
 }
 
+// TargetsToControllerOptions converts the targets to the options of the
+// buildx controller, that builds each target in its own debuggable session.
+func TargetsToControllerOptions(m map[string]*Target, inp *Input) (map[string]*controllerapi.BuildOptions, error) {
+	m2 := make(map[string]*controllerapi.BuildOptions, len(m))
+	for k, v := range m {
+		opts, err := toControllerOptions(v, inp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "target %s", k)
+		}
+		m2[k] = opts
+	}
+	return m2, nil
+}
+
+func toControllerOptions(t *Target, inp *Input) (*controllerapi.BuildOptions, error) {
+	bo, err := toBuildOpt(t, inp)
+	if err != nil {
+		return nil, err
+	}
+	if bo.Inputs.ContextState != nil {
+		return nil, errors.New("context of a remote bake definition is not supported by the debugger")
+	}
+	contexts := make(map[string]string, len(bo.Inputs.NamedContexts))
+	for k, v := range bo.Inputs.NamedContexts {
+		if v.State != nil {
+			return nil, errors.Errorf("context %s linked to a remote bake definition is not supported by the debugger", k)
+		}
+		// contexts linked to targets are kept as "target:NAME", the
+		// debugger builds them first
+		contexts[k] = v.Path
+	}
+
+	opts := &controllerapi.BuildOptions{
+		ContextPath:      bo.Inputs.ContextPath,
+		DockerfileName:   bo.Inputs.DockerfilePath,
+		DockerfileInline: bo.Inputs.DockerfileInline,
+		NamedContexts:    contexts,
+		Allow:            t.Entitlements,
+		Annotations:      t.Annotations,
+		BuildArgs:        bo.BuildArgs,
+		Labels:           bo.Labels,
+		NetworkMode:      bo.NetworkMode,
+		NoCache:          bo.NoCache,
+		NoCacheFilter:    bo.NoCacheFilter,
+		Platforms:        t.Platforms,
+		Pull:             bo.Pull,
+		ShmSize:          int64(bo.ShmSize),
+		SourcePolicy:     bo.SourcePolicy,
+		Tags:             bo.Tags,
+		Target:           bo.Target,
+	}
+
+	if t.Call != nil {
+		opts.CallFunc, err = buildflags.ParseCallFunc(*t.Call)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts.Secrets, err = buildflags.ParseSecretSpecs(t.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	opts.SSH, err = buildflags.ParseSSHSpecs(t.SSH)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.SSH) == 0 && inp != nil && buildflags.IsGitSSH(inp.URL) {
+		opts.SSH = append(opts.SSH, &controllerapi.SSH{ID: "default"})
+	}
+
+	opts.CacheFrom, err = buildflags.ParseCacheEntry(t.CacheFrom)
+	if err != nil {
+		return nil, err
+	}
+	opts.CacheTo, err = buildflags.ParseCacheEntry(t.CacheTo)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Exports, err = buildflags.ParseExports(t.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Attests, err = buildflags.ParseAttests(t.Attest)
+	if err != nil {
+		return nil, err
+	}
+
+	if ulimits := bo.Ulimits.GetList(); len(ulimits) > 0 {
+		opts.Ulimits = &controllerapi.UlimitOpt{Values: make(map[string]*controllerapi.Ulimit, len(ulimits))}
+		for _, u := range ulimits {
+			opts.Ulimits.Values[u.Name] = &controllerapi.Ulimit{
+				Name: u.Name,
+				Hard: u.Hard,
+				Soft: u.Soft,
+			}
+		}
+	}
+
+	return opts, nil
+}
+
 func updateContext(t *build.Inputs, inp *Input) {
 	if inp == nil || inp.State == nil {
 		return
@@ -1856,3 +1856,64 @@ func TestNetNone(t *testing.T) {
 	require.Len(t, bo["app"].Allow, 0)
 	require.Equal(t, "none", bo["app"].NetworkMode)
 }
+
+func TestTargetsToControllerOptions(t *testing.T) {
+	fp := File{
+		Name: "docker-bake.hcl",
+		Data: []byte(
+			`target "app" {
+				context = "dir"
+				dockerfile = "app.Dockerfile"
+				contexts = {
+					base = "docker-image://alpine"
+				}
+				args = {
+					FOO = "bar"
+				}
+				tags = ["app:latest"]
+				target = "dev"
+				ulimits = ["nofile=1024:2048"]
+				output = ["type=local,dest=out"]
+			}
+
+			target "linked" {
+				contexts = {
+					app = "target:app"
+				}
+			}
+
+			target "inline" {
+				dockerfile-inline = "FROM alpine"
+			}`),
+	}
+
+	ctx := context.TODO()
+	m, _, err := ReadTargets(ctx, []File{fp}, []string{"app"}, nil, nil)
+	require.NoError(t, err)
+
+	opts, err := TargetsToControllerOptions(m, &Input{})
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+	require.Equal(t, "dir", opts["app"].ContextPath)
+	require.Equal(t, "dir/app.Dockerfile", opts["app"].DockerfileName)
+	require.Equal(t, map[string]string{"base": "docker-image://alpine"}, opts["app"].NamedContexts)
+	require.Equal(t, map[string]string{"FOO": "bar"}, opts["app"].BuildArgs)
+	require.Equal(t, []string{"app:latest"}, opts["app"].Tags)
+	require.Equal(t, "dev", opts["app"].Target)
+	require.Equal(t, int64(2048), opts["app"].Ulimits.Values["nofile"].Hard)
+	require.Len(t, opts["app"].Exports, 1)
+	require.Equal(t, "local", opts["app"].Exports[0].Type)
+
+	m, _, err = ReadTargets(ctx, []File{fp}, []string{"linked"}, nil, nil)
+	require.NoError(t, err)
+	opts, err = TargetsToControllerOptions(m, &Input{})
+	require.NoError(t, err)
+	require.Len(t, opts, 2)
+	require.Equal(t, map[string]string{"app": "target:app"}, opts["linked"].NamedContexts)
+
+	m, _, err = ReadTargets(ctx, []File{fp}, []string{"inline"}, nil, nil)
+	require.NoError(t, err)
+	opts, err = TargetsToControllerOptions(m, &Input{})
+	require.NoError(t, err)
+	require.Equal(t, "FROM alpine", opts["inline"].DockerfileInline)
+}
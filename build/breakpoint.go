@@ -0,0 +1,165 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/solver/result"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// Breakpoints are the steps a build pauses at, once they are solved.
+type Breakpoints struct {
+	lines  []lineBreakpoint
+	stages []string
+	steps  []digest.Digest
+	// all pauses the build at every step
+	all bool
+	// resumeFrom is the step the build paused at before; the breakpoints of
+	// the steps up to it are skipped.
+	resumeFrom digest.Digest
+}
+
+type lineBreakpoint struct {
+	filename string
+	line     int
+}
+
+// ParseBreakpoints parses breakpoints on Dockerfile lines, as LINE or
+// FILE:LINE, on the instructions of a stage, as the name of the stage, and
+// on a step, as its digest. "*" is a breakpoint on every step. The
+// breakpoints of the steps up to resumeFrom are skipped.
+func ParseBreakpoints(specs []string, resumeFrom string) (*Breakpoints, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	bps := &Breakpoints{}
+	for _, spec := range specs {
+		if spec == "" {
+			return nil, errors.New("empty breakpoint")
+		}
+		if spec == "*" {
+			bps.all = true
+			continue
+		}
+		if dgst, err := digest.Parse(spec); err == nil {
+			bps.steps = append(bps.steps, dgst)
+			continue
+		}
+		if line, err := strconv.Atoi(spec); err == nil {
+			if line < 1 {
+				return nil, errors.Errorf("invalid breakpoint line %d", line)
+			}
+			bps.lines = append(bps.lines, lineBreakpoint{line: line})
+			continue
+		}
+		if i := strings.LastIndex(spec, ":"); i > 0 {
+			if line, err := strconv.Atoi(spec[i+1:]); err == nil {
+				if line < 1 {
+					return nil, errors.Errorf("invalid breakpoint line %d", line)
+				}
+				bps.lines = append(bps.lines, lineBreakpoint{filename: spec[:i], line: line})
+				continue
+			}
+		}
+		bps.stages = append(bps.stages, spec)
+	}
+	if resumeFrom != "" {
+		dgst, err := digest.Parse(resumeFrom)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid step to resume from %q", resumeFrom)
+		}
+		bps.resumeFrom = dgst
+	}
+	return bps, nil
+}
+
+// Match returns true if a breakpoint is set on the step. Breakpoints on a
+// FILE:LINE match the steps of the files with the same base name, as the
+// frontend names the source files after it.
+func (b *Breakpoints) Match(s *Step) bool {
+	if b == nil {
+		return false
+	}
+	if b.all || slices.Contains(b.steps, s.Digest) {
+		return true
+	}
+	if s.Stage != "" {
+		for _, stage := range b.stages {
+			if stage == s.Stage {
+				return true
+			}
+		}
+	}
+	for _, bp := range b.lines {
+		for _, loc := range s.Locations {
+			if bp.filename != "" && filepath.Base(bp.filename) != filepath.Base(loc.Filename) {
+				continue
+			}
+			if bp.line >= loc.StartLine && bp.line <= loc.EndLine {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// next returns the first step after resumeFrom with a breakpoint.
+func (b *Breakpoints) next(steps []*Step) *Step {
+	start := 0
+	if b.resumeFrom != "" {
+		for i, s := range steps {
+			if s.Digest == b.resumeFrom {
+				start = i + 1
+				break
+			}
+		}
+	}
+	for _, s := range steps[start:] {
+		if b.Match(s) {
+			return s
+		}
+	}
+	return nil
+}
+
+// BreakpointError is returned with the ResultHandle of a build paused at a
+// breakpoint.
+type BreakpointError struct {
+	Step *Step
+}
+
+func (e *BreakpointError) Error() string {
+	msg := fmt.Sprintf("paused at breakpoint after %s", e.Step.Name)
+	if len(e.Step.Locations) > 0 {
+		loc := e.Step.Locations[0]
+		msg += fmt.Sprintf(" (%s:%d)", loc.Filename, loc.StartLine)
+	}
+	return msg
+}
+
+// solveBreakpoint solves the steps of the definitions up to the next
+// breakpoint, platform by platform. It returns a nil step if no breakpoint
+// is left.
+func solveBreakpoint(ctx context.Context, c gateway.Client, defs *result.Result[*pb.Definition], bps *Breakpoints) (*gateway.Result, *Step, error) {
+	gs, err := parseGraphs(defs)
+	if err != nil {
+		return nil, nil, err
+	}
+	step := bps.next(graphSteps(gs))
+	if step == nil {
+		return nil, nil, nil
+	}
+	res, err := solveStep(ctx, c, findGraph(gs, step.Digest), step.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, step, nil
+}
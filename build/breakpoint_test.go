@@ -0,0 +1,57 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakpoints(t *testing.T) {
+	steps := []*Step{
+		{Digest: digest.FromString("a"), Stage: "base", Locations: []StepLocation{{Filename: "Dockerfile", StartLine: 1, EndLine: 1}}},
+		{Digest: digest.FromString("b"), Stage: "base", Locations: []StepLocation{{Filename: "Dockerfile", StartLine: 2, EndLine: 4}}},
+		{Digest: digest.FromString("c"), Stage: "build", Locations: []StepLocation{{Filename: "Dockerfile", StartLine: 7, EndLine: 7}}},
+		{Digest: digest.FromString("d"), Stage: "build", Locations: []StepLocation{{Filename: "Dockerfile", StartLine: 8, EndLine: 8}}},
+	}
+
+	bps, err := ParseBreakpoints(nil, "")
+	require.NoError(t, err)
+	require.Nil(t, bps)
+
+	bps, err = ParseBreakpoints([]string{"3", "app/Dockerfile:8", "other.Dockerfile:1"}, "")
+	require.NoError(t, err)
+	require.Equal(t, steps[1], bps.next(steps))
+	require.False(t, bps.Match(steps[0]))
+
+	bps, err = ParseBreakpoints([]string{"3", "app/Dockerfile:8"}, steps[1].Digest.String())
+	require.NoError(t, err)
+	require.Equal(t, steps[3], bps.next(steps))
+
+	bps, err = ParseBreakpoints([]string{"build"}, steps[2].Digest.String())
+	require.NoError(t, err)
+	require.Equal(t, steps[3], bps.next(steps))
+	bps, err = ParseBreakpoints([]string{"build"}, steps[3].Digest.String())
+	require.NoError(t, err)
+	require.Nil(t, bps.next(steps))
+
+	bps, err = ParseBreakpoints([]string{"*"}, steps[0].Digest.String())
+	require.NoError(t, err)
+	require.Equal(t, steps[1], bps.next(steps))
+	bps, err = ParseBreakpoints([]string{steps[2].Digest.String()}, "")
+	require.NoError(t, err)
+	require.Equal(t, steps[2], bps.next(steps))
+
+	// the lines of files with the same base name match
+	bps, err = ParseBreakpoints([]string{"/src/app/Dockerfile:7"}, "")
+	require.NoError(t, err)
+	require.True(t, bps.Match(steps[2]))
+
+	bps = nil
+	require.False(t, bps.Match(steps[0]))
+
+	_, err = ParseBreakpoints([]string{"0"}, "")
+	require.Error(t, err)
+	_, err = ParseBreakpoints([]string{"3"}, "invalid")
+	require.Error(t, err)
+}
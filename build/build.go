@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"slices"
 	"strconv"
@@ -87,6 +88,10 @@ type Options struct {
 	ProvenanceResponseMode confutil.MetadataProvenanceMode
 	SourcePolicy           *spb.Policy
 	GroupRef               string
+
+	// Breakpoints pause the build at the matching steps. They are only
+	// supported by builds returning a result handle.
+	Breakpoints *Breakpoints
 }
 
 type CallFunc struct {
@@ -102,6 +107,9 @@ type Inputs struct {
 	ContextState     *llb.State
 	DockerfileInline string
 	NamedContexts    map[string]NamedContext
+	// InvokeDirs are the host directories that can be copied in the
+	// containers invoked on the result.
+	InvokeDirs []string
 	// DockerfileMappingSrc and DockerfileMappingDst are filled in by the builder.
 	DockerfileMappingSrc string
 	DockerfileMappingDst string
@@ -196,11 +204,6 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[
 		}
 	}
 
-	drivers, err := resolveDrivers(ctx, nodes, opts, w)
-	if err != nil {
-		return nil, err
-	}
-
 	defers := make([]func(), 0, 2)
 	defer func() {
 		if err != nil {
@@ -210,96 +213,16 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[
 		}
 	}()
 
-	reqForNodes := make(map[string][]*reqForNode)
-	eg, ctx := errgroup.WithContext(ctx)
-
-	for k, opt := range opts {
-		multiDriver := len(drivers[k]) > 1
-		hasMobyDriver := false
-		addGitAttrs, err := getGitAttributes(ctx, opt.Inputs.ContextPath, opt.Inputs.DockerfilePath)
-		if err != nil {
-			logrus.WithError(err).Warn("current commit information was not captured by the build")
-		}
-		if opt.Ref == "" {
-			opt.Ref = identity.NewID()
-		}
-		var reqn []*reqForNode
-		for _, np := range drivers[k] {
-			if np.Node().Driver.IsMobyDriver() {
-				hasMobyDriver = true
-			}
-			opt.Platforms = np.platforms
-			gatewayOpts, err := np.BuildOpts(ctx)
-			if err != nil {
-				return nil, err
-			}
-			localOpt := opt
-			so, release, err := toSolveOpt(ctx, np.Node(), multiDriver, &localOpt, gatewayOpts, cfg, w, docker)
-			opts[k] = localOpt
-			if err != nil {
-				return nil, err
-			}
-			if err := saveLocalState(so, k, opt, np.Node(), cfg); err != nil {
-				return nil, err
-			}
-			addGitAttrs(so)
-			defers = append(defers, release)
-			reqn = append(reqn, &reqForNode{
-				resolvedNode: np,
-				so:           so,
-			})
-		}
-		reqForNodes[k] = reqn
-		for _, at := range opt.Session {
-			if s, ok := at.(interface {
-				SetLogger(progresswriter.Logger)
-			}); ok {
-				s.SetLogger(func(s *client.SolveStatus) {
-					w.Write(s)
-				})
-			}
-		}
-
-		// validate for multi-node push
-		if hasMobyDriver && multiDriver {
-			for _, np := range reqForNodes[k] {
-				for _, e := range np.so.Exports {
-					if e.Type == "moby" {
-						if ok, _ := strconv.ParseBool(e.Attrs["push"]); ok {
-							return nil, errors.Errorf("multi-node push can't currently be performed with the docker driver, please switch to a different driver")
-						}
-					}
-				}
-			}
-		}
+	driverRes := newDriverResolver(nodes)
+	drivers, reqForNodes, release, err := prepareResolvedNodes(ctx, driverRes, opts, w, func(drivers map[string][]*resolvedNode) (map[string][]*reqForNode, func(), error) {
+		return prepareNodes(ctx, drivers, opts, docker, cfg, w)
+	})
+	if err != nil {
+		return nil, err
 	}
+	defers = append(defers, release)
 
-	// validate that all links between targets use same drivers
-	for name := range opts {
-		dps := reqForNodes[name]
-		for i, dp := range dps {
-			so := reqForNodes[name][i].so
-			for k, v := range so.FrontendAttrs {
-				if strings.HasPrefix(k, "context:") && strings.HasPrefix(v, "target:") {
-					k2 := strings.TrimPrefix(v, "target:")
-					dps2, ok := drivers[k2]
-					if !ok {
-						return nil, errors.Errorf("failed to find target %s for context %s", k2, strings.TrimPrefix(k, "context:")) // should be validated before already
-					}
-					var found bool
-					for _, dp2 := range dps2 {
-						if dp2.driverIndex == dp.driverIndex {
-							found = true
-							break
-						}
-					}
-					if !found {
-						return nil, errors.Errorf("failed to use %s as context %s for %s because targets build with different drivers", k2, strings.TrimPrefix(k, "context:"), name)
-					}
-				}
-			}
-		}
-	}
+	eg, ctx := errgroup.WithContext(ctx)
 
 	sharedSessions, err := detectSharedMounts(ctx, reqForNodes)
 	if err != nil {
@@ -497,7 +420,7 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[
 					var rr *client.SolveResponse
 					if resultHandleFunc != nil {
 						var resultHandle *ResultHandle
-						resultHandle, rr, err = NewResultHandle(ctx, cc, *so, "buildx", buildFunc, ch)
+						resultHandle, rr, err = NewResultHandle(ctx, cc, *so, "buildx", buildFunc, ch, opt.Breakpoints)
 						resultHandleFunc(dp.driverIndex, resultHandle)
 					} else {
 						span, ctx := tracing.StartSpan(ctx, "build")
@@ -526,6 +449,7 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[
 					}
 					if opt.CallFunc == nil {
 						rr.ExporterResponse["buildx.build.ref"] = buildRef
+						rr.ExporterResponse["buildx.build.node"] = node.Name
 						if node.Driver.HistoryAPISupported(ctx) {
 							if err := setRecordProvenance(ctx, c, rr, so.Ref, opt.ProvenanceResponseMode, pw); err != nil {
 								return err
@@ -690,10 +614,16 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[
 								}
 							}
 
+							nodeNames := make([]string, 0, len(dps))
+							for _, dp := range dps {
+								nodeNames = append(nodeNames, dp.Node().Name)
+							}
+
 							respMu.Lock()
 							resp[k] = &client.SolveResponse{
 								ExporterResponse: map[string]string{
 									exptypes.ExporterImageDigestKey: desc.Digest.String(),
+									"buildx.build.node":             strings.Join(nodeNames, ","),
 								},
 							}
 							respMu.Unlock()
@@ -721,6 +651,168 @@ func BuildWithResultHandler(ctx context.Context, nodes []builder.Node, opts map[
 	return resp, nil
 }
 
+// prepareNodes creates the solve options of each target for the nodes they
+// have been resolved to. The returned function releases the resources held by
+// the solve options.
+// prepareResolvedNodes resolves the nodes building the targets and prepares
+// the solve on them with prepare. The connection to a node can drop while the
+// solve is prepared, in which case the targets are resolved again on the
+// remaining nodes. Preparing the solve modifies opts, so every attempt starts
+// from a copy of the original options.
+func prepareResolvedNodes(ctx context.Context, r *nodeResolver, opts map[string]Options, w progress.Writer, prepare func(map[string][]*resolvedNode) (map[string][]*reqForNode, func(), error)) (map[string][]*resolvedNode, map[string][]*reqForNode, func(), error) {
+	origOpts := cloneOptions(opts)
+	for {
+		drivers, err := r.Resolve(ctx, opts, w)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		reqForNodes, release, err := prepare(drivers)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if err = r.check(ctx, drivers); err == nil {
+			return drivers, reqForNodes, release, nil
+		}
+		release()
+		if !r.retry(ctx, err) {
+			return nil, nil, nil, err
+		}
+		maps.Copy(opts, cloneOptions(origOpts))
+	}
+}
+
+// cloneOptions returns a copy of opts that doesn't share the exports, the
+// cache exports and the session attachables modified when preparing a solve.
+func cloneOptions(opts map[string]Options) map[string]Options {
+	res := make(map[string]Options, len(opts))
+	for k, opt := range opts {
+		if opt.Exports != nil {
+			exports := make([]client.ExportEntry, len(opt.Exports))
+			for i, e := range opt.Exports {
+				e.Attrs = maps.Clone(e.Attrs)
+				exports[i] = e
+			}
+			opt.Exports = exports
+		}
+		if opt.CacheTo != nil {
+			cacheTo := make([]client.CacheOptionsEntry, len(opt.CacheTo))
+			for i, e := range opt.CacheTo {
+				e.Attrs = maps.Clone(e.Attrs)
+				cacheTo[i] = e
+			}
+			opt.CacheTo = cacheTo
+		}
+		opt.Session = slices.Clone(opt.Session)
+		res[k] = opt
+	}
+	return res
+}
+
+func prepareNodes(ctx context.Context, drivers map[string][]*resolvedNode, opts map[string]Options, docker *dockerutil.Client, cfg *confutil.Config, w progress.Writer) (_ map[string][]*reqForNode, _ func(), err error) {
+	var defers []func()
+	release := func() {
+		for _, f := range defers {
+			f()
+		}
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
+
+	reqForNodes := make(map[string][]*reqForNode)
+	for k, opt := range opts {
+		multiDriver := len(drivers[k]) > 1
+		hasMobyDriver := false
+		addGitAttrs, err := getGitAttributes(ctx, opt.Inputs.ContextPath, opt.Inputs.DockerfilePath)
+		if err != nil {
+			logrus.WithError(err).Warn("current commit information was not captured by the build")
+		}
+		if opt.Ref == "" {
+			opt.Ref = identity.NewID()
+		}
+		var reqn []*reqForNode
+		for _, np := range drivers[k] {
+			if np.Node().Driver.IsMobyDriver() {
+				hasMobyDriver = true
+			}
+			opt.Platforms = np.platforms
+			gatewayOpts, err := np.BuildOpts(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			localOpt := opt
+			so, release, err := toSolveOpt(ctx, np.Node(), multiDriver, &localOpt, gatewayOpts, cfg, w, docker)
+			opts[k] = localOpt
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := saveLocalState(so, k, opt, np.Node(), cfg); err != nil {
+				return nil, nil, err
+			}
+			addGitAttrs(so)
+			defers = append(defers, release)
+			reqn = append(reqn, &reqForNode{
+				resolvedNode: np,
+				so:           so,
+			})
+		}
+		reqForNodes[k] = reqn
+		for _, at := range opt.Session {
+			if s, ok := at.(interface {
+				SetLogger(progresswriter.Logger)
+			}); ok {
+				s.SetLogger(func(s *client.SolveStatus) {
+					w.Write(s)
+				})
+			}
+		}
+
+		// validate for multi-node push
+		if hasMobyDriver && multiDriver {
+			for _, np := range reqForNodes[k] {
+				for _, e := range np.so.Exports {
+					if e.Type == "moby" {
+						if ok, _ := strconv.ParseBool(e.Attrs["push"]); ok {
+							return nil, nil, errors.Errorf("multi-node push can't currently be performed with the docker driver, please switch to a different driver")
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// validate that all links between targets use same drivers
+	for name := range opts {
+		dps := reqForNodes[name]
+		for i, dp := range dps {
+			so := reqForNodes[name][i].so
+			for k, v := range so.FrontendAttrs {
+				if strings.HasPrefix(k, "context:") && strings.HasPrefix(v, "target:") {
+					k2 := strings.TrimPrefix(v, "target:")
+					dps2, ok := drivers[k2]
+					if !ok {
+						return nil, nil, errors.Errorf("failed to find target %s for context %s", k2, strings.TrimPrefix(k, "context:")) // should be validated before already
+					}
+					var found bool
+					for _, dp2 := range dps2 {
+						if dp2.driverIndex == dp.driverIndex {
+							found = true
+							break
+						}
+					}
+					if !found {
+						return nil, nil, errors.Errorf("failed to use %s as context %s for %s because targets build with different drivers", k2, strings.TrimPrefix(k, "context:"), name)
+					}
+				}
+			}
+		}
+	}
+
+	return reqForNodes, release, nil
+}
+
 func extractIndexAnnotations(exports []client.ExportEntry) (map[exptypes.AnnotationKey]string, error) {
 	annotations := map[exptypes.AnnotationKey]string{}
 	for _, exp := range exports {
@@ -0,0 +1,334 @@
+package build
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	controllerapi "github.com/docker/buildx/controller/pb"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+// Kinds of FileChange.
+const (
+	FileAdded    = "A"
+	FileModified = "M"
+	FileDeleted  = "D"
+)
+
+// listFilesArgs lists the files of the rootfs, without the other mounts, as
+// "SIZE MTIME MODE PATH" lines. It is supported by GNU and BusyBox find and
+// stat, the modification time having a precision of one second with BusyBox.
+var listFilesArgs = []string{"find", "/", "-xdev", "-exec", "stat", "-c", "%s %.9Y %f %n", "{}", "+"}
+
+// runtimePaths are the files and directories set up by the runtime in every
+// container, which are not part of its rootfs. They are ignored with their
+// content by diffs, as they would otherwise be reported as changes.
+var runtimePaths = []string{"/etc/hosts", "/etc/resolv.conf", "/etc/hostname", "/proc", "/sys", "/dev"}
+
+// fileStat is the state of a file of a rootfs.
+type fileStat struct {
+	size int64
+	// modTime is the modification time in nanoseconds.
+	modTime int64
+	// coarse is true if modTime has a precision of one second.
+	coarse bool
+	mode   uint32
+}
+
+// Diff returns the changes of the rootfs of the running container compared
+// to its initial state, that is restored by a rollback. The initial state is
+// read through the gateway when it has a reference, the running rootfs is
+// listed by running find and stat in the container as root.
+func (c *Container) Diff(ctx context.Context) ([]*controllerapi.FileChange, error) {
+	if c.IsUnavailable() {
+		return nil, errors.New("container is not running")
+	}
+	var base map[string]fileStat
+	var err error
+	if ref, p, ok := c.mountRef("/"); ok {
+		base, err = listRefFiles(ctx, ref, p)
+	} else if c.initial {
+		base, err = c.resultCtx.listStepInitialFiles(ctx)
+	} else {
+		base, err = listInitialFiles(ctx, c.resultCtx, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	if err := c.run(ctx, listFilesArgs, nil, out); err != nil {
+		return nil, errors.Wrap(err, "failed to list files of the container, find and stat are required")
+	}
+	files, err := parseFileList(out)
+	if err != nil {
+		return nil, err
+	}
+	return diffFiles(base, files), nil
+}
+
+// StepDiff returns the changes of the rootfs made by the failed step, from
+// its initial state to its state when it failed. The state of the failed
+// step has no reference and is listed by running find and stat in a
+// container.
+func (r *ResultHandle) StepDiff(ctx context.Context) ([]*controllerapi.FileChange, error) {
+	if r.solveErr == nil {
+		return nil, errors.New("diff of the step is supported only on the failed steps")
+	}
+	base, err := r.listStepInitialFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files, err := listInitialFiles(ctx, r, false)
+	if err != nil {
+		return nil, err
+	}
+	return diffFiles(base, files), nil
+}
+
+// listStepInitialFiles lists the files of the rootfs of the failed step
+// before it ran. They are read through the gateway from the solved input of
+// the rootfs if it is the first output of a step of the build.
+func (r *ResultHandle) listStepInitialFiles(ctx context.Context) (map[string]fileStat, error) {
+	exec, err := execOpFromError(r.solveErr)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range exec.Mounts {
+		if m.Dest != "/" {
+			continue
+		}
+		if pb.InputIndex(m.Input) == pb.Empty {
+			return map[string]fileStat{}, nil
+		}
+		inputs := r.solveErr.Solve.Op.Inputs
+		if int(m.Input) >= len(inputs) || inputs[m.Input].Index != 0 || r.gwClient == nil {
+			break
+		}
+		gs, err := parseGraphs(r.def)
+		if err != nil {
+			break
+		}
+		dgst := digest.Digest(inputs[m.Input].Digest)
+		g := findGraph(gs, dgst)
+		if g == nil {
+			break
+		}
+
+		solveCtx, cancel := context.WithCancel(r.gwCtx)
+		defer cancel()
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+
+		res, err := solveStep(solveCtx, r.gwClient, g, dgst)
+		if err != nil {
+			return nil, err
+		}
+		ref, err := res.SingleRef()
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil {
+			return map[string]fileStat{}, nil
+		}
+		return listRefFiles(ctx, ref, path.Join("/", m.Selector))
+	}
+	return listInitialFiles(ctx, r, true)
+}
+
+// listRefFiles lists the files of ref below the directory p.
+func listRefFiles(ctx context.Context, ref gateway.Reference, p string) (map[string]fileStat, error) {
+	files := make(map[string]fileStat)
+	err := walkRef(ctx, ref, p, func(rel string, st *fstypes.Stat) error {
+		files[path.Join("/", rel)] = fileStat{
+			size:    st.Size,
+			modTime: st.ModTime,
+			mode:    rawMode(os.FileMode(st.Mode)),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list files")
+	}
+	return files, nil
+}
+
+// rawMode returns the Unix mode of m, as printed by stat.
+func rawMode(m os.FileMode) uint32 {
+	mode := uint32(m.Perm())
+	switch {
+	case m&os.ModeDir != 0:
+		mode |= 0o040000
+	case m&os.ModeSymlink != 0:
+		mode |= 0o120000
+	case m&os.ModeNamedPipe != 0:
+		mode |= 0o010000
+	case m&os.ModeSocket != 0:
+		mode |= 0o140000
+	case m&os.ModeCharDevice != 0:
+		mode |= 0o020000
+	case m&os.ModeDevice != 0:
+		mode |= 0o060000
+	default:
+		mode |= 0o100000
+	}
+	if m&os.ModeSetuid != 0 {
+		mode |= 0o4000
+	}
+	if m&os.ModeSetgid != 0 {
+		mode |= 0o2000
+	}
+	if m&os.ModeSticky != 0 {
+		mode |= 0o1000
+	}
+	return mode
+}
+
+// listInitialFiles lists the files of the rootfs of a new container created
+// from the result, by running find and stat in it as root.
+func listInitialFiles(ctx context.Context, resultCtx *ResultHandle, initial bool) (map[string]fileStat, error) {
+	cfg := &controllerapi.InvokeConfig{
+		Entrypoint: listFilesArgs,
+		User:       "0:0",
+		NoCwd:      true,
+		Initial:    initial,
+	}
+	ctr, err := NewContainer(ctx, resultCtx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer ctr.Cancel()
+
+	out := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if err := ctr.Exec(ctx, cfg, nil, nopWriteCloser{out}, nopWriteCloser{stderr}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.Wrap(err, msg)
+		}
+		return nil, errors.Wrap(err, "failed to list initial files, find and stat are required")
+	}
+	return parseFileList(out)
+}
+
+// parseFileList parses the output of listFilesArgs. A line that isn't a file
+// entry continues the path of the previous entry, that contains a newline.
+func parseFileList(r io.Reader) (map[string]fileStat, error) {
+	files := make(map[string]fileStat)
+	var last string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		p, st, err := parseFileEntry(line)
+		if err != nil {
+			if last == "" {
+				return nil, err
+			}
+			st := files[last]
+			delete(files, last)
+			last += "\n" + line
+			files[last] = st
+			continue
+		}
+		files[p] = st
+		last = p
+	}
+	return files, scanner.Err()
+}
+
+// parseFileEntry parses a "SIZE MTIME MODE PATH" line.
+func parseFileEntry(line string) (string, fileStat, error) {
+	fields := strings.SplitN(line, " ", 4)
+	if len(fields) != 4 || !strings.HasPrefix(fields[3], "/") {
+		return "", fileStat{}, errors.Errorf("invalid file entry %q", line)
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return "", fileStat{}, errors.Wrapf(err, "invalid size of %s", fields[3])
+	}
+	sec, nsec, frac := strings.Cut(fields[1], ".")
+	modTime, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return "", fileStat{}, errors.Wrapf(err, "invalid modification time of %s", fields[3])
+	}
+	modTime *= 1e9
+	if frac {
+		ns, err := strconv.ParseInt((nsec + "000000000")[:9], 10, 64)
+		if err != nil {
+			return "", fileStat{}, errors.Wrapf(err, "invalid modification time of %s", fields[3])
+		}
+		modTime += ns
+	}
+	mode, err := strconv.ParseUint(fields[2], 16, 32)
+	if err != nil {
+		return "", fileStat{}, errors.Wrapf(err, "invalid mode of %s", fields[3])
+	}
+	return fields[3], fileStat{size: size, modTime: modTime, coarse: !frac, mode: uint32(mode)}, nil
+}
+
+// diffFiles returns the changes from base to files, sorted by path.
+// Directories are modified only if their mode changes, as their size and
+// modification time change with their entries. The runtime paths are
+// ignored.
+func diffFiles(base, files map[string]fileStat) []*controllerapi.FileChange {
+	var changes []*controllerapi.FileChange
+	for p, st := range files {
+		if isRuntimePath(p) {
+			continue
+		}
+		old, ok := base[p]
+		if !ok {
+			changes = append(changes, &controllerapi.FileChange{Kind: FileAdded, Path: p, Size: st.size})
+			continue
+		}
+		if old.mode == st.mode && (st.isDir() || (old.size == st.size && sameModTime(old, st))) {
+			continue
+		}
+		changes = append(changes, &controllerapi.FileChange{Kind: FileModified, Path: p, Size: st.size, OldSize: old.size})
+	}
+	for p, st := range base {
+		if isRuntimePath(p) {
+			continue
+		}
+		if _, ok := files[p]; !ok {
+			changes = append(changes, &controllerapi.FileChange{Kind: FileDeleted, Path: p, Size: st.size})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// isRuntimePath returns true if p is one of the runtime paths or below one.
+func isRuntimePath(p string) bool {
+	for _, rp := range runtimePaths {
+		if p == rp || strings.HasPrefix(p, rp+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sameModTime returns true if the modification times of a and b are equal, at
+// the precision of the coarsest one.
+func sameModTime(a, b fileStat) bool {
+	if a.coarse || b.coarse {
+		return a.modTime/1e9 == b.modTime/1e9
+	}
+	return a.modTime == b.modTime
+}
+
+// isDir returns true if the raw mode of stat is a directory.
+func (st fileStat) isDir() bool {
+	return st.mode&0o170000 == 0o040000
+}
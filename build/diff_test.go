@@ -0,0 +1,162 @@
+package build
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	controllerapi "github.com/docker/buildx/controller/pb"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFiles(t *testing.T) {
+	base, err := parseFileList(strings.NewReader(`4096 1700000000 41ed /
+4096 1700000000 41ed /etc
+12 1700000000 81a4 /etc/timezone
+120 1700000000 81a4 /etc/passwd
+7 1700000000 a1ff /bin/sh
+`))
+	require.NoError(t, err)
+	require.Equal(t, fileStat{size: 12, modTime: 1700000000e9, coarse: true, mode: 0o100644}, base["/etc/timezone"])
+
+	files, err := parseFileList(strings.NewReader(`4096 1700000100 41ed /
+4096 1700000100 41ed /etc
+135 1700000100 81a4 /etc/passwd
+7 1700000000 a1ff /bin/sh
+2048 1700000100 81a4 /test logs/out.txt
+`))
+	require.NoError(t, err)
+
+	changes := diffFiles(base, files)
+	require.Equal(t, []*controllerapi.FileChange{
+		{Kind: FileModified, Path: "/etc/passwd", Size: 135, OldSize: 120},
+		{Kind: FileDeleted, Path: "/etc/timezone", Size: 12},
+		{Kind: FileAdded, Path: "/test logs/out.txt", Size: 2048},
+	}, changes)
+
+	_, err = parseFileList(strings.NewReader("invalid\n"))
+	require.Error(t, err)
+}
+
+func TestDiffRuntimePaths(t *testing.T) {
+	// the rootfs of the result has no runtime files, but may have the mount
+	// points and the files of the image
+	base, err := parseFileList(strings.NewReader(`4096 1700000000 41ed /
+4096 1700000000 41ed /etc
+22 1700000000 81a4 /etc/resolv.conf
+4096 1700000000 41ed /proc
+4096 1700000000 41ed /dev
+`))
+	require.NoError(t, err)
+
+	files, err := parseFileList(strings.NewReader(`4096 1700000000 41ed /
+4096 1700000000 41ed /etc
+174 1700000100 81a4 /etc/hosts
+38 1700000100 81a4 /etc/resolv.conf
+13 1700000100 81a4 /etc/hostname
+0 1700000100 416d /proc
+0 1700000100 41ed /sys
+340 1700000100 41ed /dev
+0 1700000100 21b6 /dev/null
+40 1700000100 41ed /dev/shm
+6 1700000100 81a4 /devices
+6 1700000100 81a4 /etc/hosts.allow
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []*controllerapi.FileChange{
+		{Kind: FileAdded, Path: "/devices", Size: 6},
+		{Kind: FileAdded, Path: "/etc/hosts.allow", Size: 6},
+	}, diffFiles(base, files))
+
+	// runtime paths missing from the container aren't deleted either
+	require.Empty(t, diffFiles(base, map[string]fileStat{
+		"/":    base["/"],
+		"/etc": base["/etc"],
+	}))
+}
+
+func TestParseFileList(t *testing.T) {
+	files, err := parseFileList(strings.NewReader(`4096 1700000000.123456789 41ed /
+12 1700000000.5 81a4 /two
+lines
+0 1700000000.000000001 81a4 /empty
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]fileStat{
+		"/":           {size: 4096, modTime: 1700000000123456789, mode: 0o40755},
+		"/two\nlines": {size: 12, modTime: 1700000000500000000, mode: 0o100644},
+		"/empty":      {size: 0, modTime: 1700000000000000001, mode: 0o100644},
+	}, files)
+}
+
+func TestDiffModTime(t *testing.T) {
+	base := map[string]fileStat{
+		"/a": {size: 1, modTime: 1700000000100000000, mode: 0o100644},
+		"/b": {size: 1, modTime: 1700000000100000000, mode: 0o100644},
+	}
+	files := map[string]fileStat{
+		// modified in the same second
+		"/a": {size: 1, modTime: 1700000000200000000, mode: 0o100644},
+		// listed with a precision of one second
+		"/b": {size: 1, modTime: 1700000000e9, coarse: true, mode: 0o100644},
+	}
+	require.Equal(t, []*controllerapi.FileChange{
+		{Kind: FileModified, Path: "/a", Size: 1, OldSize: 1},
+	}, diffFiles(base, files))
+}
+
+func TestListRefFiles(t *testing.T) {
+	ref := newFakeRef()
+	ref.addDir("/etc")
+	ref.addFile("/etc/hostname", "host", 1700000000123456789)
+	ref.addSymlink("/etc/localtime", "/usr/share/zoneinfo/UTC")
+
+	files, err := listRefFiles(context.TODO(), ref, "/")
+	require.NoError(t, err)
+	require.Equal(t, map[string]fileStat{
+		"/":              {modTime: 1, mode: 0o40755},
+		"/etc":           {modTime: 1, mode: 0o40755},
+		"/etc/hostname":  {size: 4, modTime: 1700000000123456789, mode: 0o100644},
+		"/etc/localtime": {modTime: 1, mode: 0o120777},
+	}, files)
+
+	files, err = listRefFiles(context.TODO(), ref, "/etc")
+	require.NoError(t, err)
+	require.Contains(t, files, "/hostname")
+}
+
+func TestContainerDiff(t *testing.T) {
+	root := newFakeRef()
+	root.addDir("/etc")
+	root.addFile("/etc/timezone", "UTC", 1700000000e9)
+	root.addFile("/etc/motd", "welcome", 1700000000e9)
+
+	ctr := &fakeContainer{out: `4096 1700000000.000000000 41ed /
+4096 1700000100.000000000 41ed /etc
+7 1700000000.000000000 81a4 /etc/motd
+6 1700000100.000000000 81a4 /etc/timezone
+174 1700000100.000000000 81a4 /etc/hosts
+38 1700000100.000000000 81a4 /etc/resolv.conf
+13 1700000100.000000000 81a4 /etc/hostname
+0 1700000100.000000000 416d /proc
+0 1700000100.000000000 41ed /sys
+340 1700000100.000000000 41ed /dev
+3 1700000100.000000000 81a4 /out
+`}
+	c := &Container{
+		container: ctr,
+		resultCtx: &ResultHandle{res: &gateway.Result{}},
+		mounts:    []gateway.Mount{{Dest: "/", Ref: root}},
+	}
+	changes, err := c.Diff(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, []*controllerapi.FileChange{
+		{Kind: FileModified, Path: "/etc/timezone", Size: 6, OldSize: 3},
+		{Kind: FileAdded, Path: "/out", Size: 3},
+	}, changes)
+	require.Len(t, ctr.reqs, 1)
+	require.Equal(t, listFilesArgs, ctr.reqs[0].Args)
+	require.Equal(t, "0:0", ctr.reqs[0].User)
+}
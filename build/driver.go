@@ -3,6 +3,7 @@ package build
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/containerd/platforms"
@@ -15,6 +16,7 @@ import (
 	"github.com/moby/buildkit/util/tracing"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
@@ -63,6 +65,26 @@ type nodeResolver struct {
 	nodes     []builder.Node
 	clients   cachedGroup[*client.Client]
 	buildOpts cachedGroup[gateway.BuildOpts]
+
+	// failed records the nodes that could not be booted or reached. They are
+	// skipped when resolving so another node can be used instead.
+	failed   map[int]error
+	failedMu sync.Mutex
+}
+
+// nodeError is returned when a node could not be booted or reached, or when
+// the connection to it dropped before the solve started.
+type nodeError struct {
+	idx int
+	err error
+}
+
+func (e *nodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nodeError) Unwrap() error {
+	return e.err
 }
 
 func resolveDrivers(ctx context.Context, nodes []builder.Node, opt map[string]Options, pw progress.Writer) (map[string][]*resolvedNode, error) {
@@ -79,11 +101,99 @@ func newDriverResolver(nodes []builder.Node) *nodeResolver {
 		nodes:     nodes,
 		clients:   newCachedGroup[*client.Client](),
 		buildOpts: newCachedGroup[gateway.BuildOpts](),
+		failed:    map[int]error{},
 	}
 	return r
 }
 
+// Resolve picks the nodes used to build each target. If a node fails to boot
+// or to connect, it is excluded and nodes are resolved again so that another
+// node is used instead.
 func (r *nodeResolver) Resolve(ctx context.Context, opt map[string]Options, pw progress.Writer) (map[string][]*resolvedNode, error) {
+	for {
+		nodes, err := r.resolveNodes(ctx, opt, pw)
+		if err == nil {
+			return nodes, nil
+		}
+		if !r.retry(ctx, err) {
+			return nil, err
+		}
+	}
+}
+
+// retry excludes the node err is about so that resolving again picks another
+// node. It returns false if err is not a nodeError or no other node is left.
+func (r *nodeResolver) retry(ctx context.Context, err error) bool {
+	var nerr *nodeError
+	if ctx.Err() != nil || !errors.As(err, &nerr) || !r.markFailed(nerr.idx, nerr.err) {
+		return false
+	}
+	logrus.Warnf("node %s is unavailable, trying next node: %v", r.nodes[nerr.idx].Name, nerr.err)
+	return true
+}
+
+// check verifies that the nodes resolved for the targets can still be
+// reached, right before the solve starts.
+func (r *nodeResolver) check(ctx context.Context, nodes map[string][]*resolvedNode) error {
+	var idxs []int
+	for _, nodes := range nodes {
+		for _, node := range nodes {
+			if !slices.Contains(idxs, node.driverIndex) {
+				idxs = append(idxs, node.driverIndex)
+			}
+		}
+	}
+	clients, err := r.boot(ctx, idxs, nil)
+	if err != nil {
+		return err
+	}
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, c := range clients {
+		idx := idxs[i]
+		if c == nil {
+			continue
+		}
+		eg.Go(func() error {
+			if _, err := c.ListWorkers(ctx); err != nil {
+				return &nodeError{idx: idx, err: errors.Wrap(err, "listing workers")}
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// markFailed excludes a node from resolution. It returns false if no other
+// node is left to fail over to.
+func (r *nodeResolver) markFailed(idx int, err error) bool {
+	r.failedMu.Lock()
+	defer r.failedMu.Unlock()
+	if _, ok := r.failed[idx]; ok {
+		return false
+	}
+	r.failed[idx] = err
+	return len(r.failed) < len(r.nodes)
+}
+
+func (r *nodeResolver) isFailed(idx int) bool {
+	r.failedMu.Lock()
+	defer r.failedMu.Unlock()
+	_, ok := r.failed[idx]
+	return ok
+}
+
+// available returns the indexes of the nodes that have not failed.
+func (r *nodeResolver) available() []int {
+	idxs := make([]int, 0, len(r.nodes))
+	for i := range r.nodes {
+		if !r.isFailed(i) {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs
+}
+
+func (r *nodeResolver) resolveNodes(ctx context.Context, opt map[string]Options, pw progress.Writer) (map[string][]*resolvedNode, error) {
 	if len(r.nodes) == 0 {
 		return nil, nil
 	}
@@ -101,26 +211,23 @@ func (r *nodeResolver) Resolve(ctx context.Context, opt map[string]Options, pw p
 	}
 	if len(nodes) != len(opt) {
 		// if we didn't get a perfect match, we need to boot all drivers
-		allIndexes := make([]int, len(r.nodes))
-		for i := range allIndexes {
-			allIndexes[i] = i
-		}
+		allIndexes := r.available()
 
 		clients, err := r.boot(ctx, allIndexes, pw)
 		if err != nil {
 			return nil, err
 		}
 		eg, egCtx := errgroup.WithContext(ctx)
-		workers := make([][]specs.Platform, len(clients))
+		workers := make([][]specs.Platform, len(r.nodes))
 		for i, c := range clients {
-			i, c := i, c
+			i, c := allIndexes[i], c
 			if c == nil {
 				continue
 			}
 			eg.Go(func() error {
 				ww, err := c.ListWorkers(egCtx)
 				if err != nil {
-					return errors.Wrap(err, "listing workers")
+					return &nodeError{idx: i, err: errors.Wrap(err, "listing workers")}
 				}
 
 				ps := make(map[string]specs.Platform, len(ww))
@@ -177,12 +284,15 @@ func (r *nodeResolver) resolve(ctx context.Context, ps []specs.Platform, pw prog
 		return nil, true, nil
 	}
 
+	// fall back to the first node that did not fail
+	defaultIdx := r.available()[0]
+
 	perfect := true
 	nodeIdxs := make([]int, 0)
 	for _, p := range ps {
 		idx := r.get(p, matcher, additional)
 		if idx == -1 {
-			idx = 0
+			idx = defaultIdx
 			perfect = false
 		}
 		nodeIdxs = append(nodeIdxs, idx)
@@ -192,9 +302,9 @@ func (r *nodeResolver) resolve(ctx context.Context, ps []specs.Platform, pw prog
 	if len(nodeIdxs) == 0 {
 		nodes = append(nodes, &resolvedNode{
 			resolver:    r,
-			driverIndex: 0,
+			driverIndex: defaultIdx,
 		})
-		nodeIdxs = append(nodeIdxs, 0)
+		nodeIdxs = append(nodeIdxs, defaultIdx)
 	} else {
 		for i, idx := range nodeIdxs {
 			node := &resolvedNode{
@@ -219,6 +329,9 @@ func (r *nodeResolver) get(p specs.Platform, matcher matchMaker, additionalPlatf
 	best := -1
 	bestPlatform := specs.Platform{}
 	for i, node := range r.nodes {
+		if r.isFailed(i) {
+			continue
+		}
 		platforms := node.Platforms
 		if additionalPlatforms != nil {
 			platforms = append([]specs.Platform{}, platforms...)
@@ -254,15 +367,15 @@ func (r *nodeResolver) boot(ctx context.Context, idxs []int, pw progress.Writer)
 		i, idx := i, idx
 		eg.Go(func() error {
 			c, err := r.clients.g.Do(ctx, fmt.Sprint(idx), func(ctx context.Context) (*client.Client, error) {
-				if r.nodes[idx].Driver == nil {
-					return nil, nil
-				}
 				r.clients.cacheMu.Lock()
 				c, ok := r.clients.cache[idx]
 				r.clients.cacheMu.Unlock()
 				if ok {
 					return c, nil
 				}
+				if r.nodes[idx].Driver == nil {
+					return nil, nil
+				}
 				c, err := driver.Boot(ctx, baseCtx, r.nodes[idx].Driver, pw)
 				if err != nil {
 					return nil, err
@@ -273,7 +386,7 @@ func (r *nodeResolver) boot(ctx context.Context, idxs []int, pw progress.Writer)
 				return c, nil
 			})
 			if err != nil {
-				return err
+				return &nodeError{idx: idx, err: err}
 			}
 			clients[i] = c
 			return nil
@@ -323,7 +436,7 @@ func (r *nodeResolver) opts(ctx context.Context, idxs []int, pw progress.Writer)
 				return opt, err
 			})
 			if err != nil {
-				return err
+				return &nodeError{idx: idx, err: err}
 			}
 			bopts[i] = opt
 			return nil
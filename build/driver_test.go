@@ -2,13 +2,21 @@ package build
 
 import (
 	"context"
+	"net"
 	"sort"
 	"testing"
 
 	"github.com/containerd/platforms"
 	"github.com/docker/buildx/builder"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	apitypes "github.com/moby/buildkit/api/types"
+	"github.com/moby/buildkit/client"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestFindDriverSanity(t *testing.T) {
@@ -300,6 +308,169 @@ func TestSplitNodeMultiPlatformNoUnify(t *testing.T) {
 	require.Equal(t, "bbb", res[1].Node().Builder)
 }
 
+func TestSelectNodeFailover(t *testing.T) {
+	r := makeTestResolver(map[string][]specs.Platform{
+		"aaa": {platforms.MustParse("linux/amd64")},
+		"bbb": {platforms.MustParse("linux/amd64")},
+		"ccc": {platforms.MustParse("linux/riscv64")},
+	})
+
+	res, perfect, err := r.resolve(context.TODO(), []specs.Platform{platforms.MustParse("linux/amd64")}, nil, platforms.Only, nil)
+	require.NoError(t, err)
+	require.True(t, perfect)
+	require.Len(t, res, 1)
+	require.Equal(t, "aaa", res[0].Node().Builder)
+
+	// next node serving the same platform is used
+	require.True(t, r.markFailed(0, errors.New("failed to boot")))
+	res, perfect, err = r.resolve(context.TODO(), []specs.Platform{platforms.MustParse("linux/amd64")}, nil, platforms.Only, nil)
+	require.NoError(t, err)
+	require.True(t, perfect)
+	require.Len(t, res, 1)
+	require.Equal(t, "bbb", res[0].Node().Builder)
+
+	// no node left serving the platform, fall back to the first available node
+	require.True(t, r.markFailed(1, errors.New("failed to boot")))
+	res, perfect, err = r.resolve(context.TODO(), []specs.Platform{platforms.MustParse("linux/amd64")}, nil, platforms.Only, nil)
+	require.NoError(t, err)
+	require.False(t, perfect)
+	require.Len(t, res, 1)
+	require.Equal(t, "ccc", res[0].Node().Builder)
+
+	// no node left at all
+	require.False(t, r.markFailed(2, errors.New("failed to boot")))
+}
+
+func TestResolveFailoverListWorkers(t *testing.T) {
+	r := makeTestResolver(map[string][]specs.Platform{
+		"aaa": {platforms.MustParse("linux/amd64")},
+		"bbb": {platforms.MustParse("linux/amd64")},
+	})
+	addTestClient(t, r, 0, &testControlServer{err: errors.New("worker unavailable")})
+	addTestClient(t, r, 1, &testControlServer{platforms: []string{"linux/riscv64"}})
+
+	// no node declares the platform so the workers of all nodes are listed,
+	// the node failing to list them is skipped
+	res, err := r.Resolve(context.TODO(), map[string]Options{
+		"default": {Platforms: []specs.Platform{platforms.MustParse("linux/riscv64")}},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, res["default"], 1)
+	require.Equal(t, "bbb", res["default"][0].Node().Builder)
+	require.True(t, r.isFailed(0))
+}
+
+func TestResolveFailoverConnectionDrop(t *testing.T) {
+	r := makeTestResolver(map[string][]specs.Platform{
+		"aaa": {platforms.MustParse("linux/amd64")},
+		"bbb": {platforms.MustParse("linux/amd64")},
+	})
+	srv := addTestClient(t, r, 0, &testControlServer{})
+	addTestClient(t, r, 1, &testControlServer{})
+
+	opt := map[string]Options{
+		"default": {Platforms: []specs.Platform{platforms.MustParse("linux/amd64")}},
+	}
+	res, err := r.Resolve(context.TODO(), opt, nil)
+	require.NoError(t, err)
+	require.Equal(t, "aaa", res["default"][0].Node().Builder)
+	require.NoError(t, r.check(context.TODO(), res))
+
+	// the connection drops between resolving the nodes and solving
+	srv.Stop()
+	err = r.check(context.TODO(), res)
+	var nerr *nodeError
+	require.ErrorAs(t, err, &nerr)
+	require.Equal(t, 0, nerr.idx)
+	require.True(t, r.retry(context.TODO(), err))
+
+	res, err = r.Resolve(context.TODO(), opt, nil)
+	require.NoError(t, err)
+	require.Equal(t, "bbb", res["default"][0].Node().Builder)
+	require.NoError(t, r.check(context.TODO(), res))
+	require.False(t, r.retry(context.TODO(), &nodeError{idx: 1, err: errors.New("connection lost")}))
+}
+
+func TestPrepareResolvedNodesFailover(t *testing.T) {
+	r := makeTestResolver(map[string][]specs.Platform{
+		"aaa": {platforms.MustParse("linux/amd64")},
+		"bbb": {platforms.MustParse("linux/amd64")},
+	})
+	srv := addTestClient(t, r, 0, &testControlServer{})
+	addTestClient(t, r, 1, &testControlServer{})
+
+	opts := map[string]Options{
+		"default": {
+			Platforms: []specs.Platform{platforms.MustParse("linux/amd64")},
+			Exports:   []client.ExportEntry{{Type: "image", Attrs: map[string]string{"name": "foo", "push": "true"}}},
+			CacheTo:   []client.CacheOptionsEntry{{Type: "registry", Attrs: map[string]string{"ref": "foo:cache"}}},
+		},
+	}
+	var attempts []string
+	var received []Options
+	drivers, _, release, err := prepareResolvedNodes(context.TODO(), r, opts, nil, func(drivers map[string][]*resolvedNode) (map[string][]*reqForNode, func(), error) {
+		attempts = append(attempts, drivers["default"][0].Node().Builder)
+		received = append(received, cloneOptions(opts)["default"])
+		// preparing the solve modifies the exports in place, like toSolveOpt
+		opt := opts["default"]
+		opt.Exports[0].Type = "moby"
+		opt.Exports[0].Attrs["buildinfo-attrs"] = "context=aaa"
+		opt.CacheTo[0].Attrs["mode"] = "max"
+		opts["default"] = opt
+		if len(attempts) == 1 {
+			// the connection to the first node drops before the solve
+			srv.Stop()
+		}
+		return nil, func() {}, nil
+	})
+	require.NoError(t, err)
+	release()
+	require.Equal(t, []string{"aaa", "bbb"}, attempts)
+	require.Equal(t, "bbb", drivers["default"][0].Node().Builder)
+
+	// the second node receives the original exports
+	require.Equal(t, received[0], received[1])
+	require.Equal(t, []client.ExportEntry{{Type: "image", Attrs: map[string]string{"name": "foo", "push": "true"}}}, received[1].Exports)
+	require.Equal(t, []client.CacheOptionsEntry{{Type: "registry", Attrs: map[string]string{"ref": "foo:cache"}}}, received[1].CacheTo)
+}
+
+// testControlServer is a BuildKit control API only listing workers.
+type testControlServer struct {
+	controlapi.UnimplementedControlServer
+	platforms []string
+	err       error
+}
+
+func (s *testControlServer) ListWorkers(context.Context, *controlapi.ListWorkersRequest) (*controlapi.ListWorkersResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	w := &apitypes.WorkerRecord{ID: "test"}
+	for _, p := range s.platforms {
+		pp := platforms.MustParse(p)
+		w.Platforms = append(w.Platforms, &pb.Platform{OS: pp.OS, Architecture: pp.Architecture, Variant: pp.Variant})
+	}
+	return &controlapi.ListWorkersResponse{Record: []*apitypes.WorkerRecord{w}}, nil
+}
+
+// addTestClient serves srv and sets a client connected to it as the booted
+// client of the node idx.
+func addTestClient(t *testing.T, r *nodeResolver, idx int, srv controlapi.ControlServer) *grpc.Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	controlapi.RegisterControlServer(s, srv)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	c, err := client.New(context.TODO(), "tcp://"+l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	r.clients.cache[idx] = c
+	r.buildOpts.cache[idx] = gateway.BuildOpts{}
+	return s
+}
+
 func makeTestResolver(nodes map[string][]specs.Platform) *nodeResolver {
 	var ns []builder.Node
 	for name, platforms := range nodes {
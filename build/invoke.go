@@ -1,15 +1,24 @@
 package build
 
 import (
+	"bytes"
 	"context"
 	_ "crypto/sha256" // ensure digests can be computed
 	"io"
+	"path"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
 
 	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/moby/buildkit/client/llb"
 	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	gatewaypb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/identity"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -22,6 +31,8 @@ type Container struct {
 	container       gateway.Container
 	releaseCh       chan struct{}
 	resultCtx       *ResultHandle
+	mounts          []gateway.Mount
+	initial         bool
 }
 
 func NewContainer(ctx context.Context, resultCtx *ResultHandle, cfg *controllerapi.InvokeConfig) (*Container, error) {
@@ -37,7 +48,7 @@ func NewContainer(ctx context.Context, resultCtx *ResultHandle, cfg *controllera
 				cancel()
 			}()
 
-			containerCfg, err := resultCtx.getContainerConfig(cfg)
+			containerCfg, err := resultCtx.getContainerConfig(ctx, c, cfg)
 			if err != nil {
 				return nil, err
 			}
@@ -53,6 +64,8 @@ func NewContainer(ctx context.Context, resultCtx *ResultHandle, cfg *controllera
 				container:       bkContainer,
 				releaseCh:       releaseCh,
 				resultCtx:       resultCtx,
+				mounts:          containerCfg.Mounts,
+				initial:         cfg.Initial,
 			}
 			doneCh := make(chan struct{})
 			defer close(doneCh)
@@ -112,6 +125,132 @@ func (c *Container) Exec(ctx context.Context, cfg *controllerapi.InvokeConfig, s
 	return err
 }
 
+// CopyFrom writes a tar archive of the path src of the container to w.
+// Relative paths are resolved from the working directory of the image.
+// The archive is created by running tar in the container, so it has the
+// changes made by the processes of the container. If the image has no tar,
+// the files are read through the gateway from the reference mounted at src
+// instead, without these changes.
+func (c *Container) CopyFrom(ctx context.Context, src string, w io.Writer) error {
+	if c.IsUnavailable() {
+		return errors.New("container is not running")
+	}
+	src, err := c.absPath(src)
+	if err != nil {
+		return err
+	}
+	cw := &countWriter{w: w}
+	err = c.run(ctx, []string{"tar", "-cf", "-", "-C", path.Dir(src), path.Base(src)}, nil, cw)
+	if err == nil || cw.n > 0 || ctx.Err() != nil || !tarNotRun(err) {
+		return err
+	}
+	ref, p, ok := c.mountRef(src)
+	if !ok {
+		return err
+	}
+	logrus.Debugf("failed to run tar in container, reading %s from its result: %v", src, err)
+	return writeRefTar(ctx, ref, p, w)
+}
+
+// tarNotRun returns whether err is the failure of a tar process that
+// couldn't run, rather than an error reported by tar, which exits with 1 or 2.
+func tarNotRun(err error) bool {
+	var exitErr *gatewaypb.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode != 1 && exitErr.ExitCode != 2
+	}
+	return true
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// CopyTo extracts the tar archive read from r to the directory dst of the
+// container. The archive is extracted by running tar in the container.
+func (c *Container) CopyTo(ctx context.Context, dst string, r io.Reader) error {
+	if c.IsUnavailable() {
+		return errors.New("container is not running")
+	}
+	dst, err := c.absPath(dst)
+	if err != nil {
+		return err
+	}
+	return c.run(ctx, []string{"tar", "-xf", "-", "-C", dst}, r, io.Discard)
+}
+
+// absPath returns the absolute path of p in the container, resolving it from
+// the working directory of the image if it is relative.
+func (c *Container) absPath(p string) (string, error) {
+	if path.IsAbs(p) {
+		return path.Clean(p), nil
+	}
+	req, err := c.resultCtx.getProcessConfig(&controllerapi.InvokeConfig{NoCwd: true}, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return path.Join("/", req.Cwd, p), nil
+}
+
+// mountRef returns the reference mounted at the absolute path p of the
+// container and the path of p in it. It returns false if the mount of p has
+// no reference.
+func (c *Container) mountRef(p string) (gateway.Reference, string, bool) {
+	var mnt *gateway.Mount
+	var mntDest string
+	for i, m := range c.mounts {
+		dest := path.Join("/", m.Dest)
+		if dest != "/" && p != dest && !strings.HasPrefix(p, dest+"/") {
+			continue
+		}
+		if mnt == nil || len(dest) > len(mntDest) {
+			mnt, mntDest = &c.mounts[i], dest
+		}
+	}
+	if mnt == nil || mnt.Ref == nil {
+		return nil, "", false
+	}
+	return mnt.Ref, path.Join("/", mnt.Selector, strings.TrimPrefix(p, mntDest)), true
+}
+
+// run runs a helper process in the container with args as root. Unlike
+// Exec, a failure of the process doesn't make the container unavailable.
+func (c *Container) run(ctx context.Context, args []string, r io.Reader, w io.Writer) error {
+	if c.IsUnavailable() {
+		return errors.New("container is not running")
+	}
+	cfg := &controllerapi.InvokeConfig{
+		Entrypoint: args,
+		User:       "0:0",
+		NoCwd:      true,
+	}
+	var stdin io.ReadCloser
+	if r != nil {
+		stdin = io.NopCloser(r)
+	}
+	stderr := &bytes.Buffer{}
+	if err := exec(ctx, c.resultCtx, cfg, c.container, stdin, nopWriteCloser{w}, nopWriteCloser{stderr}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.Wrap(err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
 func exec(ctx context.Context, resultCtx *ResultHandle, cfg *controllerapi.InvokeConfig, ctr gateway.Container, stdin io.ReadCloser, stdout io.WriteCloser, stderr io.WriteCloser) error {
 	processCfg, err := resultCtx.getProcessConfig(cfg, stdin, stdout, stderr)
 	if err != nil {
@@ -136,3 +275,126 @@ func exec(ctx context.Context, resultCtx *ResultHandle, cfg *controllerapi.Invok
 
 	return proc.Wait()
 }
+
+// invokeDirName returns the name of the local mount of the build session
+// sharing the host directory dir, copied in the invoked containers.
+func invokeDirName(dir string) string {
+	return "invoke-" + digest.FromString(dir).Encoded()[:12]
+}
+
+// invokeMounts returns the mounts of the invoke config as mounts of a
+// container.
+func invokeMounts(ctx context.Context, c gateway.Client, cfgs []*controllerapi.InvokeMount) ([]gateway.Mount, error) {
+	var mounts []gateway.Mount
+	for _, cfg := range cfgs {
+		mnt := gateway.Mount{
+			Dest:     cfg.Target,
+			Readonly: cfg.ReadOnly,
+		}
+		switch cfg.Type {
+		case "copy":
+			// The directory is synced again from the build session for each
+			// new container, so that a rollback picks up the changes made on
+			// the host. BuildKit containers can only mount snapshots, so the
+			// mount can't be a live bind mount of the host directory.
+			if cfg.Source == "" {
+				return nil, errors.New("copy mount requires a source")
+			}
+			def, err := llb.Local(invokeDirName(cfg.Source),
+				llb.SharedKeyHint(cfg.Source),
+				llb.LocalUniqueID(identity.NewID()),
+				llb.IgnoreCache,
+				llb.WithCustomName("[internal] load invoke directory "+cfg.Source),
+			).Marshal(ctx)
+			if err != nil {
+				return nil, err
+			}
+			res, err := c.Solve(ctx, gateway.SolveRequest{Definition: def.ToPB()})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load %s (directories must be declared with --invoke before the build)", cfg.Source)
+			}
+			ref, err := res.SingleRef()
+			if err != nil {
+				return nil, err
+			}
+			mnt.MountType = pb.MountType_BIND
+			mnt.Ref = ref
+		case "cache":
+			id := cfg.Source
+			if id == "" {
+				id = cfg.Target
+			}
+			var sharing pb.CacheSharingOpt
+			switch cfg.Sharing {
+			case "", "shared":
+				sharing = pb.CacheSharingOpt_SHARED
+			case "private":
+				sharing = pb.CacheSharingOpt_PRIVATE
+			case "locked":
+				sharing = pb.CacheSharingOpt_LOCKED
+			default:
+				return nil, errors.Errorf("invalid cache sharing mode %q", cfg.Sharing)
+			}
+			mnt.MountType = pb.MountType_CACHE
+			mnt.CacheOpt = &pb.CacheOpt{ID: id, Sharing: sharing}
+		case "secret":
+			if cfg.Source == "" {
+				return nil, errors.New("secret mount requires an ID")
+			}
+			if mnt.Dest == "" {
+				mnt.Dest = path.Join("/run/secrets", cfg.Source)
+			}
+			mnt.MountType = pb.MountType_SECRET
+			mnt.SecretOpt = &pb.SecretOpt{ID: cfg.Source, Mode: 0400}
+		case "ssh":
+			id := cfg.Source
+			if id == "" {
+				id = "default"
+			}
+			if mnt.Dest == "" {
+				mnt.Dest = defaultSSHSocket
+			}
+			mnt.MountType = pb.MountType_SSH
+			mnt.SSHOpt = &pb.SSHOpt{ID: id, Mode: 0600}
+		default:
+			return nil, errors.Errorf("unsupported mount type %q", cfg.Type)
+		}
+		if mnt.Dest == "" {
+			return nil, errors.Errorf("%s mount requires a target", cfg.Type)
+		}
+		mounts = append(mounts, mnt)
+	}
+	return mounts, nil
+}
+
+// defaultSSHSocket is the path of the SSH agent socket mounted by default, like
+// for RUN --mount=type=ssh.
+const defaultSSHSocket = "/run/buildkit/ssh_agent.0"
+
+// mergeMounts returns the mounts of the step with the mounts of the invoke
+// config, that replace the mounts of the step with the same target.
+func mergeMounts(mounts, invoke []gateway.Mount) []gateway.Mount {
+	merged := make([]gateway.Mount, 0, len(mounts)+len(invoke))
+	for _, m := range mounts {
+		if !slices.ContainsFunc(invoke, func(i gateway.Mount) bool { return i.Dest == m.Dest }) {
+			merged = append(merged, m)
+		}
+	}
+	return append(merged, invoke...)
+}
+
+// invokeMountsEnv returns the environment variables for the mounts of the
+// invoke config.
+func invokeMountsEnv(cfgs []*controllerapi.InvokeMount) []string {
+	var env []string
+	for _, cfg := range cfgs {
+		if cfg.Type == "ssh" {
+			target := cfg.Target
+			if target == "" {
+				target = defaultSSHSocket
+			}
+			env = append(env, "SSH_AUTH_SOCK="+target)
+		}
+	}
+	return env
+}
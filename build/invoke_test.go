@@ -0,0 +1,321 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	gatewaypb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokeMounts(t *testing.T) {
+	mounts, err := invokeMounts(context.TODO(), nil, []*controllerapi.InvokeMount{
+		{Type: "cache", Target: "/root/.cache/go-build"},
+		{Type: "cache", Source: "npm", Target: "/root/.npm", Sharing: "locked"},
+		{Type: "secret", Source: "token"},
+		{Type: "ssh"},
+		{Type: "ssh", Source: "github", Target: "/run/ssh.sock"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []gateway.Mount{
+		{Dest: "/root/.cache/go-build", MountType: pb.MountType_CACHE, CacheOpt: &pb.CacheOpt{ID: "/root/.cache/go-build", Sharing: pb.CacheSharingOpt_SHARED}},
+		{Dest: "/root/.npm", MountType: pb.MountType_CACHE, CacheOpt: &pb.CacheOpt{ID: "npm", Sharing: pb.CacheSharingOpt_LOCKED}},
+		{Dest: "/run/secrets/token", MountType: pb.MountType_SECRET, SecretOpt: &pb.SecretOpt{ID: "token", Mode: 0400}},
+		{Dest: "/run/buildkit/ssh_agent.0", MountType: pb.MountType_SSH, SSHOpt: &pb.SSHOpt{ID: "default", Mode: 0600}},
+		{Dest: "/run/ssh.sock", MountType: pb.MountType_SSH, SSHOpt: &pb.SSHOpt{ID: "github", Mode: 0600}},
+	}, mounts)
+
+	require.Equal(t, []string{
+		"SSH_AUTH_SOCK=/run/buildkit/ssh_agent.0",
+	}, invokeMountsEnv([]*controllerapi.InvokeMount{{Type: "secret", Source: "token"}, {Type: "ssh"}}))
+}
+
+func TestInvokeMountsInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		mount *controllerapi.InvokeMount
+		err   string
+	}{
+		{&controllerapi.InvokeMount{Type: "tmpfs", Target: "/tmp"}, `unsupported mount type "tmpfs"`},
+		{&controllerapi.InvokeMount{Type: "cache"}, "cache mount requires a target"},
+		{&controllerapi.InvokeMount{Type: "cache", Target: "/cache", Sharing: "foo"}, `invalid cache sharing mode "foo"`},
+		{&controllerapi.InvokeMount{Type: "secret"}, "secret mount requires an ID"},
+		{&controllerapi.InvokeMount{Type: "copy", Target: "/src"}, "copy mount requires a source"},
+	} {
+		_, err := invokeMounts(context.TODO(), nil, []*controllerapi.InvokeMount{tt.mount})
+		require.ErrorContains(t, err, tt.err)
+	}
+}
+
+// solveClient is a gateway client recording the definitions it solves.
+type solveClient struct {
+	gateway.Client
+	defs []*pb.Definition
+}
+
+func (c *solveClient) Solve(_ context.Context, req gateway.SolveRequest) (*gateway.Result, error) {
+	c.defs = append(c.defs, req.Definition)
+	return &gateway.Result{Ref: newFakeRef()}, nil
+}
+
+func TestInvokeMountsCopy(t *testing.T) {
+	c := &solveClient{}
+	cfgs := []*controllerapi.InvokeMount{{Type: "copy", Source: "/home/user/src", Target: "/src", ReadOnly: true}}
+	for i := 0; i < 2; i++ {
+		mounts, err := invokeMounts(context.TODO(), c, cfgs)
+		require.NoError(t, err)
+		require.Len(t, mounts, 1)
+		require.Equal(t, "/src", mounts[0].Dest)
+		require.Equal(t, pb.MountType_BIND, mounts[0].MountType)
+		require.True(t, mounts[0].Readonly)
+		require.NotNil(t, mounts[0].Ref)
+	}
+
+	// each container syncs the directory again
+	require.Len(t, c.defs, 2)
+	var uniqueIDs []string
+	for _, def := range c.defs {
+		var src *pb.SourceOp
+		var dgst digest.Digest
+		for _, dt := range def.Def {
+			var op pb.Op
+			require.NoError(t, op.Unmarshal(dt))
+			if s := op.GetSource(); s != nil {
+				src, dgst = s, digest.FromBytes(dt)
+			}
+		}
+		require.NotNil(t, src)
+		require.Equal(t, "local://"+invokeDirName("/home/user/src"), src.Identifier)
+		require.Equal(t, "/home/user/src", src.Attrs[pb.AttrSharedKeyHint])
+		require.NotEmpty(t, src.Attrs[pb.AttrLocalUniqueID])
+		require.True(t, def.Metadata[string(dgst)].IgnoreCache)
+		uniqueIDs = append(uniqueIDs, src.Attrs[pb.AttrLocalUniqueID])
+	}
+	require.NotEqual(t, uniqueIDs[0], uniqueIDs[1])
+}
+
+func TestMergeMounts(t *testing.T) {
+	mounts := mergeMounts([]gateway.Mount{
+		{Dest: "/", ResultID: "root"},
+		{Dest: "/cache", ResultID: "cache"},
+	}, []gateway.Mount{
+		{Dest: "/cache", MountType: pb.MountType_CACHE},
+		{Dest: "/src", MountType: pb.MountType_BIND},
+	})
+	require.Equal(t, []gateway.Mount{
+		{Dest: "/", ResultID: "root"},
+		{Dest: "/cache", MountType: pb.MountType_CACHE},
+		{Dest: "/src", MountType: pb.MountType_BIND},
+	}, mounts)
+}
+
+// fakeContainer is a container recording the processes started in it. The
+// processes write out to their stdout.
+type fakeContainer struct {
+	reqs  []gateway.StartRequest
+	stdin []byte
+	out   string
+}
+
+func (c *fakeContainer) Start(_ context.Context, req gateway.StartRequest) (gateway.ContainerProcess, error) {
+	c.reqs = append(c.reqs, req)
+	if req.Stdin != nil {
+		dt, err := io.ReadAll(req.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		c.stdin = dt
+	}
+	if _, err := io.WriteString(req.Stdout, c.out); err != nil {
+		return nil, err
+	}
+	return &fakeProcess{}, nil
+}
+
+func (c *fakeContainer) Release(context.Context) error {
+	return nil
+}
+
+type fakeProcess struct {
+	gateway.ContainerProcess
+	err error
+}
+
+func (p *fakeProcess) Wait() error {
+	return p.err
+}
+
+// tarContainer is a container with an in-memory filesystem, in which tar
+// extracts and archives the regular files. tar fails to start if noTar is
+// set.
+type tarContainer struct {
+	files map[string]string
+	noTar bool
+	reqs  []gateway.StartRequest
+}
+
+func (c *tarContainer) Start(_ context.Context, req gateway.StartRequest) (gateway.ContainerProcess, error) {
+	c.reqs = append(c.reqs, req)
+	if c.noTar {
+		return &fakeProcess{err: errors.New(`exec: "tar": executable file not found in $PATH`)}, nil
+	}
+	args := req.Args
+	switch {
+	case len(args) == 5 && args[1] == "-xf":
+		tr := tar.NewReader(req.Stdin)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return &fakeProcess{}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			dt, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			c.files[path.Join(args[4], hdr.Name)] = string(dt)
+		}
+	case len(args) == 6 && args[1] == "-cf":
+		dir, base := args[4], args[5]
+		tw := tar.NewWriter(req.Stdout)
+		var found bool
+		for p, dt := range c.files {
+			name := strings.TrimPrefix(p, dir+"/")
+			if name != base && !strings.HasPrefix(name, base+"/") {
+				continue
+			}
+			found = true
+			if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(dt))}); err != nil {
+				return nil, err
+			}
+			if _, err := io.WriteString(tw, dt); err != nil {
+				return nil, err
+			}
+		}
+		if !found {
+			return &fakeProcess{err: &gatewaypb.ExitError{ExitCode: 2}}, nil
+		}
+		return &fakeProcess{}, tw.Close()
+	}
+	return nil, errors.Errorf("unexpected process %v", args)
+}
+
+func (c *tarContainer) Release(context.Context) error {
+	return nil
+}
+
+func TestContainerCopyFrom(t *testing.T) {
+	root := newFakeRef()
+	root.addDir("/etc")
+	root.addFile("/etc/hostname", "host", 1)
+	src := newFakeRef()
+	src.addDir("/app")
+	src.addFile("/app/main.go", "package main", 1)
+
+	ctr := &tarContainer{files: map[string]string{
+		"/etc/hostname": "runtime host",
+		"/src/main.go":  "package main",
+		"/cache/go/a":   "cached",
+	}}
+	c := &Container{
+		container: ctr,
+		resultCtx: &ResultHandle{res: &gateway.Result{
+			Metadata: map[string][]byte{exptypes.ExporterImageConfigKey: []byte(`{"config":{"WorkingDir":"/etc"}}`)},
+		}},
+		mounts: []gateway.Mount{
+			{Dest: "/", Ref: root},
+			{Dest: "/src", Ref: src, Selector: "/app"},
+			{Dest: "/cache", ResultID: "cache"},
+		},
+	}
+
+	// the files are read from the running container
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.CopyFrom(context.TODO(), "hostname", buf))
+	_, files := readTar(t, buf)
+	require.Equal(t, map[string]string{"hostname": "runtime host"}, files)
+	require.Len(t, ctr.reqs, 1)
+	require.Equal(t, []string{"tar", "-cf", "-", "-C", "/etc", "hostname"}, ctr.reqs[0].Args)
+	require.Equal(t, "0:0", ctr.reqs[0].User)
+
+	buf.Reset()
+	require.NoError(t, c.CopyFrom(context.TODO(), "/cache/go", buf))
+	_, files = readTar(t, buf)
+	require.Equal(t, map[string]string{"go/a": "cached"}, files)
+
+	// errors of tar are returned
+	buf.Reset()
+	require.ErrorContains(t, c.CopyFrom(context.TODO(), "/src/missing", buf), "exit code: 2")
+
+	// without tar, the files are read from the result
+	ctr.noTar = true
+	buf.Reset()
+	require.NoError(t, c.CopyFrom(context.TODO(), "hostname", buf))
+	_, files = readTar(t, buf)
+	require.Equal(t, map[string]string{"hostname": "host"}, files)
+
+	buf.Reset()
+	require.NoError(t, c.CopyFrom(context.TODO(), "/src/main.go", buf))
+	_, files = readTar(t, buf)
+	require.Equal(t, map[string]string{"main.go": "package main"}, files)
+
+	// the mount has no reference to fall back to
+	buf.Reset()
+	require.ErrorContains(t, c.CopyFrom(context.TODO(), "/cache/go", buf), "executable file not found")
+}
+
+func TestContainerCopyRoundTrip(t *testing.T) {
+	src := newFakeRef()
+	src.addDir("/app")
+	src.addFile("/app/main.go", "package main", 1)
+
+	ctr := &tarContainer{files: map[string]string{"/src/main.go": "package main"}}
+	c := &Container{
+		container: ctr,
+		resultCtx: &ResultHandle{res: &gateway.Result{}},
+		mounts:    []gateway.Mount{{Dest: "/src", Ref: src, Selector: "/app"}},
+	}
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	dt := "package main\n\nfunc main() {}\n"
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "main.go", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(dt))}))
+	_, err := io.WriteString(tw, dt)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, c.CopyTo(context.TODO(), "/src", buf))
+
+	// the copied file is read back, not the one of the result
+	buf.Reset()
+	require.NoError(t, c.CopyFrom(context.TODO(), "/src/main.go", buf))
+	_, files := readTar(t, buf)
+	require.Equal(t, map[string]string{"main.go": dt}, files)
+}
+
+func TestContainerCopyTo(t *testing.T) {
+	ctr := &fakeContainer{}
+	c := &Container{
+		container: ctr,
+		resultCtx: &ResultHandle{res: &gateway.Result{}},
+	}
+	require.NoError(t, c.CopyTo(context.TODO(), "/src/", strings.NewReader("archive")))
+	require.Len(t, ctr.reqs, 1)
+	require.Equal(t, []string{"tar", "-xf", "-", "-C", "/src"}, ctr.reqs[0].Args)
+	require.Equal(t, "0:0", ctr.reqs[0].User)
+	require.Equal(t, "archive", string(ctr.stdin))
+
+	c.markUnavailable()
+	require.ErrorContains(t, c.CopyTo(context.TODO(), "/src", strings.NewReader("archive")), "container is not running")
+}
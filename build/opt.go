@@ -541,6 +541,19 @@ func loadInputs(ctx context.Context, d *driver.DriverHandle, inp *Inputs, pw pro
 		target.FrontendAttrs["context:"+k] = "local:" + localName
 	}
 
+	for _, dir := range inp.InvokeDirs {
+		st, err := os.Stat(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get invoke directory %v", dir)
+		}
+		if !st.IsDir() {
+			return nil, errors.Wrapf(syscall.ENOTDIR, "failed to get invoke directory %v", dir)
+		}
+		if err := setLocalMount(invokeDirName(dir), dir, target); err != nil {
+			return nil, err
+		}
+	}
+
 	release := func() {
 		for _, dir := range toRemove {
 			_ = os.RemoveAll(dir)
@@ -0,0 +1,114 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/pkg/errors"
+	"github.com/tonistiigi/fsutil"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+// refReadChunkSize is the size of the ranges in which the files of a
+// reference are read.
+const refReadChunkSize = 1 << 20
+
+// walkRef calls fn for the file p of ref and, if it is a directory, for all
+// the files below it, with their paths relative to p. The stat of p follows
+// its symlinks, unlike the ones of the files below it.
+func walkRef(ctx context.Context, ref gateway.Reference, p string, fn func(rel string, st *fstypes.Stat) error) error {
+	st, err := ref.StatFile(ctx, gateway.StatRequest{Path: p})
+	if err != nil {
+		return err
+	}
+	return walkRefStat(ctx, ref, p, ".", st, fn)
+}
+
+func walkRefStat(ctx context.Context, ref gateway.Reference, p, rel string, st *fstypes.Stat, fn func(rel string, st *fstypes.Stat) error) error {
+	if err := fn(rel, st); err != nil {
+		return err
+	}
+	if !st.IsDir() {
+		return nil
+	}
+	entries, err := ref.ReadDir(ctx, gateway.ReadDirRequest{Path: p})
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		base := path.Base(e.Path)
+		if err := walkRefStat(ctx, ref, path.Join(p, base), path.Join(rel, base), e, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRefTar writes a tar archive of the file p of ref to w, with the names
+// of the entries relative to the parent directory of p.
+func writeRefTar(ctx context.Context, ref gateway.Reference, p string, w io.Writer) error {
+	base := path.Base(p)
+	if p == "/" {
+		base = "."
+	}
+	tw := tar.NewWriter(w)
+	err := walkRef(ctx, ref, p, func(rel string, st *fstypes.Stat) error {
+		name := path.Join(base, rel)
+		fi := &fsutil.StatInfo{Stat: st}
+		hdr, err := tar.FileInfoHeader(fi, st.Linkname)
+		if err != nil {
+			return errors.Wrapf(err, "failed to archive %s", name)
+		}
+		hdr.Name = name
+		if fi.IsDir() && !strings.HasSuffix(hdr.Name, "/") {
+			hdr.Name += "/"
+		}
+		hdr.Uid = int(st.Uid)
+		hdr.Gid = int(st.Gid)
+		hdr.Devmajor = st.Devmajor
+		hdr.Devminor = st.Devminor
+		if len(st.Xattrs) > 0 {
+			hdr.PAXRecords = make(map[string]string, len(st.Xattrs))
+			for k, v := range st.Xattrs {
+				hdr.PAXRecords["SCHILY.xattr."+k] = string(v)
+			}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Wrapf(err, "failed to write header of %s", name)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil
+		}
+		return copyRefFile(ctx, ref, path.Join(p, rel), hdr.Size, tw)
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// copyRefFile copies the size first bytes of the file p of ref to w.
+func copyRefFile(ctx context.Context, ref gateway.Reference, p string, size int64, w io.Writer) error {
+	for off := int64(0); off < size; {
+		n := min(size-off, refReadChunkSize)
+		dt, err := ref.ReadFile(ctx, gateway.ReadRequest{
+			Filename: p,
+			Range:    &gateway.FileRange{Offset: int(off), Length: int(n)},
+		})
+		if err != nil {
+			return err
+		}
+		if len(dt) == 0 {
+			return errors.Wrapf(io.ErrUnexpectedEOF, "failed to read %s", p)
+		}
+		if _, err := w.Write(dt); err != nil {
+			return err
+		}
+		off += int64(len(dt))
+	}
+	return nil
+}
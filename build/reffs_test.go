@@ -0,0 +1,130 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	fstypes "github.com/tonistiigi/fsutil/types"
+)
+
+// fakeRef is a reference of the files added to it.
+type fakeRef struct {
+	gateway.Reference
+	stats map[string]*fstypes.Stat
+	data  map[string][]byte
+}
+
+func newFakeRef() *fakeRef {
+	r := &fakeRef{stats: map[string]*fstypes.Stat{}, data: map[string][]byte{}}
+	r.addDir("/")
+	return r
+}
+
+func (r *fakeRef) addDir(p string) {
+	r.stats[p] = &fstypes.Stat{Path: path.Base(p), Mode: uint32(os.ModeDir | 0o755), ModTime: 1}
+}
+
+func (r *fakeRef) addFile(p, data string, modTime int64) {
+	r.stats[p] = &fstypes.Stat{Path: path.Base(p), Mode: 0o644, Size: int64(len(data)), ModTime: modTime}
+	r.data[p] = []byte(data)
+}
+
+func (r *fakeRef) addSymlink(p, target string) {
+	r.stats[p] = &fstypes.Stat{Path: path.Base(p), Mode: uint32(os.ModeSymlink | 0o777), Linkname: target, ModTime: 1}
+}
+
+func (r *fakeRef) StatFile(_ context.Context, req gateway.StatRequest) (*fstypes.Stat, error) {
+	st, ok := r.stats[path.Clean(req.Path)]
+	if !ok {
+		return nil, errors.Wrap(os.ErrNotExist, req.Path)
+	}
+	return st, nil
+}
+
+func (r *fakeRef) ReadDir(_ context.Context, req gateway.ReadDirRequest) ([]*fstypes.Stat, error) {
+	dir := path.Clean(req.Path)
+	var names []string
+	for p := range r.stats {
+		if p != "/" && path.Dir(p) == dir {
+			names = append(names, p)
+		}
+	}
+	sort.Strings(names)
+	entries := make([]*fstypes.Stat, 0, len(names))
+	for _, p := range names {
+		entries = append(entries, r.stats[p])
+	}
+	return entries, nil
+}
+
+func (r *fakeRef) ReadFile(_ context.Context, req gateway.ReadRequest) ([]byte, error) {
+	dt, ok := r.data[path.Clean(req.Filename)]
+	if !ok {
+		return nil, errors.Wrap(os.ErrNotExist, req.Filename)
+	}
+	if rng := req.Range; rng != nil {
+		dt = dt[min(rng.Offset, len(dt)):min(rng.Offset+rng.Length, len(dt))]
+	}
+	return dt, nil
+}
+
+// readTar returns the entries of the tar archive r as "NAME TYPE" strings,
+// and the content of its regular files.
+func readTar(t *testing.T, r io.Reader) ([]string, map[string]string) {
+	var entries []string
+	files := map[string]string{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, files
+		}
+		require.NoError(t, err)
+		entries = append(entries, hdr.Name+" "+string(hdr.Typeflag))
+		if hdr.Typeflag == tar.TypeReg {
+			dt, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(dt)
+		}
+	}
+}
+
+func TestWriteRefTar(t *testing.T) {
+	ref := newFakeRef()
+	ref.addDir("/app")
+	ref.addFile("/app/main.go", "package main", 1)
+	ref.addDir("/app/out")
+	ref.addFile("/app/out/big", strings.Repeat("x", refReadChunkSize+10), 1)
+	ref.addSymlink("/app/latest", "out/big")
+	ref.addFile("/etc/hostname", "host", 1)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeRefTar(context.TODO(), ref, "/app", buf))
+	entries, files := readTar(t, buf)
+	require.Equal(t, []string{
+		"app/ 5",
+		"app/latest 2",
+		"app/main.go 0",
+		"app/out/ 5",
+		"app/out/big 0",
+	}, entries)
+	require.Equal(t, "package main", files["app/main.go"])
+	require.Len(t, files["app/out/big"], refReadChunkSize+10)
+
+	buf.Reset()
+	require.NoError(t, writeRefTar(context.TODO(), ref, "/app/main.go", buf))
+	entries, _ = readTar(t, buf)
+	require.Equal(t, []string{"main.go 0"}, entries)
+
+	require.ErrorIs(t, writeRefTar(context.TODO(), ref, "/missing", io.Discard), os.ErrNotExist)
+}
@@ -27,8 +27,12 @@ import (
 // context as the build occurred, which can allow easy debugging of build
 // failures and successes.
 //
+// If bps is not nil, the build pauses at the first step matching one of the
+// breakpoints: the ResultHandle of the step is returned with a
+// *BreakpointError.
+//
 // If the returned ResultHandle is not nil, the caller must call Done() on it.
-func NewResultHandle(ctx context.Context, cc *client.Client, opt client.SolveOpt, product string, buildFunc gateway.BuildFunc, ch chan *client.SolveStatus) (*ResultHandle, *client.SolveResponse, error) {
+func NewResultHandle(ctx context.Context, cc *client.Client, opt client.SolveOpt, product string, buildFunc gateway.BuildFunc, ch chan *client.SolveStatus, bps *Breakpoints) (*ResultHandle, *client.SolveResponse, error) {
 	// Create a new context to wrap the original, and cancel it when the
 	// caller-provided context is cancelled.
 	//
@@ -80,6 +84,7 @@ func NewResultHandle(ctx context.Context, cc *client.Client, opt client.SolveOpt
 	var resp *client.SolveResponse
 	var respErr error
 	var respHandle *ResultHandle
+	var respDef *result.Result[*pb.Definition]
 
 	go func() {
 		defer cancel(context.Canceled) // ensure no dangling processes
@@ -97,7 +102,40 @@ func NewResultHandle(ctx context.Context, cc *client.Client, opt client.SolveOpt
 				if err2 != nil {
 					return nil, err2
 				}
-				res, err = evalDefinition(ctx, c, def)
+				respDef = def
+
+				if bps != nil {
+					// Scenario 0: the build pauses at a breakpoint.
+					//
+					// The steps up to the breakpoint are solved and the
+					// ResultHandle of the step is returned with a
+					// BreakpointError. Like for a failed build, the gateway
+					// session is kept open until the caller closes the
+					// ResultHandle.
+					var bpRes *gateway.Result
+					var step *Step
+					bpRes, step, err = solveBreakpoint(ctx, c, def, bps)
+					if err == nil && step != nil {
+						respHandle = &ResultHandle{
+							done:     make(chan struct{}),
+							res:      bpRes,
+							def:      def,
+							gwClient: c,
+							gwCtx:    ctx,
+						}
+						respErr = &BreakpointError{Step: step}
+						close(done)
+
+						select {
+						case <-respHandle.done:
+						case <-ctx.Done():
+						}
+						return nil, respErr
+					}
+				}
+				if err == nil {
+					res, err = evalDefinition(ctx, c, def)
+				}
 			}
 
 			if err != nil {
@@ -114,6 +152,7 @@ func NewResultHandle(ctx context.Context, cc *client.Client, opt client.SolveOpt
 					respHandle = &ResultHandle{
 						done:     make(chan struct{}),
 						solveErr: se,
+						def:      respDef,
 						gwClient: c,
 						gwCtx:    ctx,
 					}
@@ -171,6 +210,7 @@ func NewResultHandle(ctx context.Context, cc *client.Client, opt client.SolveOpt
 			respHandle = &ResultHandle{
 				done:     make(chan struct{}),
 				res:      res,
+				def:      def,
 				gwClient: c,
 				gwCtx:    ctx,
 			}
@@ -255,6 +295,10 @@ func evalDefinition(ctx context.Context, c gateway.Client, defs *result.Result[*
 type ResultHandle struct {
 	res      *gateway.Result
 	solveErr *errdefs.SolveError
+	// def is the definition of the result, if the frontend returned one
+	def *result.Result[*pb.Definition]
+	// parent is the handle owning the gateway session of a step handle
+	parent *ResultHandle
 
 	done     chan struct{}
 	doneOnce sync.Once
@@ -277,6 +321,10 @@ func (r *ResultHandle) Done() {
 		}
 
 		close(r.done)
+		if r.parent != nil {
+			// the session is released with the parent
+			return
+		}
 		<-r.gwCtx.Done()
 	})
 }
@@ -292,7 +340,7 @@ func (r *ResultHandle) build(buildFunc gateway.BuildFunc) (err error) {
 	return err
 }
 
-func (r *ResultHandle) getContainerConfig(cfg *controllerapi.InvokeConfig) (containerCfg gateway.NewContainerRequest, _ error) {
+func (r *ResultHandle) getContainerConfig(ctx context.Context, c gateway.Client, cfg *controllerapi.InvokeConfig) (containerCfg gateway.NewContainerRequest, _ error) {
 	if r.res != nil && r.solveErr == nil {
 		logrus.Debugf("creating container from successful build")
 		ccfg, err := containerConfigFromResult(r.res, cfg)
@@ -308,6 +356,11 @@ func (r *ResultHandle) getContainerConfig(cfg *controllerapi.InvokeConfig) (cont
 		}
 		containerCfg = *ccfg
 	}
+	mounts, err := invokeMounts(ctx, c, cfg.Mounts)
+	if err != nil {
+		return containerCfg, err
+	}
+	containerCfg.Mounts = mergeMounts(containerCfg.Mounts, mounts)
 	return containerCfg, nil
 }
 
@@ -380,6 +433,7 @@ func populateProcessConfigFromResult(req *gateway.StartRequest, res *gateway.Res
 	if img != nil {
 		env = append(env, img.Config.Env...)
 	}
+	env = append(env, invokeMountsEnv(cfg.Mounts)...)
 	env = append(env, cfg.Env...)
 
 	args := []string{}
@@ -451,7 +505,8 @@ func populateProcessConfigFromError(req *gateway.StartRequest, solveErr *errdefs
 		cwd = meta.Cwd
 	}
 
-	env := append(meta.Env, cfg.Env...)
+	env := append(meta.Env, invokeMountsEnv(cfg.Mounts)...)
+	env = append(env, cfg.Env...)
 
 	args := []string{}
 	if cfg.Entrypoint != nil {
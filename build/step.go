@@ -0,0 +1,407 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	gateway "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/errdefs"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/solver/result"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultPath is the PATH of the process of a step if the instruction
+// didn't set one.
+const defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+var stageNameRe = regexp.MustCompile(`^\[(?:(\S+) )?\d+/\d+\]`)
+
+// Step is a vertex of the build graph that the frontend mapped to a location
+// in one of its source files, like an instruction of a Dockerfile.
+type Step struct {
+	Digest digest.Digest
+	Op     *pb.Op
+	// Name is the name of the vertex, e.g. "[build 2/4] RUN make".
+	Name string
+	// Stage is the name of the stage of the step, if the name of the vertex
+	// has one.
+	Stage string
+	// Meta is the process metadata of the step, from the step itself for
+	// exec ops or from the closest exec op it is based on.
+	Meta *pb.Meta
+	// Locations are the locations of the step in the source files.
+	Locations []StepLocation
+	// Inputs are the digests of the closest steps the step depends on.
+	Inputs []digest.Digest
+}
+
+// StepLocation is a range of lines of a source file. Lines start at 1.
+type StepLocation struct {
+	Filename  string
+	StartLine int
+	EndLine   int
+}
+
+// Steps returns the steps of the build in the order they are solved, the
+// inputs of a step coming before it. The steps of multi-platform builds are
+// returned platform by platform, the steps shared by several platforms once.
+func (r *ResultHandle) Steps() ([]*Step, error) {
+	gs, err := parseGraphs(r.def)
+	if err != nil {
+		return nil, err
+	}
+	return graphSteps(gs), nil
+}
+
+// FailedStep returns the step that failed the build, if any.
+func (r *ResultHandle) FailedStep() (*Step, error) {
+	if r.solveErr == nil || r.solveErr.Solve == nil || r.solveErr.Solve.Op == nil {
+		return nil, nil
+	}
+	steps, err := r.Steps()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range steps {
+		if proto.Equal(s.Op, r.solveErr.Solve.Op) {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// StepResult returns a handle to the result of a step, as it is after the
+// instruction of the step ran. Containers of the handle start from the
+// rootfs of the step, or from the failure of the step if it is the one that
+// failed the build. The handle shares the session of r and is released with
+// it.
+func (r *ResultHandle) StepResult(ctx context.Context, dgst digest.Digest) (*ResultHandle, error) {
+	if r.parent != nil {
+		return r.parent.StepResult(ctx, dgst)
+	}
+	gs, err := parseGraphs(r.def)
+	if err != nil {
+		return nil, err
+	}
+	g := findGraph(gs, dgst)
+	if g == nil {
+		return nil, errors.Errorf("step %s not found in the build", dgst)
+	}
+
+	h := &ResultHandle{
+		done:     make(chan struct{}),
+		def:      r.def,
+		parent:   r,
+		gwClient: r.gwClient,
+		gwCtx:    r.gwCtx,
+	}
+	if r.solveErr != nil && r.solveErr.Solve != nil && proto.Equal(g.ops[dgst], r.solveErr.Solve.Op) {
+		h.solveErr = r.solveErr
+		r.registerCleanup(h.Done)
+		return h, nil
+	}
+
+	solveCtx, cancel := context.WithCancel(r.gwCtx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	res, err := solveStep(solveCtx, r.gwClient, g, dgst)
+	if err != nil {
+		var se *errdefs.SolveError
+		if !errors.As(err, &se) {
+			return nil, err
+		}
+		h.solveErr = se
+	} else {
+		h.res = res
+	}
+	r.registerCleanup(h.Done)
+	return h, nil
+}
+
+// parseGraphs parses the definitions of all the platforms of defs.
+func parseGraphs(defs *result.Result[*pb.Definition]) ([]*graph, error) {
+	if defs == nil {
+		return nil, errors.New("no build definition available")
+	}
+	ps, err := exptypes.ParsePlatforms(defs.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	gs := make([]*graph, 0, len(ps.Platforms))
+	for _, p := range ps.Platforms {
+		def, ok := defs.FindRef(p.ID)
+		if !ok || def == nil {
+			return nil, errors.Errorf("no build definition found for %s", p.ID)
+		}
+		g, err := parseGraph(def)
+		if err != nil {
+			return nil, err
+		}
+		gs = append(gs, g)
+	}
+	return gs, nil
+}
+
+// graphSteps returns the steps of the graphs, the steps shared by several
+// graphs once.
+func graphSteps(gs []*graph) []*Step {
+	var steps []*Step
+	seen := make(map[digest.Digest]struct{})
+	for _, g := range gs {
+		for _, s := range g.steps() {
+			if _, ok := seen[s.Digest]; ok {
+				continue
+			}
+			seen[s.Digest] = struct{}{}
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// findGraph returns the first graph with the op dgst.
+func findGraph(gs []*graph, dgst digest.Digest) *graph {
+	for _, g := range gs {
+		if _, ok := g.ops[dgst]; ok {
+			return g
+		}
+	}
+	return nil
+}
+
+// solveStep solves the result of the step dgst of the graph, with the image
+// config of the environment of the step.
+func solveStep(ctx context.Context, c gateway.Client, g *graph, dgst digest.Digest) (*gateway.Result, error) {
+	res, err := c.Solve(ctx, gateway.SolveRequest{
+		Definition: g.subDefinition(dgst),
+		Evaluate:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dt, err := json.Marshal(g.image(dgst))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	res.AddMeta(exptypes.ExporterImageConfigKey, dt)
+	return res, nil
+}
+
+// graph is the parsed ops of a definition.
+type graph struct {
+	ops      map[digest.Digest]*pb.Op
+	order    []digest.Digest
+	raw      map[digest.Digest][]byte
+	source   *pb.Source
+	metadata map[digest.Digest]*pb.OpMetadata
+}
+
+func parseGraph(def *pb.Definition) (*graph, error) {
+	g := &graph{
+		ops:      make(map[digest.Digest]*pb.Op, len(def.Def)),
+		raw:      make(map[digest.Digest][]byte, len(def.Def)),
+		source:   def.Source,
+		metadata: make(map[digest.Digest]*pb.OpMetadata, len(def.Metadata)),
+	}
+	for _, dt := range def.Def {
+		var op pb.Op
+		if err := op.UnmarshalVT(dt); err != nil {
+			return nil, errors.Wrap(err, "failed to parse build definition")
+		}
+		dgst := digest.FromBytes(dt)
+		g.ops[dgst] = &op
+		g.raw[dgst] = dt
+		g.order = append(g.order, dgst)
+	}
+	for k, v := range def.Metadata {
+		g.metadata[digest.Digest(k)] = v
+	}
+	return g, nil
+}
+
+// steps walks the graph from the terminal op and returns the ops with a
+// source location.
+func (g *graph) steps() []*Step {
+	if len(g.order) == 0 {
+		return nil
+	}
+	terminal := g.ops[g.order[len(g.order)-1]]
+	if len(terminal.Inputs) == 0 {
+		return nil
+	}
+
+	var steps []*Step
+	visited := make(map[digest.Digest][]digest.Digest)
+	// visit returns the closest steps of dgst, itself if it is a step
+	var visit func(dgst digest.Digest) []digest.Digest
+	visit = func(dgst digest.Digest) []digest.Digest {
+		if closest, ok := visited[dgst]; ok {
+			return closest
+		}
+		visited[dgst] = nil
+		op, ok := g.ops[dgst]
+		if !ok {
+			return nil
+		}
+		var inputs []digest.Digest
+		for _, inp := range op.Inputs {
+			for _, d := range visit(digest.Digest(inp.Digest)) {
+				if !containsDigest(inputs, d) {
+					inputs = append(inputs, d)
+				}
+			}
+		}
+		s := g.step(dgst)
+		if s == nil {
+			visited[dgst] = inputs
+			return inputs
+		}
+		s.Inputs = inputs
+		steps = append(steps, s)
+		visited[dgst] = []digest.Digest{dgst}
+		return visited[dgst]
+	}
+	visit(digest.Digest(terminal.Inputs[0].Digest))
+	return steps
+}
+
+// step returns the step of dgst, or nil if the op has no source location.
+func (g *graph) step(dgst digest.Digest) *Step {
+	if g.source == nil {
+		return nil
+	}
+	locs, ok := g.source.Locations[dgst.String()]
+	if !ok || len(locs.Locations) == 0 {
+		return nil
+	}
+	s := &Step{
+		Digest: dgst,
+		Op:     g.ops[dgst],
+		Meta:   g.meta(dgst),
+	}
+	if md, ok := g.metadata[dgst]; ok {
+		s.Name = md.Description["llb.customname"]
+	}
+	if m := stageNameRe.FindStringSubmatch(s.Name); m != nil {
+		s.Stage = m[1]
+	}
+	for _, loc := range locs.Locations {
+		if loc.SourceIndex < 0 || int(loc.SourceIndex) >= len(g.source.Infos) {
+			continue
+		}
+		filename := g.source.Infos[loc.SourceIndex].Filename
+		for _, rng := range loc.Ranges {
+			if rng.Start == nil {
+				continue
+			}
+			end := rng.Start.Line
+			if rng.End != nil && rng.End.Line > end {
+				end = rng.End.Line
+			}
+			s.Locations = append(s.Locations, StepLocation{
+				Filename:  filename,
+				StartLine: int(rng.Start.Line),
+				EndLine:   int(end),
+			})
+		}
+	}
+	return s
+}
+
+// meta returns the process metadata of the closest exec op dgst is based
+// on, following the first input of the ops.
+func (g *graph) meta(dgst digest.Digest) *pb.Meta {
+	for {
+		op, ok := g.ops[dgst]
+		if !ok {
+			return nil
+		}
+		if exec := op.GetExec(); exec != nil {
+			return exec.Meta
+		}
+		if len(op.Inputs) == 0 {
+			return nil
+		}
+		dgst = digest.Digest(op.Inputs[0].Digest)
+	}
+}
+
+// image returns an image config with the environment of the step dgst.
+func (g *graph) image(dgst digest.Digest) *specs.Image {
+	img := &specs.Image{}
+	meta := g.meta(dgst)
+	if meta == nil {
+		img.Config.Env = []string{"PATH=" + defaultPath}
+		return img
+	}
+	img.Config.Env = meta.Env
+	if !hasEnv(meta.Env, "PATH") {
+		img.Config.Env = append([]string{"PATH=" + defaultPath}, meta.Env...)
+	}
+	img.Config.WorkingDir = meta.Cwd
+	img.Config.User = meta.User
+	return img
+}
+
+// subDefinition returns the definition of the result of the op dgst.
+func (g *graph) subDefinition(dgst digest.Digest) *pb.Definition {
+	reachable := make(map[digest.Digest]struct{})
+	var visit func(digest.Digest)
+	visit = func(d digest.Digest) {
+		if _, ok := reachable[d]; ok {
+			return
+		}
+		reachable[d] = struct{}{}
+		if op, ok := g.ops[d]; ok {
+			for _, inp := range op.Inputs {
+				visit(digest.Digest(inp.Digest))
+			}
+		}
+	}
+	visit(dgst)
+
+	out := &pb.Definition{
+		Metadata: make(map[string]*pb.OpMetadata),
+		Source:   g.source,
+	}
+	for _, d := range g.order {
+		if _, ok := reachable[d]; !ok {
+			continue
+		}
+		out.Def = append(out.Def, g.raw[d])
+		if md, ok := g.metadata[d]; ok {
+			out.Metadata[d.String()] = md
+		}
+	}
+	terminal := &pb.Op{Inputs: []*pb.Input{{Digest: dgst.String(), Index: 0}}}
+	dt, _ := terminal.MarshalVT()
+	out.Def = append(out.Def, dt)
+	return out
+}
+
+func hasEnv(env []string, key string) bool {
+	for _, e := range env {
+		if k, _, _ := strings.Cut(e, "="); k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDigest(dgsts []digest.Digest, dgst digest.Digest) bool {
+	for _, d := range dgsts {
+		if d == dgst {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/platforms"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/solver/result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSteps(t *testing.T) {
+	dockerfile := []byte("FROM alpine AS base\nRUN apk add make\n\nFROM base AS build\nWORKDIR /src\nRUN make\n")
+	sm := llb.NewSourceMap(nil, "Dockerfile", "Dockerfile", dockerfile)
+	lines := func(start, end int32) llb.ConstraintsOpt {
+		return sm.Location([]*pb.Range{{Start: &pb.Position{Line: start}, End: &pb.Position{Line: end}}})
+	}
+
+	base := llb.Image("alpine", llb.WithCustomName("[base 1/2] FROM alpine"), lines(1, 1))
+	base = base.Run(llb.Shlex("apk add make"), llb.AddEnv("PATH", "/bin"), llb.WithCustomName("[base 2/2] RUN apk add make"), lines(2, 2)).Root()
+	build := base.Dir("/src").File(llb.Mkdir("/src", 0755), llb.WithCustomName("[build 1/2] WORKDIR /src"), lines(5, 5))
+	build = build.Run(llb.Shlex("make"), llb.WithCustomName("[build 2/2] RUN make"), lines(6, 6)).Root()
+
+	def, err := build.Marshal(context.TODO())
+	require.NoError(t, err)
+
+	g, err := parseGraph(def.ToPB())
+	require.NoError(t, err)
+	steps := g.steps()
+	require.Len(t, steps, 4)
+
+	var names []string
+	for _, s := range steps {
+		names = append(names, s.Name)
+	}
+	require.Equal(t, []string{
+		"[base 1/2] FROM alpine",
+		"[base 2/2] RUN apk add make",
+		"[build 1/2] WORKDIR /src",
+		"[build 2/2] RUN make",
+	}, names)
+
+	require.Equal(t, "base", steps[1].Stage)
+	require.Equal(t, "build", steps[3].Stage)
+	require.Equal(t, []StepLocation{{Filename: "Dockerfile", StartLine: 6, EndLine: 6}}, steps[3].Locations)
+	require.Nil(t, steps[0].Inputs)
+	require.Equal(t, steps[2].Digest, steps[3].Inputs[0])
+	require.Equal(t, "/src", steps[3].Meta.Cwd)
+	require.Equal(t, []string{"make"}, steps[3].Meta.Args)
+
+	// the workdir step has the environment of the RUN it is based on
+	img := g.image(steps[2].Digest)
+	require.Equal(t, []string{"PATH=/bin"}, img.Config.Env)
+
+	sub := g.subDefinition(steps[1].Digest)
+	sg, err := parseGraph(sub)
+	require.NoError(t, err)
+	require.Len(t, sg.steps(), 2)
+}
+
+func TestStepsMultiPlatform(t *testing.T) {
+	dockerfile := []byte("FROM alpine\nRUN uname -m\n")
+	sm := llb.NewSourceMap(nil, "Dockerfile", "Dockerfile", dockerfile)
+	lines := func(start, end int32) llb.ConstraintsOpt {
+		return sm.Location([]*pb.Range{{Start: &pb.Position{Line: start}, End: &pb.Position{Line: end}}})
+	}
+
+	defs := &result.Result[*pb.Definition]{}
+	var ps exptypes.Platforms
+	for _, p := range []string{"linux/amd64", "linux/arm64"} {
+		platform := platforms.MustParse(p)
+		st := llb.Image("alpine", llb.Platform(platform), llb.WithCustomName("[1/2] FROM alpine"), lines(1, 1))
+		st = st.Run(llb.Shlex("uname -m"), llb.WithCustomName("[2/2] RUN uname -m"), lines(2, 2)).Root()
+		def, err := st.Marshal(context.TODO(), llb.Platform(platform))
+		require.NoError(t, err)
+		defs.AddRef(p, def.ToPB())
+		ps.Platforms = append(ps.Platforms, exptypes.Platform{ID: p, Platform: platform})
+	}
+	dt, err := json.Marshal(ps)
+	require.NoError(t, err)
+	defs.AddMeta(exptypes.ExporterPlatformsKey, dt)
+
+	r := &ResultHandle{def: defs}
+	steps, err := r.Steps()
+	require.NoError(t, err)
+	require.Len(t, steps, 4)
+	require.Equal(t, "amd64", steps[1].Op.GetPlatform().Architecture)
+	require.Equal(t, "arm64", steps[3].Op.GetPlatform().Architecture)
+	require.Equal(t, steps[2].Digest, steps[3].Inputs[0])
+}
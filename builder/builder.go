@@ -25,6 +25,7 @@ import (
 	"github.com/google/shlex"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"github.com/tonistiigi/go-csvvalue"
 	"golang.org/x/sync/errgroup"
@@ -37,6 +38,7 @@ type Builder struct {
 	nodes         []Node
 	opts          builderOpts
 	err           error
+	discovered    bool
 }
 
 type builderOpts struct {
@@ -205,6 +207,92 @@ func (b *Builder) Boot(ctx context.Context) (bool, error) {
 	return true, err
 }
 
+// Recreate removes and boots again the BuildKit instances of the nodes whose
+// configuration has drifted from the store. If nodeName is set, only this
+// node is considered. If force is set, nodes are recreated even if no drift
+// has been detected. The state of the nodes is kept.
+func (b *Builder) Recreate(ctx context.Context, nodeName string, force bool) (bool, error) {
+	toRecreate := make([]int, 0, len(b.nodes))
+	seen := make(map[*driver.DriverHandle]struct{})
+	for idx, d := range b.nodes {
+		if d.Err != nil || d.Driver == nil || d.DriverInfo == nil {
+			continue
+		}
+		if nodeName != "" && d.Name != nodeName {
+			continue
+		}
+		if d.DriverInfo.Status == driver.Inactive {
+			// nothing to recreate, the current configuration will be used
+			// on next boot
+			continue
+		}
+		if len(d.DriverInfo.Drift) == 0 && !force {
+			continue
+		}
+		// dynamic nodes share the same driver
+		if _, ok := seen[d.Driver]; ok {
+			continue
+		}
+		seen[d.Driver] = struct{}{}
+		toRecreate = append(toRecreate, idx)
+	}
+	if len(toRecreate) == 0 {
+		return false, nil
+	}
+
+	printer, err := progress.NewPrinter(context.TODO(), os.Stderr, progressui.AutoMode)
+	if err != nil {
+		return false, err
+	}
+
+	baseCtx := ctx
+	eg, _ := errgroup.WithContext(ctx)
+	for _, idx := range toRecreate {
+		func(idx int) {
+			eg.Go(func() error {
+				pw := progress.WithPrefix(printer, b.nodes[idx].Name, len(toRecreate) > 1)
+				if err := progress.Wrap("[internal] removing buildkit", pw.Write, func(progress.SubLogger) error {
+					if err := b.nodes[idx].Driver.Stop(ctx, true); err != nil {
+						return err
+					}
+					return b.nodes[idx].Driver.Rm(ctx, true, false, true)
+				}); err != nil {
+					b.nodes[idx].Err = err
+					return err
+				}
+				if _, err := driver.Boot(ctx, baseCtx, b.nodes[idx].Driver, pw); err != nil {
+					b.nodes[idx].Err = err
+					return err
+				}
+				return nil
+			})
+		}(idx)
+	}
+
+	err = eg.Wait()
+	err1 := printer.Wait()
+	if err == nil {
+		err = err1
+	}
+	return true, err
+}
+
+// WarnDrift logs a warning for each node whose BuildKit instance does not
+// run with the configuration stored for the builder.
+func (b *Builder) WarnDrift() {
+	seen := make(map[*driver.DriverHandle]struct{})
+	for _, d := range b.nodes {
+		if d.DriverInfo == nil || len(d.DriverInfo.Drift) == 0 {
+			continue
+		}
+		if _, ok := seen[d.Driver]; ok {
+			continue
+		}
+		seen[d.Driver] = struct{}{}
+		logrus.Warnf("node %s is not running with the current configuration (%s), use \"docker buildx update %s\" to recreate it", d.Name, driver.FormatDrift(d.DriverInfo.Drift), b.Name)
+	}
+}
+
 // Inactive checks if all nodes are inactive for this builder.
 func (b *Builder) Inactive() bool {
 	for _, d := range b.nodes {
@@ -215,6 +303,13 @@ func (b *Builder) Inactive() bool {
 	return true
 }
 
+// Discovered returns true if the nodes of the builder have been discovered
+// from a remote discovery endpoint when loaded. Such builders are dynamic but,
+// unlike the kubernetes ones, have no instance to manage.
+func (b *Builder) Discovered() bool {
+	return b.discovered
+}
+
 // Err returns error if any.
 func (b *Builder) Err() error {
 	return b.err
@@ -546,6 +641,8 @@ func Create(ctx context.Context, txn *store.Txn, dockerCli command.Cli, opts Cre
 		}
 	}
 
+	b.WarnDrift()
+
 	if opts.Use && ep != "" {
 		current, err := dockerutil.GetCurrentEndpoint(dockerCli)
 		if err != nil {
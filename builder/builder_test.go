@@ -1,10 +1,19 @@
 package builder
 
 import (
+	"context"
 	"os"
 	"path"
 	"testing"
+	"time"
 
+	"github.com/docker/buildx/driver"
+	"github.com/docker/buildx/localstate"
+	"github.com/docker/buildx/store"
+	"github.com/docker/buildx/util/confutil"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -171,3 +180,117 @@ insecure-entitlements = [ "network.host", "security.insecure" ]
 		})
 	}
 }
+
+// recreateDriver is a driver recording the calls made to recreate its
+// BuildKit instance.
+type recreateDriver struct {
+	driver.Driver
+	status driver.Status
+	calls  []string
+}
+
+func (d *recreateDriver) Info(context.Context) (*driver.Info, error) {
+	return &driver.Info{Status: d.status}, nil
+}
+
+func (d *recreateDriver) Bootstrap(context.Context, progress.Logger) error {
+	d.calls = append(d.calls, "bootstrap")
+	d.status = driver.Running
+	return nil
+}
+
+func (d *recreateDriver) Stop(context.Context, bool) error {
+	d.calls = append(d.calls, "stop")
+	d.status = driver.Stopped
+	return nil
+}
+
+func (d *recreateDriver) Rm(context.Context, bool, bool, bool) error {
+	d.calls = append(d.calls, "rm")
+	d.status = driver.Inactive
+	return nil
+}
+
+func (d *recreateDriver) Client(context.Context, ...client.ClientOpt) (*client.Client, error) {
+	return nil, nil
+}
+
+func TestRecreate(t *testing.T) {
+	drift := []driver.Drift{{Field: "image", Current: "moby/buildkit:v0.12", Desired: "moby/buildkit:latest"}}
+	newNode := func(name string, status driver.Status, drift []driver.Drift) (Node, *recreateDriver) {
+		d := &recreateDriver{status: status}
+		return Node{
+			Node:       store.Node{Name: name},
+			Driver:     &driver.DriverHandle{Driver: d},
+			DriverInfo: &driver.Info{Status: status, Drift: drift},
+		}, d
+	}
+	n0, d0 := newNode("drifted", driver.Running, drift)
+	n1, d1 := newNode("uptodate", driver.Running, nil)
+	n2, d2 := newNode("inactive", driver.Inactive, drift)
+	n3, d3 := newNode("failed", driver.Running, drift)
+	n3.Err = errors.New("failed to load node")
+
+	b := &Builder{nodes: []Node{n0, n1, n2, n3}}
+	ok, err := b.Recreate(context.TODO(), "", false)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{"stop", "rm", "bootstrap"}, d0.calls)
+	require.Empty(t, d1.calls)
+	require.Empty(t, d2.calls)
+	require.Empty(t, d3.calls)
+
+	// only the given node, even without drift with force
+	d0.calls = nil
+	ok, err = b.Recreate(context.TODO(), "uptodate", true)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, d0.calls)
+	require.Equal(t, []string{"stop", "rm", "bootstrap"}, d1.calls)
+
+	ok, err = b.Recreate(context.TODO(), "uptodate", false)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// the nodes of a dynamic builder share the same driver
+	dyn0, d := newNode("dynamic0", driver.Running, drift)
+	dyn1 := dyn0
+	dyn1.Name = "dynamic1"
+	b = &Builder{nodes: []Node{dyn0, dyn1}}
+	ok, err = b.Recreate(context.TODO(), "", false)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{"stop", "rm", "bootstrap"}, d.calls)
+}
+
+func TestRecordLastSeen(t *testing.T) {
+	ls, err := localstate.New(confutil.NewConfig(nil, confutil.WithDir(t.TempDir())))
+	require.NoError(t, err)
+
+	readLastSeen := func() time.Time {
+		st, err := ls.ReadNode("builder", "node0")
+		require.NoError(t, err)
+		return st.LastSeen
+	}
+
+	// nothing is recorded for a node that never ran
+	require.True(t, recordLastSeen(ls, "builder", "node0", false, time.Now()).IsZero())
+	_, err = ls.ReadNode("builder", "node0")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, t0, recordLastSeen(ls, "builder", "node0", true, t0))
+	require.Equal(t, t0, readLastSeen())
+
+	// the record isn't rewritten while it is recent
+	t1 := t0.Add(lastSeenSaveInterval / 2)
+	require.Equal(t, t1, recordLastSeen(ls, "builder", "node0", true, t1))
+	require.Equal(t, t0, readLastSeen())
+
+	t2 := t0.Add(lastSeenSaveInterval)
+	require.Equal(t, t2, recordLastSeen(ls, "builder", "node0", true, t2))
+	require.Equal(t, t2, readLastSeen())
+
+	// a stopped node reports the recorded time
+	require.Equal(t, t2, recordLastSeen(ls, "builder", "node0", false, t2.Add(time.Hour)))
+}
@@ -3,20 +3,28 @@ package builder
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/containerd/platforms"
 	"github.com/docker/buildx/driver"
+	remoteutil "github.com/docker/buildx/driver/remote/util"
+	"github.com/docker/buildx/localstate"
 	"github.com/docker/buildx/store"
 	"github.com/docker/buildx/store/storeutil"
+	"github.com/docker/buildx/util/confutil"
 	"github.com/docker/buildx/util/dockerutil"
 	"github.com/docker/buildx/util/imagetools"
 	"github.com/docker/buildx/util/platformutil"
+	controlapi "github.com/moby/buildkit/api/services/control"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/util/grpcerrors"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 )
@@ -36,6 +44,26 @@ type Node struct {
 	Platforms []ocispecs.Platform
 	GCPolicy  []client.PruneInfo
 	Labels    map[string]string
+
+	// Usage is only loaded with the WithUsage option
+	Usage *NodeUsage
+	// LastSeen is the last time the node answered, as recorded in the local
+	// state when its data is loaded. It is zero if it never answered.
+	LastSeen time.Time
+}
+
+// NodeUsage describes the current load and capacity of a node.
+type NodeUsage struct {
+	Workers      int
+	ActiveSolves int
+	// DiskUsage is the total size of the build cache records in bytes.
+	DiskUsage   int64
+	Reclaimable int64
+	// CacheRecords is the number of build cache records.
+	CacheRecords int
+	// Error is set if the usage could not be fully loaded. It doesn't make
+	// the node fail as the node can still be used to build.
+	Error string `json:",omitempty"`
 }
 
 // Nodes returns nodes for this builder.
@@ -47,6 +75,7 @@ type LoadNodesOption func(*loadNodesOptions)
 
 type loadNodesOptions struct {
 	data      bool
+	usage     bool
 	dialMeta  map[string][]string
 	clientOpt []client.ClientOpt
 }
@@ -57,6 +86,15 @@ func WithData() LoadNodesOption {
 	}
 }
 
+// WithUsage loads the current load and capacity of the nodes. It implies
+// WithData.
+func WithUsage() LoadNodesOption {
+	return func(o *loadNodesOptions) {
+		o.data = true
+		o.usage = true
+	}
+}
+
 func WithDialMeta(dialMeta map[string][]string) LoadNodesOption {
 	return func(o *loadNodesOptions) {
 		o.dialMeta = dialMeta
@@ -101,47 +139,7 @@ func (b *Builder) LoadNodes(ctx context.Context, opts ...LoadNodesOption) (_ []N
 	for i, n := range b.NodeGroup.Nodes {
 		func(i int, n store.Node) {
 			eg.Go(func() error {
-				node := Node{
-					Node:        n,
-					ProxyConfig: storeutil.GetProxyConfig(b.opts.dockerCli),
-					Platforms:   n.Platforms,
-					Builder:     b.Name,
-				}
-				defer func() {
-					b.nodes[i] = node
-				}()
-
-				dockerapi, err := dockerutil.NewClientAPI(b.opts.dockerCli, n.Endpoint)
-				if err != nil {
-					node.Err = err
-					return nil
-				}
-
-				d, err := driver.GetDriver(ctx, factory, driver.InitConfig{
-					Name:            driver.BuilderName(n.Name),
-					EndpointAddr:    n.Endpoint,
-					DockerAPI:       dockerapi,
-					ContextStore:    b.opts.dockerCli.ContextStore(),
-					BuildkitdFlags:  n.BuildkitdFlags,
-					Files:           n.Files,
-					DriverOpts:      n.DriverOpts,
-					Auth:            imageopt.Auth,
-					Platforms:       n.Platforms,
-					ContextPathHash: b.opts.contextPathHash,
-					DialMeta:        lno.dialMeta,
-				})
-				if err != nil {
-					node.Err = err
-					return nil
-				}
-				node.Driver = d
-				node.ImageOpt = imageopt
-
-				if lno.data {
-					if err := node.loadData(ctx, lno.clientOpt...); err != nil {
-						node.Err = err
-					}
-				}
+				b.nodes[i] = b.loadNode(ctx, factory, imageopt, n, lno)
 				return nil
 			})
 		}(i, n)
@@ -151,6 +149,10 @@ func (b *Builder) LoadNodes(ctx context.Context, opts ...LoadNodesOption) (_ []N
 		return nil, err
 	}
 
+	if err := b.loadDiscoveredNodes(ctx, factory, imageopt, lno); err != nil {
+		return nil, err
+	}
+
 	// TODO: This should be done in the routine loading driver data
 	if lno.data {
 		kubernetesDriverCount := 0
@@ -189,6 +191,145 @@ func (b *Builder) LoadNodes(ctx context.Context, opts ...LoadNodesOption) (_ []N
 	return b.nodes, nil
 }
 
+func (b *Builder) loadNode(ctx context.Context, factory driver.Factory, imageopt imagetools.Opt, n store.Node, lno loadNodesOptions) Node {
+	node := Node{
+		Node:        n,
+		ProxyConfig: storeutil.GetProxyConfig(b.opts.dockerCli),
+		Platforms:   n.Platforms,
+		Builder:     b.Name,
+	}
+
+	dockerapi, err := dockerutil.NewClientAPI(b.opts.dockerCli, n.Endpoint)
+	if err != nil {
+		node.Err = err
+		return node
+	}
+
+	d, err := driver.GetDriver(ctx, factory, driver.InitConfig{
+		Name:            driver.BuilderName(n.Name),
+		EndpointAddr:    n.Endpoint,
+		DockerAPI:       dockerapi,
+		ContextStore:    b.opts.dockerCli.ContextStore(),
+		BuildkitdFlags:  n.BuildkitdFlags,
+		Files:           n.Files,
+		DriverOpts:      n.DriverOpts,
+		Auth:            imageopt.Auth,
+		Platforms:       n.Platforms,
+		ContextPathHash: b.opts.contextPathHash,
+		DialMeta:        lno.dialMeta,
+		CacheDir:        filepath.Join(confutil.NewConfig(b.opts.dockerCli).Dir(), "cache", "drivers"),
+	})
+	if err != nil {
+		node.Err = err
+		return node
+	}
+	node.Driver = d
+	node.ImageOpt = imageopt
+
+	if lno.data {
+		if err := node.loadData(ctx, lno.usage, lno.clientOpt...); err != nil {
+			node.Err = err
+		}
+		node.LastSeen = b.lastSeen(node)
+	}
+	return node
+}
+
+// lastSeenSaveInterval is the minimum interval at which the last time a
+// running node answered is persisted, so that listing and inspecting the
+// builders doesn't write the local state each time.
+const lastSeenSaveInterval = 5 * time.Minute
+
+// lastSeen returns the last time the node answered: the current time if it
+// is running, and the last recorded time otherwise.
+func (b *Builder) lastSeen(n Node) time.Time {
+	ls, err := localstate.New(confutil.NewConfig(b.opts.dockerCli))
+	if err != nil {
+		logrus.Debugf("failed to load local state: %v", err)
+		return time.Time{}
+	}
+	running := n.Err == nil && n.DriverInfo != nil && n.DriverInfo.Status == driver.Running
+	return recordLastSeen(ls, b.Name, n.Name, running, time.Now().UTC())
+}
+
+// recordLastSeen returns now if the node is running and the last recorded
+// time otherwise. The time is only recorded if the previous record is older
+// than lastSeenSaveInterval.
+func recordLastSeen(ls *localstate.LocalState, builderName, nodeName string, running bool, now time.Time) time.Time {
+	var last time.Time
+	if st, err := ls.ReadNode(builderName, nodeName); err == nil {
+		last = st.LastSeen
+	}
+	if !running {
+		return last
+	}
+	if now.Sub(last) >= lastSeenSaveInterval {
+		if err := ls.SaveNode(builderName, nodeName, localstate.NodeState{LastSeen: now}); err != nil {
+			logrus.Debugf("failed to save state of node %s: %v", nodeName, err)
+		}
+	}
+	return now
+}
+
+// loadDiscoveredNodes replaces the nodes using a remote discovery endpoint
+// with a node for each discovered BuildKit instance. Unlike the dynamic nodes
+// of the kubernetes driver, discovered nodes have their own endpoint so they
+// get their own driver.
+func (b *Builder) loadDiscoveredNodes(ctx context.Context, factory driver.Factory, imageopt imagetools.Opt, lno loadNodesOptions) error {
+	var discovered bool
+	var nodes []Node
+	var storeNodes []store.Node
+	for _, n := range b.nodes {
+		if n.Driver == nil || !remoteutil.IsDiscoveryEndpoint(n.Endpoint) {
+			nodes = append(nodes, n)
+			storeNodes = append(storeNodes, n.Node)
+			continue
+		}
+		discovered = true
+		info := n.DriverInfo
+		if info == nil {
+			var err error
+			if info, err = n.Driver.Info(ctx); err != nil {
+				n.Err = err
+			}
+		}
+		if info != nil && info.Health != nil && info.Health.Error != "" {
+			n.Err = errors.New(info.Health.Error)
+		}
+		if n.Err != nil || len(info.DynamicNodes) == 0 {
+			nodes = append(nodes, n)
+			storeNodes = append(storeNodes, n.Node)
+			continue
+		}
+
+		dynNodes := make([]Node, len(info.DynamicNodes))
+		eg, _ := errgroup.WithContext(ctx)
+		for i, dn := range info.DynamicNodes {
+			func(i int, dn store.Node) {
+				eg.Go(func() error {
+					dynNodes[i] = b.loadNode(ctx, factory, imageopt, dn, lno)
+					return nil
+				})
+			}(i, dn)
+		}
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+		nodes = append(nodes, dynNodes...)
+		storeNodes = append(storeNodes, info.DynamicNodes...)
+	}
+	if !discovered {
+		return nil
+	}
+
+	// not append (remove the static nodes in the store)
+	b.NodeGroup.Nodes = storeNodes
+	b.nodes = nodes
+	b.NodeGroup.Dynamic = true
+	b.discovered = true
+	return nil
+}
+
 func (n *Node) MarshalJSON() ([]byte, error) {
 	var status string
 	if n.DriverInfo != nil {
@@ -199,10 +340,20 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 		status = "error"
 		nerr = strings.TrimSpace(n.Err.Error())
 	}
+	var drift []driver.Drift
+	var health *driver.Health
+	if n.DriverInfo != nil {
+		drift = n.DriverInfo.Drift
+		health = n.DriverInfo.Health
+	}
 	var pp []string
 	for _, p := range n.Platforms {
 		pp = append(pp, platforms.Format(p))
 	}
+	var lastSeen *time.Time
+	if !n.LastSeen.IsZero() {
+		lastSeen = &n.LastSeen
+	}
 	return json.Marshal(struct {
 		Name           string
 		Endpoint       string
@@ -210,6 +361,8 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 		DriverOpts     map[string]string  `json:",omitempty"`
 		Files          map[string][]byte  `json:",omitempty"`
 		Status         string             `json:",omitempty"`
+		Drift          []driver.Drift     `json:",omitempty"`
+		Health         *driver.Health     `json:",omitempty"`
 		ProxyConfig    map[string]string  `json:",omitempty"`
 		Version        string             `json:",omitempty"`
 		Err            string             `json:",omitempty"`
@@ -217,6 +370,8 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 		Platforms      []string           `json:",omitempty"`
 		GCPolicy       []client.PruneInfo `json:",omitempty"`
 		Labels         map[string]string  `json:",omitempty"`
+		Usage          *NodeUsage         `json:",omitempty"`
+		LastSeen       *time.Time         `json:",omitempty"`
 	}{
 		Name:           n.Name,
 		Endpoint:       n.Endpoint,
@@ -224,6 +379,8 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 		DriverOpts:     n.DriverOpts,
 		Files:          n.Files,
 		Status:         status,
+		Drift:          drift,
+		Health:         health,
 		ProxyConfig:    n.ProxyConfig,
 		Version:        n.Version,
 		Err:            nerr,
@@ -231,10 +388,12 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 		Platforms:      pp,
 		GCPolicy:       n.GCPolicy,
 		Labels:         n.Labels,
+		Usage:          n.Usage,
+		LastSeen:       lastSeen,
 	})
 }
 
-func (n *Node) loadData(ctx context.Context, clientOpt ...client.ClientOpt) error {
+func (n *Node) loadData(ctx context.Context, usage bool, clientOpt ...client.ClientOpt) error {
 	if n.Driver == nil {
 		return nil
 	}
@@ -273,6 +432,55 @@ func (n *Node) loadData(ctx context.Context, clientOpt ...client.ClientOpt) erro
 		} else {
 			n.Version = inf.BuildkitVersion.Version
 		}
+		if usage {
+			n.Usage = loadUsage(ctx, driverClient)
+			n.Usage.Workers = len(workers)
+		}
 	}
 	return nil
 }
+
+func loadUsage(ctx context.Context, c *client.Client) *NodeUsage {
+	usage := &NodeUsage{}
+	du, err := c.DiskUsage(ctx)
+	if err != nil {
+		usage.Error = errors.Wrap(err, "getting disk usage").Error()
+	}
+	usage.CacheRecords = len(du)
+	for _, di := range du {
+		if di.Size <= 0 {
+			continue
+		}
+		usage.DiskUsage += di.Size
+		if !di.InUse {
+			usage.Reclaimable += di.Size
+		}
+	}
+
+	cl, err := c.ControlClient().ListenBuildHistory(ctx, &controlapi.BuildHistoryRequest{
+		ActiveOnly: true,
+		EarlyExit:  true,
+	})
+	if err != nil {
+		// history API not supported by this BuildKit version
+		return usage
+	}
+	for {
+		ev, err := cl.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			if st, ok := grpcerrors.AsGRPCStatus(err); ok && st.Code() == codes.Unimplemented {
+				break
+			}
+			if usage.Error == "" {
+				usage.Error = errors.Wrap(err, "listing active builds").Error()
+			}
+			break
+		}
+		if ev.Record != nil {
+			usage.ActiveSolves++
+		}
+	}
+	return usage
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
@@ -22,6 +23,10 @@ import (
 	"github.com/docker/buildx/bake/hclparser"
 	"github.com/docker/buildx/build"
 	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/commands/debug"
+	"github.com/docker/buildx/controller"
+	"github.com/docker/buildx/controller/control"
+	controllererrors "github.com/docker/buildx/controller/errdefs"
 	"github.com/docker/buildx/controller/pb"
 	"github.com/docker/buildx/localstate"
 	"github.com/docker/buildx/util/buildflags"
@@ -34,9 +39,12 @@ import (
 	"github.com/docker/buildx/util/progress"
 	"github.com/docker/buildx/util/tracing"
 	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -56,6 +64,10 @@ type bakeOptions struct {
 	exportPush   bool
 	exportLoad   bool
 	callFunc     string
+
+	control.ControlOptions
+	invokeConfig *invokeConfig
+	breakpoints  []string
 }
 
 func runBake(ctx context.Context, dockerCli command.Cli, targets []string, in bakeOptions, cFlags commonFlags) (err error) {
@@ -260,10 +272,43 @@ func runBake(ctx context.Context, dockerCli command.Cli, targets []string, in ba
 		}
 	}
 
+	var opts map[string]*pb.BuildOptions
+	if in.invokeConfig != nil {
+		if opts, err = bake.TargetsToControllerOptions(tgts, inp); err != nil {
+			return err
+		}
+	}
+
 	if err := saveLocalStateGroup(dockerCli, in, targets, bo, overrides, def); err != nil {
 		return err
 	}
 
+	if in.invokeConfig != nil {
+		for k, b := range bo {
+			if opt, ok := opts[k]; ok {
+				opt.Ref = b.Ref
+				opt.GroupRef = b.GroupRef
+				opt.ProvenanceResponseMode = string(b.ProvenanceResponseMode)
+			}
+		}
+		done := timeBuildCommand(mp, attributes)
+		resp, retErr := runControllerBake(ctx, dockerCli, opts, in, printer)
+		if err := printer.Wait(); retErr == nil {
+			retErr = err
+		}
+		if retErr != nil {
+			err = wrapBuildError(retErr, true)
+		}
+		done(err)
+		if err != nil {
+			return err
+		}
+		if len(in.metadataFile) > 0 {
+			return writeBakeMetadataFile(in.metadataFile, resp, callFunc == nil, printer)
+		}
+		return nil
+	}
+
 	done := timeBuildCommand(mp, attributes)
 	resp, retErr := build.Build(ctx, nodes, bo, dockerutil.NewClient(dockerCli), confutil.NewConfig(dockerCli), printer)
 	if err := printer.Wait(); retErr == nil {
@@ -282,16 +327,7 @@ func runBake(ctx context.Context, dockerCli command.Cli, targets []string, in ba
 		desktop.PrintBuildDetails(os.Stderr, printer.BuildRefs(), term)
 	}
 	if len(in.metadataFile) > 0 {
-		dt := make(map[string]interface{})
-		for t, r := range resp {
-			dt[t] = decodeExporterResponse(r.ExporterResponse)
-		}
-		if callFunc == nil {
-			if warnings := printer.Warnings(); len(warnings) > 0 && confutil.MetadataWarningsEnabled() {
-				dt["buildx.build.warnings"] = warnings
-			}
-		}
-		if err := writeMetadataFile(in.metadataFile, dt); err != nil {
+		if err := writeBakeMetadataFile(in.metadataFile, resp, callFunc == nil, printer); err != nil {
 			return err
 		}
 	}
@@ -404,7 +440,243 @@ func runBake(ctx context.Context, dockerCli command.Cli, targets []string, in ba
 	return nil
 }
 
-func bakeCmd(dockerCli command.Cli, rootOpts *rootOptions) *cobra.Command {
+// runControllerBake builds the targets one by one, each in its own session of
+// the controller, and launches the monitor on the session of the failed target
+// or, with "--on=always", of the last one. The other sessions stay available
+// to the "attach" command of the monitor. The targets linked as contexts are
+// built first and also exported to an OCI layout passed as the context of the
+// targets linking them.
+func runControllerBake(ctx context.Context, dockerCli command.Cli, opts map[string]*pb.BuildOptions, in bakeOptions, printer *progress.Printer) (map[string]*client.SolveResponse, error) {
+	names, err := bakeBuildOrder(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := controller.NewController(ctx, in.ControlOptions, dockerCli, printer)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Warnf("failed to close server connection %v", err)
+		}
+	}()
+
+	layoutDir, err := os.MkdirTemp("", "buildx-debug-bake-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(layoutDir)
+	linked := linkedBakeTargets(opts)
+	for name := range linked {
+		opts[name].Exports = append(opts[name].Exports, &pb.ExportEntry{
+			Type:        client.ExporterOCI,
+			Attrs:       map[string]string{"tar": "false"},
+			Destination: filepath.Join(layoutDir, name),
+		})
+	}
+
+	sessions := make(map[string]string, len(names))
+	defer func() {
+		for _, ref := range sessions {
+			if err := c.Disconnect(ctx, ref); err != nil {
+				logrus.Warnf("disconnect error: %v", err)
+			}
+		}
+	}()
+
+	rec, err := in.invokeConfig.newRecorder()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to record debugging session")
+	}
+	var buildProgress progress.Writer = printer
+	if rec != nil {
+		defer rec.Close()
+		buildProgress = rec.Progress(printer)
+	}
+
+	resps := make(map[string]*client.SolveResponse, len(names))
+	var ref, target string
+	var retErr error
+	for _, name := range names {
+		for k, v := range opts[name].NamedContexts {
+			if dep, ok := strings.CutPrefix(v, "target:"); ok {
+				dgst := resps[dep].ExporterResponse[exptypes.ExporterImageDigestKey]
+				if dgst == "" {
+					return nil, errors.Errorf("failed to link context %s of %s: no digest exported for %s", k, name, dep)
+				}
+				opts[name].NamedContexts[k] = "oci-layout://" + filepath.Join(layoutDir, dep) + "@" + dgst
+			}
+		}
+		// NOTE: buildx server has the current working directory different from the client
+		// so we need to resolve paths to abosolute ones in the client.
+		opt, err := pb.ResolveOptionPaths(opts[name])
+		if err != nil {
+			return nil, err
+		}
+		opt.Builder = in.builder
+		opt.Breakpoints = in.breakpoints
+		opt.InvokeDirs = in.invokeConfig.invokeDirs()
+		opts[name] = opt
+
+		target = name
+		var resp *client.SolveResponse
+		ref, resp, _, err = c.Build(ctx, opt, nil, buildProgress)
+		if err != nil {
+			var be *controllererrors.BuildError
+			if !errors.As(err, &be) {
+				return nil, errors.Wrapf(err, "failed to build %s", name)
+			}
+			ref, retErr = be.SessionID, err
+		}
+		sessions[name] = ref
+		if retErr != nil {
+			break
+		}
+		resps[name] = resp
+	}
+
+	if !in.invokeConfig.needsDebug(retErr) {
+		return resps, retErr
+	}
+	if len(in.invokeConfig.exec) > 0 {
+		return resps, execResult(retErr, in.invokeConfig.runExec(ctx, ref, c, printer))
+	}
+	// Print errors before launching monitor
+	if err := printError(retErr, printer); err != nil {
+		logrus.Warnf("failed to print error information: %v", err)
+	}
+	if err := printSessions(sessions, target, printer); err != nil {
+		logrus.Warnf("failed to print sessions: %v", err)
+	}
+	monitorBuildResult, err := in.invokeConfig.runDebug(ctx, ref, opts[target], c, dockerCli.In(), os.Stdout, os.Stderr, printer, rec)
+	if err != nil {
+		logrus.Warnf("failed to run monitor: %v", err)
+	}
+	if monitorBuildResult != nil {
+		// Update the error with the last build result from monitor
+		retErr = monitorBuildResult.Err
+	}
+	return resps, retErr
+}
+
+// bakeBuildOrder returns the names of the targets sorted by name, with the
+// targets linked as contexts of a target coming before it.
+func bakeBuildOrder(opts map[string]*pb.BuildOptions) ([]string, error) {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	order := make([]string, 0, len(names))
+	visited := make(map[string]bool, len(names))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if done, ok := visited[name]; ok {
+			if !done {
+				return errors.Errorf("circular link of targets: %s", strings.Join(append(path, name), " -> "))
+			}
+			return nil
+		}
+		visited[name] = false
+		for _, dep := range bakeTargetLinks(opts[name]) {
+			if _, ok := opts[dep]; !ok {
+				return errors.Errorf("target %s links to unknown target %s", name, dep)
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		visited[name] = true
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// linkedBakeTargets returns the targets linked as contexts of other targets.
+func linkedBakeTargets(opts map[string]*pb.BuildOptions) map[string]struct{} {
+	linked := make(map[string]struct{})
+	for _, opt := range opts {
+		for _, dep := range bakeTargetLinks(opt) {
+			linked[dep] = struct{}{}
+		}
+	}
+	return linked
+}
+
+// bakeTargetLinks returns the sorted names of the targets linked as contexts
+// of opt.
+func bakeTargetLinks(opt *pb.BuildOptions) []string {
+	var deps []string
+	for _, v := range opt.NamedContexts {
+		if dep, ok := strings.CutPrefix(v, "target:"); ok && !slices.Contains(deps, dep) {
+			deps = append(deps, dep)
+		}
+	}
+	slices.Sort(deps)
+	return deps
+}
+
+// writeBakeMetadataFile writes the metadata of the responses of the targets
+// to the file, with the warnings of the build if warnings is true.
+func writeBakeMetadataFile(filename string, resp map[string]*client.SolveResponse, warnings bool, printer *progress.Printer) error {
+	dt := make(map[string]interface{})
+	for t, r := range resp {
+		dt[t] = decodeExporterResponse(r.ExporterResponse)
+	}
+	if warnings {
+		if warnings := printer.Warnings(); len(warnings) > 0 && confutil.MetadataWarningsEnabled() {
+			dt["buildx.build.warnings"] = warnings
+		}
+	}
+	return writeMetadataFile(filename, dt)
+}
+
+// printSessions prints the sessions of the targets that can be attached from
+// the monitor.
+func printSessions(sessions map[string]string, current string, printer *progress.Printer) error {
+	if len(sessions) < 2 {
+		return nil
+	}
+	if err := printer.Pause(); err != nil {
+		return err
+	}
+	defer printer.Unpause()
+	names := make([]string, 0, len(sessions))
+	for name := range sessions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	fmt.Fprintf(os.Stderr, "Launching monitor on target %q. Run \"attach SESSION\" in the monitor to switch to another target.\n", current)
+	tw := tabwriter.NewWriter(os.Stderr, 1, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "TARGET\tSESSION")
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t%s\n", name, sessions[name])
+	}
+	return tw.Flush()
+}
+
+func newDebuggableBake(dockerCli command.Cli, rootOpts *rootOptions) debug.DebuggableCmd {
+	return &debuggableBake{dockerCli: dockerCli, rootOpts: rootOpts}
+}
+
+type debuggableBake struct {
+	dockerCli command.Cli
+	rootOpts  *rootOptions
+}
+
+func (b *debuggableBake) NewDebugger(cfg *debug.DebugConfig) *cobra.Command {
+	return bakeCmd(b.dockerCli, b.rootOpts, cfg)
+}
+
+func bakeCmd(dockerCli command.Cli, rootOpts *rootOptions, debugConfig *debug.DebugConfig) *cobra.Command {
 	var options bakeOptions
 	var cFlags commonFlags
 
@@ -423,6 +695,28 @@ func bakeCmd(dockerCli command.Cli, rootOpts *rootOptions) *cobra.Command {
 			options.builder = rootOpts.builder
 			options.metadataFile = cFlags.metadataFile
 			// Other common flags (noCache, pull and progress) are processed in runBake function.
+
+			if debugConfig != nil && debugConfig.DAP {
+				return errors.New("debug adapter protocol is not supported for bake")
+			}
+			if debugConfig != nil && (debugConfig.InvokeFlag != "" || debugConfig.OnFlag != "") {
+				iConfig := new(invokeConfig)
+				if err := iConfig.parseInvokeConfig(debugConfig.InvokeFlag, debugConfig.OnFlag); err != nil {
+					return err
+				}
+				iConfig.exec = debugConfig.Exec
+				iConfig.execOutput = debugConfig.ExecOutput
+				iConfig.record = debugConfig.Record
+				iConfig.recordInput = debugConfig.RecordInput
+				if iConfig.record != "" && len(iConfig.exec) > 0 {
+					return errors.New("record can't be used with exec")
+				}
+				if iConfig.recordInput && iConfig.record == "" {
+					return errors.New("record-input requires record")
+				}
+				options.invokeConfig = iConfig
+				options.breakpoints = debugConfig.Breakpoints
+			}
 			return runBake(cmd.Context(), dockerCli, args, options, cFlags)
 		},
 		ValidArgsFunction: completion.BakeTargets(options.files),
@@ -443,6 +737,13 @@ func bakeCmd(dockerCli command.Cli, rootOpts *rootOptions) *cobra.Command {
 	flags.VarPF(callAlias(&options.callFunc, "check"), "check", "", `Shorthand for "--call=check"`)
 	flags.Lookup("check").NoOptDefVal = "true"
 
+	if debugConfig != nil {
+		flags.StringVar(&options.Root, "root", "", "Specify root directory of server to connect")
+		flags.BoolVar(&options.Detach, "detach", false, "Detach buildx server (supported only on linux)")
+		flags.StringVar(&options.ServerConfig, "server-config", "", "Specify buildx server config file (used only when launching new server)")
+		cobrautil.MarkFlagsExperimental(flags, "root", "detach", "server-config")
+	}
+
 	flags.BoolVar(&options.listTargets, "list-targets", false, "List available targets")
 	cobrautil.MarkFlagsExperimental(flags, "list-targets")
 	flags.MarkHidden("list-targets")
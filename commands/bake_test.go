@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/docker/buildx/controller/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBakeBuildOrder(t *testing.T) {
+	opts := map[string]*pb.BuildOptions{
+		"app":   {NamedContexts: map[string]string{"base": "target:base", "tools": "target:tools"}},
+		"base":  {},
+		"tools": {NamedContexts: map[string]string{"base": "target:base", "src": "./src"}},
+		"docs":  {},
+	}
+	order, err := bakeBuildOrder(opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"base", "tools", "app", "docs"}, order)
+	require.Equal(t, map[string]struct{}{"base": {}, "tools": {}}, linkedBakeTargets(opts))
+
+	opts["base"].NamedContexts = map[string]string{"app": "target:app"}
+	_, err = bakeBuildOrder(opts)
+	require.ErrorContains(t, err, "circular link of targets: app -> base -> app")
+
+	_, err = bakeBuildOrder(map[string]*pb.BuildOptions{
+		"app": {NamedContexts: map[string]string{"base": "target:base"}},
+	})
+	require.ErrorContains(t, err, "target app links to unknown target base")
+}
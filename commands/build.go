@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/containerd/console"
+	"github.com/distribution/reference"
 	"github.com/docker/buildx/build"
 	"github.com/docker/buildx/builder"
 	"github.com/docker/buildx/commands/debug"
@@ -25,6 +26,7 @@ import (
 	"github.com/docker/buildx/controller/control"
 	controllererrors "github.com/docker/buildx/controller/errdefs"
 	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/driver"
 	"github.com/docker/buildx/monitor"
 	"github.com/docker/buildx/store"
 	"github.com/docker/buildx/store/storeutil"
@@ -32,6 +34,7 @@ import (
 	"github.com/docker/buildx/util/cobrautil"
 	"github.com/docker/buildx/util/confutil"
 	"github.com/docker/buildx/util/desktop"
+	"github.com/docker/buildx/util/imagetools"
 	"github.com/docker/buildx/util/ioset"
 	"github.com/docker/buildx/util/metricutil"
 	"github.com/docker/buildx/util/osutil"
@@ -44,15 +47,18 @@ import (
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	gatewaypb "github.com/moby/buildkit/frontend/gateway/pb"
 	"github.com/moby/buildkit/frontend/subrequests"
 	"github.com/moby/buildkit/frontend/subrequests/lint"
 	"github.com/moby/buildkit/frontend/subrequests/outline"
 	"github.com/moby/buildkit/frontend/subrequests/targets"
+	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/solver/errdefs"
 	solverpb "github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/grpcerrors"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -84,6 +90,7 @@ type buildOptions struct {
 	callFunc       string
 	secrets        []string
 	shmSize        dockeropts.MemBytes
+	signKey        string
 	ssh            []string
 	tags           []string
 	target         string
@@ -106,6 +113,7 @@ type buildOptions struct {
 	control.ControlOptions
 
 	invokeConfig *invokeConfig
+	breakpoints  []string
 }
 
 func (o *buildOptions) toControllerOptions() (*controllerapi.BuildOptions, error) {
@@ -142,6 +150,10 @@ func (o *buildOptions) toControllerOptions() (*controllerapi.BuildOptions, error
 		Pull:           o.pull,
 		ExportPush:     o.exportPush,
 		ExportLoad:     o.exportLoad,
+		Breakpoints:    o.breakpoints,
+	}
+	if o.invokeConfig != nil {
+		opts.InvokeDirs = o.invokeConfig.invokeDirs()
 	}
 
 	// TODO: extract env var parsing to a method easily usable by library consumers
@@ -295,6 +307,13 @@ func runBuild(ctx context.Context, dockerCli command.Cli, options buildOptions)
 		return err
 	}
 
+	var signKey *imagetools.Key
+	if options.signKey != "" {
+		if signKey, err = loadSignKey(options.signKey, opts); err != nil {
+			return err
+		}
+	}
+
 	// Avoid leaving a stale file if we eventually fail
 	if options.imageIDFile != "" {
 		if err := os.Remove(options.imageIDFile); err != nil && !os.IsNotExist(err) {
@@ -313,10 +332,18 @@ func runBuild(ctx context.Context, dockerCli command.Cli, options buildOptions)
 	if err != nil {
 		return err
 	}
-	_, err = b.LoadNodes(ctx)
+	nodes, err := b.LoadNodes(ctx)
 	if err != nil {
 		return err
 	}
+	if signKey != nil {
+		// drivers without attestations support push single manifests
+		for _, n := range nodes {
+			if n.Err == nil && n.Driver != nil && !n.Driver.Features(ctx)[driver.MultiPlatform] {
+				return errors.Errorf("sign-key is not supported by the %s driver, the pushed image would not be an index", b.Driver)
+			}
+		}
+	}
 	driverType := b.Driver
 
 	var term bool
@@ -365,6 +392,12 @@ func runBuild(ctx context.Context, dockerCli command.Cli, options buildOptions)
 		return retErr
 	}
 
+	if signKey != nil {
+		if err := signPushedImage(ctx, b, signKey, resp.ExporterResponse); err != nil {
+			return err
+		}
+	}
+
 	switch progressMode {
 	case progressui.RawJSONMode:
 		// no additional display
@@ -399,6 +432,113 @@ func runBuild(ctx context.Context, dockerCli command.Cli, options buildOptions)
 	return nil
 }
 
+// loadSignKey loads the private key the image pushed by the build is signed
+// with. Signatures are attached to an index, so the build must push one.
+func loadSignKey(fn string, opts *controllerapi.BuildOptions) (*imagetools.Key, error) {
+	push := opts.ExportPush
+	for _, e := range opts.Exports {
+		if e.Type == "registry" || (e.Type == "image" && e.Attrs["push"] == "true") {
+			push = true
+		}
+	}
+	if !push {
+		return nil, errors.New("sign-key requires the image to be pushed, use --push")
+	}
+	if ok, err := buildsIndex(opts); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("sign-key requires the pushed image to be an index, build for several platforms or with attestations")
+	}
+	dt, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	k, err := imagetools.LoadKey(dt)
+	if err != nil {
+		return nil, err
+	}
+	if !k.IsPrivate() {
+		return nil, errors.Errorf("%s is a public key and cannot be used to sign", fn)
+	}
+	return k, nil
+}
+
+// buildsIndex returns whether the build results in an index rather than a
+// single manifest: when it is for several platforms or has attestations,
+// including the default provenance one.
+func buildsIndex(opts *controllerapi.BuildOptions) (bool, error) {
+	if len(opts.Platforms) > 1 {
+		return true, nil
+	}
+	attests := controllerapi.CreateAttestations(opts.Attests)
+	for _, v := range attests {
+		if v != nil {
+			return true, nil
+		}
+	}
+	if _, ok := attests["provenance"]; ok {
+		return false, nil
+	}
+	if v, ok := os.LookupEnv("BUILDX_NO_DEFAULT_ATTESTATIONS"); ok {
+		noProv, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid BUILDX_NO_DEFAULT_ATTESTATIONS")
+		}
+		return !noProv, nil
+	}
+	return true, nil
+}
+
+// signPushedImage signs the index pushed by the build, like imagetools sign,
+// and pushes the signed index with the names of the image. The digest in the
+// exporter response is updated to the one of the signed index.
+func signPushedImage(ctx context.Context, b *builder.Builder, k *imagetools.Key, resp map[string]string) error {
+	dgst, err := digest.Parse(resp[exptypes.ExporterImageDigestKey])
+	if err != nil {
+		return errors.Wrap(err, "no pushed image to sign")
+	}
+	names := strings.Split(resp["image.name"], ",")
+	if names[0] == "" {
+		return errors.New("no pushed image to sign")
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+	r := imagetools.New(imageopt)
+
+	var u *imagetools.IndexUpdate
+	for _, name := range names {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return err
+		}
+		named = reference.TagNameOnly(named)
+		if u == nil {
+			src, err := reference.WithDigest(reference.TrimNamed(named), dgst)
+			if err != nil {
+				return err
+			}
+			if u, err = r.Sign(ctx, src.String(), k, nil); err != nil {
+				return errors.Wrapf(err, "failed to sign %s", src)
+			}
+		}
+		if err := r.PushUpdate(ctx, u, named); err != nil {
+			return errors.Wrapf(err, "failed to push signed index to %s", named)
+		}
+	}
+
+	resp[exptypes.ExporterImageDigestKey] = u.Desc.Digest.String()
+	if _, ok := resp[exptypes.ExporterImageDescriptorKey]; ok {
+		dt, err := json.Marshal(u.Desc)
+		if err != nil {
+			return err
+		}
+		resp[exptypes.ExporterImageDescriptorKey] = base64.StdEncoding.EncodeToString(dt)
+	}
+	return nil
+}
+
 // getImageID returns the image ID - the digest of the image config
 func getImageID(resp map[string]string) string {
 	dgst := resp[exptypes.ExporterImageDigestKey]
@@ -443,6 +583,18 @@ func runControllerBuild(ctx context.Context, dockerCli command.Cli, opts *contro
 	var resp *client.SolveResponse
 	var inputs *build.Inputs
 
+	var rec *monitor.Recorder
+	var buildProgress progress.Writer = printer
+	if options.invokeConfig != nil {
+		if rec, err = options.invokeConfig.newRecorder(); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to record debugging session")
+		}
+		if rec != nil {
+			defer rec.Close()
+			buildProgress = rec.Progress(printer)
+		}
+	}
+
 	var f *ioset.SingleForwarder
 	var pr io.ReadCloser
 	var pw io.WriteCloser
@@ -459,11 +611,11 @@ func runControllerBuild(ctx context.Context, dockerCli command.Cli, opts *contro
 		})
 	}
 
-	ref, resp, inputs, err = c.Build(ctx, opts, pr, printer)
+	ref, resp, inputs, err = c.Build(ctx, opts, pr, buildProgress)
 	if err != nil {
 		var be *controllererrors.BuildError
 		if errors.As(err, &be) {
-			ref = be.Ref
+			ref = be.SessionID
 			retErr = err
 			// We can proceed to monitor
 		} else {
@@ -480,7 +632,9 @@ func runControllerBuild(ctx context.Context, dockerCli command.Cli, opts *contro
 		}
 	}
 
-	if options.invokeConfig != nil && options.invokeConfig.needsDebug(retErr) {
+	if options.invokeConfig != nil && options.invokeConfig.needsDebug(retErr) && len(options.invokeConfig.exec) > 0 {
+		retErr = execResult(retErr, options.invokeConfig.runExec(ctx, ref, c, printer))
+	} else if options.invokeConfig != nil && options.invokeConfig.needsDebug(retErr) {
 		// Print errors before launching monitor
 		if err := printError(retErr, printer); err != nil {
 			logrus.Warnf("failed to print error information: %v", err)
@@ -491,7 +645,7 @@ func runControllerBuild(ctx context.Context, dockerCli command.Cli, opts *contro
 			pw2.Close() // propagate EOF
 			return nil
 		})
-		monitorBuildResult, err := options.invokeConfig.runDebug(ctx, ref, opts, c, pr2, os.Stdout, os.Stderr, printer)
+		monitorBuildResult, err := options.invokeConfig.runDebug(ctx, ref, opts, c, pr2, os.Stdout, os.Stderr, printer, rec)
 		if err := pw2.Close(); err != nil {
 			logrus.Debug("failed to close monitor stdin pipe reader")
 		}
@@ -519,6 +673,11 @@ func printError(err error, printer *progress.Printer) error {
 		return err
 	}
 	defer printer.Unpause()
+	var be *controllererrors.BuildError
+	if errors.As(err, &be) && be.Breakpoint != "" {
+		fmt.Fprintf(os.Stderr, "Build %v. Run \"continue\" in the monitor to resume it.\n", err)
+		return nil
+	}
 	for _, s := range errdefs.Sources(err) {
 		s.Print(os.Stderr)
 	}
@@ -566,12 +725,26 @@ func buildCmd(dockerCli command.Cli, rootOpts *rootOptions, debugConfig *debug.D
 			options.progress = cFlags.progress
 			cmd.Flags().VisitAll(checkWarnedFlags)
 
+			if debugConfig != nil && debugConfig.DAP {
+				return runDAPBuild(cmd.Context(), dockerCli, *options)
+			}
 			if debugConfig != nil && (debugConfig.InvokeFlag != "" || debugConfig.OnFlag != "") {
 				iConfig := new(invokeConfig)
 				if err := iConfig.parseInvokeConfig(debugConfig.InvokeFlag, debugConfig.OnFlag); err != nil {
 					return err
 				}
+				iConfig.exec = debugConfig.Exec
+				iConfig.execOutput = debugConfig.ExecOutput
+				iConfig.record = debugConfig.Record
+				iConfig.recordInput = debugConfig.RecordInput
+				if iConfig.record != "" && len(iConfig.exec) > 0 {
+					return errors.New("record can't be used with exec")
+				}
+				if iConfig.recordInput && iConfig.record == "" {
+					return errors.New("record-input requires record")
+				}
 				options.invokeConfig = iConfig
+				options.breakpoints = debugConfig.Breakpoints
 			}
 
 			return runBuild(cmd.Context(), dockerCli, *options)
@@ -628,6 +801,8 @@ func buildCmd(dockerCli command.Cli, rootOpts *rootOptions, debugConfig *debug.D
 
 	flags.Var(&options.shmSize, "shm-size", `Shared memory size for build containers`)
 
+	flags.StringVar(&options.signKey, "sign-key", "", "Sign the pushed image with the PEM encoded private key from file")
+
 	flags.StringArrayVar(&options.ssh, "ssh", []string{}, `SSH agent socket or keys to expose to the build (format: "default|<id>[=<socket>|<key>[,<key>]]")`)
 
 	flags.StringArrayVarP(&options.tags, "tag", "t", []string{}, `Name and optionally a tag (format: "name:tag")`)
@@ -991,8 +1166,12 @@ func printValue(w io.Writer, printer callFunc, version string, format string, re
 
 type invokeConfig struct {
 	controllerapi.InvokeConfig
-	onFlag     string
-	invokeFlag string
+	onFlag      string
+	invokeFlag  string
+	exec        []string
+	execOutput  string
+	record      string
+	recordInput bool
 }
 
 func (cfg *invokeConfig) needsDebug(retErr error) bool {
@@ -1006,7 +1185,16 @@ func (cfg *invokeConfig) needsDebug(retErr error) bool {
 	}
 }
 
-func (cfg *invokeConfig) runDebug(ctx context.Context, ref string, options *controllerapi.BuildOptions, c control.BuildxController, stdin io.ReadCloser, stdout io.WriteCloser, stderr console.File, progress *progress.Printer) (*monitor.MonitorBuildResult, error) {
+// newRecorder returns the recorder of the debugging session, or nil if it
+// isn't recorded.
+func (cfg *invokeConfig) newRecorder() (*monitor.Recorder, error) {
+	if cfg.record == "" {
+		return nil, nil
+	}
+	return monitor.NewRecorder(cfg.record, os.Stderr, cfg.recordInput)
+}
+
+func (cfg *invokeConfig) runDebug(ctx context.Context, ref string, options *controllerapi.BuildOptions, c control.BuildxController, stdin io.ReadCloser, stdout io.WriteCloser, stderr console.File, progress *progress.Printer, rec *monitor.Recorder) (*monitor.MonitorBuildResult, error) {
 	con := console.Current()
 	if err := con.SetRaw(); err != nil {
 		// TODO: run disconnect in build command (on error case)
@@ -1016,7 +1204,83 @@ func (cfg *invokeConfig) runDebug(ctx context.Context, ref string, options *cont
 		return nil, errors.Errorf("failed to configure terminal: %v", err)
 	}
 	defer con.Reset()
-	return monitor.RunMonitor(ctx, ref, options, &cfg.InvokeConfig, c, stdin, stdout, stderr, progress)
+	var opts []monitor.MonitorOpt
+	if rec != nil {
+		opts = append(opts, monitor.WithRecorder(rec))
+	}
+	return monitor.RunMonitor(ctx, ref, options, &cfg.InvokeConfig, c, stdin, stdout, stderr, progress, opts...)
+}
+
+// runExec runs the exec commands one by one in the container of the session,
+// without a terminal, and writes their output to the build progress or to the
+// exec output file. It returns the error of the first command that failed.
+func (cfg *invokeConfig) runExec(ctx context.Context, ref string, c control.BuildxController, printer *progress.Printer) (retErr error) {
+	defer func() {
+		if err := c.Disconnect(ctx, ref); err != nil {
+			logrus.Warnf("disconnect error: %v", err)
+		}
+	}()
+	var out io.Writer
+	if cfg.execOutput != "" {
+		f, err := os.Create(cfg.execOutput)
+		if err != nil {
+			return errors.Wrap(err, "failed to create exec output file")
+		}
+		defer f.Close()
+		out = f
+	}
+	for _, cmd := range cfg.exec {
+		err := progress.Wrap(fmt.Sprintf("[debug] exec %s", cmd), printer.Write, func(l progress.SubLogger) error {
+			var stdout, stderr io.WriteCloser = &logWriter{l, 1}, &logWriter{l, 2}
+			if out != nil {
+				fmt.Fprintf(out, "$ %s\n", cmd)
+				stdout, stderr = ioutils.NopWriteCloser(out), ioutils.NopWriteCloser(out)
+			}
+			execConfig := proto.Clone(&cfg.InvokeConfig).(*controllerapi.InvokeConfig)
+			execConfig.Entrypoint = []string{"/bin/sh", "-c", cmd}
+			execConfig.Cmd = []string{}
+			execConfig.NoCmd = false
+			execConfig.Tty = false
+			return c.Invoke(ctx, ref, identity.NewID(), execConfig, io.NopCloser(strings.NewReader("")), stdout, stderr)
+		})
+		if err != nil && retErr == nil {
+			retErr = errors.Wrapf(err, "failed to exec %q", cmd)
+		}
+	}
+	return retErr
+}
+
+// execResult returns the error of a debug session that ran exec commands
+// after a build that ended with buildErr. The build error takes precedence
+// over execErr, the error of the first command that failed. If that command
+// exited with a non-zero code, buildx exits with the same code.
+func execResult(buildErr, execErr error) error {
+	if buildErr != nil || execErr == nil {
+		return buildErr
+	}
+	var exitErr *gatewaypb.ExitError
+	if errors.As(execErr, &exitErr) && exitErr.ExitCode != 0 {
+		return cli.StatusError{
+			Status:     "ERROR: " + execErr.Error(),
+			StatusCode: int(exitErr.ExitCode),
+		}
+	}
+	return execErr
+}
+
+// logWriter writes to the logs of a progress vertex.
+type logWriter struct {
+	logger progress.SubLogger
+	stream int
+}
+
+func (l *logWriter) Write(dt []byte) (int, error) {
+	l.logger.Log(l.stream, append([]byte{}, dt...))
+	return len(dt), nil
+}
+
+func (l *logWriter) Close() error {
+	return nil
 }
 
 func (cfg *invokeConfig) parseInvokeConfig(invoke, on string) error {
@@ -1079,6 +1343,12 @@ func (cfg *invokeConfig) parseInvokeConfig(invoke, on string) error {
 			if err != nil {
 				return errors.Errorf("failed to parse tty: %v", err)
 			}
+		case "mount":
+			m, err := parseInvokeMount(value)
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse mount %q", value)
+			}
+			cfg.Mounts = append(cfg.Mounts, m)
 		default:
 			return errors.Errorf("unknown key %q", key)
 		}
@@ -1086,6 +1356,65 @@ func (cfg *invokeConfig) parseInvokeConfig(invoke, on string) error {
 	return nil
 }
 
+// parseInvokeMount parses a mount of the interactive container in the format
+// of the --mount flag of RUN, e.g. "type=copy,source=.,target=/src".
+func parseInvokeMount(v string) (*controllerapi.InvokeMount, error) {
+	fields, err := csvvalue.Fields(v, nil)
+	if err != nil {
+		return nil, err
+	}
+	m := &controllerapi.InvokeMount{Type: "copy"}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		switch strings.ToLower(key) {
+		case "type":
+			m.Type = value
+		case "source", "src", "id":
+			m.Source = value
+		case "target", "dst", "destination":
+			m.Target = value
+		case "readonly", "ro":
+			m.ReadOnly = true
+			if ok {
+				if m.ReadOnly, err = strconv.ParseBool(value); err != nil {
+					return nil, errors.Errorf("invalid value for %s: %s", key, value)
+				}
+			}
+		case "sharing":
+			m.Sharing = value
+		default:
+			return nil, errors.Errorf("unknown key %q", key)
+		}
+	}
+	switch m.Type {
+	case "copy":
+		if m.Source == "" || m.Target == "" {
+			return nil, errors.New("copy mount requires a source and a target")
+		}
+		// The directory is shared by the client with the build session.
+		if m.Source, err = filepath.Abs(m.Source); err != nil {
+			return nil, err
+		}
+	case "cache", "secret", "ssh":
+	case "bind":
+		return nil, errors.New("bind mounts of host directories aren't supported, use type=copy to copy a directory in the container")
+	default:
+		return nil, errors.Errorf("unsupported mount type %q", m.Type)
+	}
+	return m, nil
+}
+
+// invokeDirs returns the host directories copied in the interactive
+// container, that must be shared with the build.
+func (cfg *invokeConfig) invokeDirs() (dirs []string) {
+	for _, m := range cfg.Mounts {
+		if m.Type == "copy" {
+			dirs = append(dirs, m.Source)
+		}
+	}
+	return dirs
+}
+
 func maybeJSONArray(v string) []string {
 	var list []string
 	if err := json.Unmarshal([]byte(v), &list); err == nil {
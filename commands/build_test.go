@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/buildx/controller/control"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	gatewaypb "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// execController runs the exec commands of a debug session, failing the ones
+// that have an error in errs.
+type execController struct {
+	control.BuildxController
+	errs map[string]error
+
+	invoked      []*controllerapi.InvokeConfig
+	disconnected []string
+}
+
+func (c *execController) Invoke(_ context.Context, _, _ string, cfg *controllerapi.InvokeConfig, _ io.ReadCloser, stdout, _ io.WriteCloser) error {
+	c.invoked = append(c.invoked, cfg)
+	cmd := cfg.Entrypoint[len(cfg.Entrypoint)-1]
+	fmt.Fprintf(stdout, "ran %s\n", cmd)
+	return c.errs[cmd]
+}
+
+func (c *execController) Disconnect(_ context.Context, ref string) error {
+	c.disconnected = append(c.disconnected, ref)
+	return nil
+}
+
+func runTestExec(t *testing.T, cfg *invokeConfig, c *execController) error {
+	ctx := context.TODO()
+	printer, err := progress.NewPrinter(ctx, os.Stderr, progressui.QuietMode)
+	require.NoError(t, err)
+	err = cfg.runExec(ctx, "ref", c, printer)
+	require.NoError(t, printer.Wait())
+	return err
+}
+
+func TestRunExec(t *testing.T) {
+	c := &execController{errs: map[string]error{
+		"make test": &gatewaypb.ExitError{ExitCode: 2},
+		"false":     &gatewaypb.ExitError{ExitCode: 1},
+	}}
+	cfg := &invokeConfig{
+		InvokeConfig: controllerapi.InvokeConfig{Cwd: "/app", Tty: true},
+		exec:         []string{"ls", "make test", "false"},
+		execOutput:   filepath.Join(t.TempDir(), "exec.log"),
+	}
+	err := runTestExec(t, cfg, c)
+	require.ErrorContains(t, err, `failed to exec "make test"`)
+
+	require.Len(t, c.invoked, 3)
+	for i, cmd := range cfg.exec {
+		require.Equal(t, []string{"/bin/sh", "-c", cmd}, c.invoked[i].Entrypoint)
+		require.Equal(t, "/app", c.invoked[i].Cwd)
+		require.False(t, c.invoked[i].Tty)
+	}
+	require.Equal(t, []string{"ref"}, c.disconnected)
+
+	dt, err := os.ReadFile(cfg.execOutput)
+	require.NoError(t, err)
+	require.Equal(t, "$ ls\nran ls\n$ make test\nran make test\n$ false\nran false\n", string(dt))
+}
+
+func TestExecResult(t *testing.T) {
+	c := &execController{errs: map[string]error{
+		"make test": &gatewaypb.ExitError{ExitCode: 2},
+	}}
+	cfg := &invokeConfig{exec: []string{"make test"}}
+
+	// after a successful build, buildx exits with the code of the command
+	err := execResult(nil, runTestExec(t, cfg, c))
+	var statusErr cli.StatusError
+	require.True(t, errors.As(err, &statusErr), "%T", err)
+	require.Equal(t, 2, statusErr.StatusCode)
+	require.Contains(t, statusErr.Status, `failed to exec "make test"`)
+
+	// after a failed build, the commands run but the build error is kept
+	buildErr := errors.New("failed to solve")
+	require.Equal(t, buildErr, execResult(buildErr, runTestExec(t, cfg, c)))
+	require.Len(t, c.invoked, 2)
+
+	// errors without an exit code are returned as they are
+	c.errs["make test"] = errors.New("no running container")
+	err = execResult(nil, runTestExec(t, cfg, c))
+	require.ErrorContains(t, err, "no running container")
+	require.False(t, errors.As(err, &statusErr))
+
+	delete(c.errs, "make test")
+	require.NoError(t, execResult(nil, runTestExec(t, cfg, c)))
+	require.NoError(t, execResult(nil, nil))
+}
+
+func TestParseInvokeMount(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	m, err := parseInvokeMount("source=src,target=/app/src,readonly")
+	require.NoError(t, err)
+	require.Equal(t, "copy", m.Type)
+	require.Equal(t, filepath.Join(wd, "src"), m.Source)
+	require.Equal(t, "/app/src", m.Target)
+	require.True(t, m.ReadOnly)
+
+	m, err = parseInvokeMount("type=cache,target=/root/.npm,id=npm,sharing=locked")
+	require.NoError(t, err)
+	require.Equal(t, &controllerapi.InvokeMount{Type: "cache", Source: "npm", Target: "/root/.npm", Sharing: "locked"}, m)
+
+	cfg := &invokeConfig{InvokeConfig: controllerapi.InvokeConfig{Mounts: []*controllerapi.InvokeMount{
+		{Type: "copy", Source: "/src"},
+		{Type: "cache", Source: "npm"},
+	}}}
+	require.Equal(t, []string{"/src"}, cfg.invokeDirs())
+
+	_, err = parseInvokeMount("type=bind,source=.,target=/src")
+	require.ErrorContains(t, err, "use type=copy")
+	_, err = parseInvokeMount("type=copy,target=/src")
+	require.ErrorContains(t, err, "copy mount requires a source and a target")
+	_, err = parseInvokeMount("type=tmpfs,target=/tmp")
+	require.ErrorContains(t, err, `unsupported mount type "tmpfs"`)
+}
+
+func TestLoadSignKey(t *testing.T) {
+	t.Setenv("BUILDX_NO_DEFAULT_ATTESTATIONS", "")
+	os.Unsetenv("BUILDX_NO_DEFAULT_ATTESTATIONS")
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dt, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	fn := filepath.Join(t.TempDir(), "signing.key")
+	require.NoError(t, os.WriteFile(fn, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: dt}), 0600))
+
+	// the default provenance attestation makes the image an index
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true})
+	require.NoError(t, err)
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{
+		Exports: []*controllerapi.ExportEntry{{Type: "image", Attrs: map[string]string{"push": "true"}}},
+	})
+	require.NoError(t, err)
+
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{})
+	require.ErrorContains(t, err, "use --push")
+
+	noProvenance := []*controllerapi.Attest{{Type: "provenance", Disabled: true}}
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true, Attests: noProvenance})
+	require.ErrorContains(t, err, "requires the pushed image to be an index")
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true, Attests: noProvenance, Platforms: []string{"linux/amd64"}})
+	require.ErrorContains(t, err, "requires the pushed image to be an index")
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true, Attests: noProvenance, Platforms: []string{"linux/amd64", "linux/arm64"}})
+	require.NoError(t, err)
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true, Attests: append(noProvenance, &controllerapi.Attest{Type: "sbom"})})
+	require.NoError(t, err)
+
+	t.Setenv("BUILDX_NO_DEFAULT_ATTESTATIONS", "1")
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true})
+	require.ErrorContains(t, err, "requires the pushed image to be an index")
+	_, err = loadSignKey(fn, &controllerapi.BuildOptions{ExportPush: true, Attests: []*controllerapi.Attest{{Type: "provenance", Attrs: "mode=max"}}})
+	require.NoError(t, err)
+}
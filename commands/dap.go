@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/containerd/console"
+	"github.com/docker/buildx/commands/debug"
+	cbuild "github.com/docker/buildx/controller/build"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/dap"
+	"github.com/docker/buildx/util/cobrautil"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+func dapCmd(dockerCli command.Cli, children ...debug.DebuggableCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dap",
+		Short: "Start a Debug Adapter Protocol server",
+		Args:  cobra.NoArgs,
+	}
+	cobrautil.MarkCommandExperimental(cmd)
+
+	for _, c := range children {
+		cmd.AddCommand(c.NewDebugger(&debug.DebugConfig{DAP: true}))
+	}
+	cmd.AddCommand(dapAttachCmd())
+	return cmd
+}
+
+func runDAPBuild(ctx context.Context, dockerCli command.Cli, options buildOptions) error {
+	opts, err := options.toControllerOptions()
+	if err != nil {
+		return err
+	}
+	if opts.ContextPath == "-" || opts.DockerfileName == "-" {
+		return errors.New("reading the build context or Dockerfile from stdin is not supported by the debug adapter")
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	s := dap.NewServer(os.Stdin, os.Stdout, dap.Opt{
+		Launch: dap.LaunchConfig{
+			Dockerfile:  opts.DockerfileName,
+			ContextPath: opts.ContextPath,
+			Target:      opts.Target,
+			Args:        opts.BuildArgs,
+		},
+		Build: func(ctx context.Context, cfg dap.LaunchConfig, bps []string, resumeFrom string, pw progress.Writer) (dap.Build, error) {
+			opts := proto.Clone(opts).(*controllerapi.BuildOptions)
+			opts.DockerfileName = cfg.Dockerfile
+			opts.ContextPath = cfg.ContextPath
+			opts.Target = cfg.Target
+			opts.BuildArgs = cfg.Args
+			opts.Breakpoints = bps
+			opts.ResumeFrom = resumeFrom
+
+			_, res, _, err := cbuild.RunBuild(ctx, dockerCli, opts, nil, pw, true)
+			if res == nil {
+				return nil, err
+			}
+			return dap.NewBuild(res), err
+		},
+		AttachCommand: []string{self, "dap", "attach"},
+	})
+	return s.Serve(ctx)
+}
+
+func dapAttachCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach SOCKET",
+		Short: "Attach the terminal to a shell of a debug session",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			con := console.Current()
+			if err := con.SetRaw(); err != nil {
+				return errors.Errorf("failed to configure terminal: %v", err)
+			}
+			defer con.Reset()
+			return dap.Attach(cmd.Context(), args[0], con, con)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,80 @@
+package debug
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	"github.com/containerd/console"
+	"github.com/docker/buildx/controller"
+	"github.com/docker/buildx/controller/control"
+	"github.com/docker/buildx/monitor"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// statusFollower is a controller that can follow the progress of a build
+// started by another client.
+type statusFollower interface {
+	Status(ctx context.Context, ref string, w progress.Writer) error
+}
+
+func attachCmd(dockerCli command.Cli, controlOptions *control.ControlOptions, progressMode *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach SESSION",
+		Short: "Attach to a session of the buildx server",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAttach(cmd.Context(), dockerCli, *controlOptions, *progressMode, args[0])
+		},
+	}
+	return cmd
+}
+
+func runAttach(ctx context.Context, dockerCli command.Cli, controlOptions control.ControlOptions, progressMode string, ref string) error {
+	if !controlOptions.Detach {
+		return errors.New("attach requires the detached buildx server")
+	}
+	printer, err := progress.NewPrinter(context.TODO(), os.Stderr, progressui.DisplayMode(progressMode))
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.NewController(ctx, controlOptions, dockerCli, printer)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logrus.Warnf("failed to close server connection %v", err)
+		}
+	}()
+
+	refs, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(refs, ref) {
+		return errors.Errorf("unknown session %q", ref)
+	}
+
+	// Follow the build of the session until it finishes or pauses.
+	if f, ok := c.(statusFollower); ok {
+		if err := f.Status(ctx, ref, printer); err != nil {
+			return err
+		}
+	}
+
+	con := console.Current()
+	if err := con.SetRaw(); err != nil {
+		return errors.Errorf("failed to configure terminal: %v", err)
+	}
+	err = monitor.AttachMonitor(ctx, ref, c, dockerCli.In(), os.Stdout, os.Stderr, printer)
+	con.Reset()
+	return err
+}
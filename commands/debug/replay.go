@@ -0,0 +1,81 @@
+package debug
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/buildx/util/asciicast"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type replayOptions struct {
+	speed       float64
+	idleTimeMax time.Duration
+}
+
+func replayCmd(dockerCli command.Cli) *cobra.Command {
+	var options replayOptions
+
+	cmd := &cobra.Command{
+		Use:   "replay FILE",
+		Short: "Replay a debugging session recorded with --record",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReplay(cmd.Context(), dockerCli, options, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.Float64Var(&options.speed, "speed", 1, "Playback speed")
+	flags.DurationVar(&options.idleTimeMax, "idle-time-limit", 0, "Limit the time between two outputs of the session")
+
+	return cmd
+}
+
+func runReplay(ctx context.Context, dockerCli command.Cli, options replayOptions, path string) error {
+	if options.speed <= 0 {
+		return errors.Errorf("invalid speed %v", options.speed)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := asciicast.NewReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", path)
+	}
+	out := dockerCli.Out()
+	var last time.Duration
+	for {
+		e, err := r.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		if e.Type != asciicast.Output {
+			continue
+		}
+		wait := e.Time - last
+		if options.idleTimeMax > 0 && wait > options.idleTimeMax {
+			wait = options.idleTimeMax
+		}
+		last = e.Time
+		select {
+		case <-time.After(time.Duration(float64(wait) / options.speed)):
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+		if _, err := io.WriteString(out, e.Data); err != nil {
+			return err
+		}
+	}
+}
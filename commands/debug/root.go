@@ -26,6 +26,24 @@ type DebugConfig struct {
 
 	// OnFlag is a flag to configure the timing of launching the debugger.
 	OnFlag string
+
+	// Breakpoints are the Dockerfile lines and stages the build pauses at.
+	Breakpoints []string
+
+	// Exec is the list of shell commands run in the debug container instead of launching the monitor.
+	Exec []string
+
+	// ExecOutput is the file the output of Exec is written to instead of the build progress.
+	ExecOutput string
+
+	// Record is the file the debugging session is recorded to, in the asciicast format.
+	Record string
+
+	// RecordInput records the input of the debugging session in addition to its output.
+	RecordInput bool
+
+	// DAP serves the Debug Adapter Protocol on the standard input and output instead of launching the monitor.
+	DAP bool
 }
 
 // DebuggableCmd is a command that supports debugger with recognizing the user-specified DebugConfig.
@@ -44,6 +62,21 @@ func RootCmd(dockerCli command.Cli, children ...DebuggableCmd) *cobra.Command {
 		Short: "Start debugger",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(options.Exec) > 0 {
+				return errors.New("exec requires a build subcommand")
+			}
+			var monitorOpts []monitor.MonitorOpt
+			if options.RecordInput && options.Record == "" {
+				return errors.New("record-input requires record")
+			}
+			if options.Record != "" {
+				rec, err := monitor.NewRecorder(options.Record, os.Stderr, options.RecordInput)
+				if err != nil {
+					return err
+				}
+				defer rec.Close()
+				monitorOpts = append(monitorOpts, monitor.WithRecorder(rec))
+			}
 			printer, err := progress.NewPrinter(context.TODO(), os.Stderr, progressui.DisplayMode(progressMode))
 			if err != nil {
 				return err
@@ -66,7 +99,7 @@ func RootCmd(dockerCli command.Cli, children ...DebuggableCmd) *cobra.Command {
 
 			_, err = monitor.RunMonitor(ctx, "", nil, &controllerapi.InvokeConfig{
 				Tty: true,
-			}, c, dockerCli.In(), os.Stdout, os.Stderr, printer)
+			}, c, dockerCli.In(), os.Stdout, os.Stderr, printer, monitorOpts...)
 			con.Reset()
 			return err
 		},
@@ -76,17 +109,24 @@ func RootCmd(dockerCli command.Cli, children ...DebuggableCmd) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.InvokeFlag, "invoke", "", "Launch a monitor with executing specified command")
 	flags.StringVar(&options.OnFlag, "on", "error", "When to launch the monitor ([always, error])")
+	flags.StringArrayVar(&options.Breakpoints, "break", nil, `Pause the build after a Dockerfile instruction ("LINE", "FILE:LINE", a stage name or "*" for every instruction)`)
 
+	flags.StringArrayVar(&options.Exec, "exec", nil, "Run a shell command in the debug container instead of launching the monitor")
+	flags.StringVar(&options.ExecOutput, "exec-output", "", "Write the output of the exec commands to a file instead of the build output")
+	flags.StringVar(&options.Record, "record", "", "Record the debugging session to a file in the asciicast format")
+	flags.BoolVar(&options.RecordInput, "record-input", false, "Record the input of the debugging session, including what is typed in the containers")
 	flags.StringVar(&controlOptions.Root, "root", "", "Specify root directory of server to connect for the monitor")
 	flags.BoolVar(&controlOptions.Detach, "detach", runtime.GOOS == "linux", "Detach buildx server for the monitor (supported only on linux)")
 	flags.StringVar(&controlOptions.ServerConfig, "server-config", "", "Specify buildx server config file for the monitor (used only when launching new server)")
 	flags.StringVar(&progressMode, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "rawjson") for the monitor. Use plain to show container output`)
 
-	cobrautil.MarkFlagsExperimental(flags, "invoke", "on", "root", "detach", "server-config")
+	cobrautil.MarkFlagsExperimental(flags, "invoke", "on", "break", "exec", "exec-output", "record", "record-input", "root", "detach", "server-config")
 
 	for _, c := range children {
 		cmd.AddCommand(c.NewDebugger(&options))
 	}
+	cmd.AddCommand(attachCmd(dockerCli, &controlOptions, &progressMode))
+	cmd.AddCommand(replayCmd(dockerCli))
 
 	return cmd
 }
@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/buildx/util/platformutil"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+type attestOptions struct {
+	builder       string
+	file          string
+	key           string
+	predicateType string
+	platforms     []string
+	tags          []string
+	dryrun        bool
+	progress      string
+	format        string
+}
+
+func runAttestAdd(ctx context.Context, dockerCli command.Cli, in attestOptions, name string) error {
+	dt, err := os.ReadFile(in.file)
+	if err != nil {
+		return err
+	}
+	return runAttestUpdate(ctx, dockerCli, in, name, func(ctx context.Context, r *imagetools.Resolver) (*imagetools.IndexUpdate, error) {
+		ps, err := platformutil.Parse(in.platforms)
+		if err != nil {
+			return nil, err
+		}
+		return r.AddAttestation(ctx, name, dt, ps)
+	})
+}
+
+func runAttestRm(ctx context.Context, dockerCli command.Cli, in attestOptions, name string) error {
+	return runAttestUpdate(ctx, dockerCli, in, name, func(ctx context.Context, r *imagetools.Resolver) (*imagetools.IndexUpdate, error) {
+		ps, err := platformutil.Parse(in.platforms)
+		if err != nil {
+			return nil, err
+		}
+		return r.RemoveAttestations(ctx, name, in.predicateType, ps)
+	})
+}
+
+func runAttestUpdate(ctx context.Context, dockerCli command.Cli, in attestOptions, name string, fn func(context.Context, *imagetools.Resolver) (*imagetools.IndexUpdate, error)) error {
+	tagNames := in.tags
+	if len(tagNames) == 0 {
+		tagNames = []string{name}
+	}
+	tags, err := parseRefs(tagNames)
+	if err != nil {
+		return err
+	}
+	if _, ok := tags[0].(reference.Digested); ok && len(in.tags) == 0 {
+		return errors.Errorf("%s is referenced by digest, please set --tag for the new index", name)
+	}
+
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+
+	u, err := fn(ctx, imagetools.New(imageopt))
+	if err != nil {
+		return err
+	}
+
+	if in.dryrun {
+		fmt.Printf("%s\n", u.Data)
+		return nil
+	}
+
+	// new resolver cause need new auth
+	r := imagetools.New(imageopt)
+
+	ctx2, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	printer, err := progress.NewPrinter(ctx2, os.Stderr, progressui.DisplayMode(in.progress))
+	if err != nil {
+		return err
+	}
+
+	eg, _ := errgroup.WithContext(ctx)
+	pw := progress.WithPrefix(printer, "internal", true)
+
+	for _, t := range tags {
+		t := reference.TagNameOnly(t)
+		eg.Go(func() error {
+			return progress.Wrap(fmt.Sprintf("pushing %s", t.String()), pw.Write, func(sub progress.SubLogger) error {
+				sub.Log(1, []byte(fmt.Sprintf("pushing %s to %s\n", u.Desc.Digest.String(), t.String())))
+				return r.PushUpdate(ctx, u, t)
+			})
+		})
+	}
+
+	err = eg.Wait()
+	err1 := printer.Wait()
+	if err == nil {
+		err = err1
+	}
+
+	return err
+}
+
+func runAttestLs(ctx context.Context, dockerCli command.Cli, in attestOptions, name string) error {
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+
+	atts, err := imagetools.New(imageopt).ListAttestations(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	switch in.format {
+	case "json":
+		enc := json.NewEncoder(dockerCli.Out())
+		enc.SetIndent("", "  ")
+		return enc.Encode(atts)
+	case "", "table":
+	default:
+		return errors.Errorf("unsupported format %q", in.format)
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PLATFORM\tPREDICATE TYPE\tMEDIA TYPE\tDIGEST")
+	for _, a := range atts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Platform, a.PredicateType, a.Layer.MediaType, a.Layer.Digest)
+	}
+	return w.Flush()
+}
+
+func attestCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "attest",
+		Short:             "Manage attestations of an image in the registry",
+		ValidArgsFunction: completion.Disable,
+	}
+
+	cmd.AddCommand(
+		attestAddCmd(dockerCli, opts),
+		attestRmCmd(dockerCli, opts),
+		attestLsCmd(dockerCli, opts),
+	)
+
+	return cmd
+}
+
+func attestAddCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	var options attestOptions
+
+	cmd := &cobra.Command{
+		Use:   "add [OPTIONS] --file STATEMENT IMAGE",
+		Short: "Attach an in-toto statement to the images of an index",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *opts.Builder
+			return runAttestAdd(cmd.Context(), dockerCli, options, args[0])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.file, "file", "f", "", "Read the in-toto statement or its DSSE envelope from file")
+	cmd.MarkFlagRequired("file")
+	addAttestUpdateFlags(cmd, &options)
+
+	return cmd
+}
+
+func attestRmCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	var options attestOptions
+
+	cmd := &cobra.Command{
+		Use:   "rm [OPTIONS] --predicate-type TYPE IMAGE",
+		Short: "Remove attestations from the images of an index",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *opts.Builder
+			return runAttestRm(cmd.Context(), dockerCli, options, args[0])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.predicateType, "predicate-type", "", "Predicate type of the attestations to remove")
+	cmd.MarkFlagRequired("predicate-type")
+	addAttestUpdateFlags(cmd, &options)
+
+	return cmd
+}
+
+func attestLsCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	var options attestOptions
+
+	cmd := &cobra.Command{
+		Use:   "ls [OPTIONS] IMAGE",
+		Short: "List attestations of the images of an index",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *opts.Builder
+			return runAttestLs(cmd.Context(), dockerCli, options, args[0])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", "table", `Format the output ("table", "json")`)
+
+	return cmd
+}
+
+func addAttestUpdateFlags(cmd *cobra.Command, options *attestOptions) {
+	flags := cmd.Flags()
+	flags.StringArrayVar(&options.platforms, "platform", []string{}, "Only update the images of the given platforms")
+	flags.StringArrayVarP(&options.tags, "tag", "t", []string{}, "Set reference for the new index (defaults to the updated image)")
+	flags.BoolVar(&options.dryrun, "dry-run", false, "Show final index instead of pushing")
+	flags.StringVar(&options.progress, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "rawjson"). Use plain to show container output`)
+}
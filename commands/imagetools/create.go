@@ -5,15 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/distribution/reference"
 	"github.com/docker/buildx/builder"
 	"github.com/docker/buildx/util/buildflags"
 	"github.com/docker/buildx/util/cobrautil/completion"
 	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/buildx/util/platformutil"
 	"github.com/docker/buildx/util/progress"
 	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -31,9 +35,26 @@ type createOptions struct {
 	actionAppend bool
 	progress     string
 	preferIndex  bool
+	platforms    []string
+	mirror       string
+	labels       []string
+	env          []string
+	user         *string
+	entrypoint   *string
+	created      string
 }
 
 func runCreate(ctx context.Context, dockerCli command.Cli, in createOptions, args []string) error {
+	if in.mirror != "" {
+		if len(args) > 0 || len(in.files) > 0 || len(in.tags) > 0 || in.actionAppend {
+			return errors.Errorf("sources, tags and append cannot be used with mirror")
+		}
+		if len(in.labels) > 0 || len(in.env) > 0 || in.user != nil || in.entrypoint != nil || in.created != "" {
+			return errors.Errorf("label, env, user, entrypoint and created cannot be used with mirror")
+		}
+		return runMirror(ctx, dockerCli, in)
+	}
+
 	if len(args) == 0 && len(in.files) == 0 {
 		return errors.Errorf("no sources specified")
 	}
@@ -97,7 +118,7 @@ func runCreate(ctx context.Context, dockerCli command.Cli, in createOptions, arg
 			if defaultRepo == nil {
 				return errors.Errorf("multiple repositories specified, cannot infer repository for %q", args[i])
 			}
-			n, err := reference.ParseNormalizedNamed(*defaultRepo)
+			n, err := imagetools.ParseRef(*defaultRepo)
 			if err != nil {
 				return err
 			}
@@ -160,11 +181,31 @@ func runCreate(ctx context.Context, dockerCli command.Cli, in createOptions, arg
 		return errors.Wrapf(err, "failed to parse annotations")
 	}
 
+	rw, err := parseRewrite(in, annotations)
+	if err != nil {
+		return err
+	}
+
+	if len(in.platforms) > 0 {
+		srcs, err = filterPlatforms(ctx, r, srcs, in.platforms, annotations)
+		if err != nil {
+			return err
+		}
+	}
+
 	dt, desc, err := r.Combine(ctx, srcs, annotations, in.preferIndex)
 	if err != nil {
 		return err
 	}
 
+	var blobs []imagetools.Blob
+	if !rw.IsZero() {
+		dt, desc, blobs, err = r.Rewrite(ctx, srcs, dt, desc, rw)
+		if err != nil {
+			return err
+		}
+	}
+
 	if in.dryrun {
 		fmt.Printf("%s\n", dt)
 		return nil
@@ -187,23 +228,7 @@ func runCreate(ctx context.Context, dockerCli command.Cli, in createOptions, arg
 		t := t
 		eg.Go(func() error {
 			return progress.Wrap(fmt.Sprintf("pushing %s", t.String()), pw.Write, func(sub progress.SubLogger) error {
-				eg2, _ := errgroup.WithContext(ctx)
-				for _, s := range srcs {
-					if reference.Domain(s.Ref) == reference.Domain(t) && reference.Path(s.Ref) == reference.Path(t) {
-						continue
-					}
-					s := s
-					eg2.Go(func() error {
-						sub.Log(1, []byte(fmt.Sprintf("copying %s from %s to %s\n", s.Desc.Digest.String(), s.Ref.String(), t.String())))
-						return r.Copy(ctx, s, t)
-					})
-				}
-
-				if err := eg2.Wait(); err != nil {
-					return err
-				}
-				sub.Log(1, []byte(fmt.Sprintf("pushing %s to %s\n", desc.Digest.String(), t.String())))
-				return r.Push(ctx, t, desc, dt)
+				return copyAndPush(ctx, r, sub, srcs, blobs, t, desc, dt)
 			})
 		})
 	}
@@ -217,6 +242,129 @@ func runCreate(ctx context.Context, dockerCli command.Cli, in createOptions, arg
 	return err
 }
 
+// copyAndPush copies the sources to the repository of t if needed, pushes
+// the rewritten configs and manifests and then the combined manifest with the
+// tag of t.
+func copyAndPush(ctx context.Context, r *imagetools.Resolver, sub progress.SubLogger, srcs []*imagetools.Source, blobs []imagetools.Blob, t reference.Named, desc ocispec.Descriptor, dt []byte) error {
+	eg, _ := errgroup.WithContext(ctx)
+	for _, s := range srcs {
+		if reference.Domain(s.Ref) == reference.Domain(t) && reference.Path(s.Ref) == reference.Path(t) {
+			continue
+		}
+		s := s
+		eg.Go(func() error {
+			sub.Log(1, []byte(fmt.Sprintf("copying %s from %s to %s\n", s.Desc.Digest.String(), s.Ref.String(), t.String())))
+			return r.Copy(ctx, s, t)
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	for _, b := range blobs {
+		ref, err := reference.WithDigest(reference.TrimNamed(t), b.Desc.Digest)
+		if err != nil {
+			return err
+		}
+		if err := r.Push(ctx, ref, b.Desc, b.Data); err != nil {
+			return err
+		}
+	}
+	sub.Log(1, []byte(fmt.Sprintf("pushing %s to %s\n", desc.Digest.String(), t.String())))
+	return r.Push(ctx, t, desc, dt)
+}
+
+// filterPlatforms only keeps the manifests of the sources matching the given
+// platforms. The annotations of the filtered indexes are added to the
+// annotations of the new image unless they are already set.
+func filterPlatforms(ctx context.Context, r *imagetools.Resolver, srcs []*imagetools.Source, platformSpecs []string, annotations map[exptypes.AnnotationKey]string) ([]*imagetools.Source, error) {
+	ps, err := platformutil.Parse(platformSpecs)
+	if err != nil {
+		return nil, err
+	}
+	srcs, indexAnnotations, err := r.FilterPlatforms(ctx, srcs, ps)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range indexAnnotations {
+		if _, ok := annotations[k]; !ok {
+			annotations[k] = v
+		}
+	}
+	return srcs, nil
+}
+
+// parseRewrite returns the changes to make to the images. Manifest
+// annotations are moved from annotations to the rewrite as they require new
+// manifests.
+func parseRewrite(in createOptions, annotations map[exptypes.AnnotationKey]string) (*imagetools.Rewrite, error) {
+	rw := &imagetools.Rewrite{
+		Env:  in.env,
+		User: in.user,
+	}
+	for _, l := range in.labels {
+		k, v, ok := strings.Cut(l, "=")
+		if !ok || k == "" {
+			return nil, errors.Errorf("invalid label %q, expected key=value", l)
+		}
+		if rw.Labels == nil {
+			rw.Labels = make(map[string]string)
+		}
+		rw.Labels[k] = v
+	}
+	for _, e := range in.env {
+		if k, _, ok := strings.Cut(e, "="); !ok || k == "" {
+			return nil, errors.Errorf("invalid env %q, expected key=value", e)
+		}
+	}
+	if in.entrypoint != nil {
+		ep := *in.entrypoint
+		switch {
+		case strings.HasPrefix(ep, "["):
+			if err := json.Unmarshal([]byte(ep), &rw.Entrypoint); err != nil {
+				return nil, errors.Wrapf(err, "invalid entrypoint %q", ep)
+			}
+			if rw.Entrypoint == nil {
+				rw.Entrypoint = []string{}
+			}
+		case ep == "":
+			rw.Entrypoint = []string{}
+		default:
+			rw.Entrypoint = []string{ep}
+		}
+	}
+	if in.created != "" {
+		created, err := parseCreated(in.created)
+		if err != nil {
+			return nil, err
+		}
+		rw.Created = &created
+	}
+	for k, v := range annotations {
+		if k.Type == exptypes.AnnotationManifest {
+			if rw.Annotations == nil {
+				rw.Annotations = make(map[exptypes.AnnotationKey]string)
+			}
+			rw.Annotations[k] = v
+			delete(annotations, k)
+		}
+	}
+	return rw, nil
+}
+
+// parseCreated parses a RFC 3339 timestamp or a Unix timestamp in seconds
+// like SOURCE_DATE_EPOCH.
+func parseCreated(in string) (time.Time, error) {
+	if sec, err := strconv.ParseInt(in, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, in)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid created timestamp %q, expected RFC 3339 or Unix timestamp", in)
+	}
+	return t, nil
+}
+
 func parseSources(in []string) ([]*imagetools.Source, error) {
 	out := make([]*imagetools.Source, len(in))
 	for i, in := range in {
@@ -232,7 +380,7 @@ func parseSources(in []string) ([]*imagetools.Source, error) {
 func parseRefs(in []string) ([]reference.Named, error) {
 	refs := make([]reference.Named, len(in))
 	for i, in := range in {
-		n, err := reference.ParseNormalizedNamed(in)
+		n, err := imagetools.ParseRef(in)
 		if err != nil {
 			return nil, err
 		}
@@ -254,7 +402,7 @@ func parseSource(in string) (*imagetools.Source, error) {
 		return nil, err
 	}
 
-	ref, err := reference.ParseNormalizedNamed(in)
+	ref, err := imagetools.ParseRef(in)
 	if err == nil {
 		return &imagetools.Source{
 			Ref: ref,
@@ -272,12 +420,20 @@ func parseSource(in string) (*imagetools.Source, error) {
 
 func createCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
 	var options createOptions
+	var user, entrypoint string
 
 	cmd := &cobra.Command{
 		Use:   "create [OPTIONS] [SOURCE] [SOURCE...]",
 		Short: "Create a new image based on source images",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.builder = *opts.Builder
+			// an empty user or entrypoint resets it
+			if cmd.Flags().Changed("user") {
+				options.user = &user
+			}
+			if cmd.Flags().Changed("entrypoint") {
+				options.entrypoint = &entrypoint
+			}
 			return runCreate(cmd.Context(), dockerCli, options, args)
 		},
 		ValidArgsFunction: completion.Disable,
@@ -291,6 +447,13 @@ func createCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
 	flags.StringVar(&options.progress, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "rawjson"). Use plain to show container output`)
 	flags.StringArrayVarP(&options.annotations, "annotation", "", []string{}, "Add annotation to the image")
 	flags.BoolVar(&options.preferIndex, "prefer-index", true, "When only a single source is specified, prefer outputting an image index or manifest list instead of performing a carbon copy")
+	flags.StringArrayVar(&options.platforms, "platform", []string{}, "Only include the manifests of the given platforms")
+	flags.StringVar(&options.mirror, "mirror", "", "Copy the images listed in a file with source and destination references")
+	flags.StringArrayVar(&options.labels, "label", []string{}, "Set a label in the config of the images")
+	flags.StringArrayVar(&options.env, "env", []string{}, "Set an environment variable in the config of the images")
+	flags.StringVar(&user, "user", "", "Set the user in the config of the images")
+	flags.StringVar(&entrypoint, "entrypoint", "", "Set the entrypoint in the config of the images")
+	flags.StringVar(&options.created, "created", "", "Set the creation time of the images (RFC 3339 or Unix timestamp)")
 
 	return cmd
 }
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type diffOptions struct {
+	builder string
+	format  string
+}
+
+func runDiff(ctx context.Context, dockerCli command.Cli, in diffOptions, oldRef, newRef string) error {
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+
+	d, err := imagetools.New(imageopt).Diff(ctx, oldRef, newRef)
+	if err != nil {
+		return err
+	}
+	return d.Print(in.format, dockerCli.Out())
+}
+
+func diffCmd(dockerCli command.Cli, rootOpts RootOptions) *cobra.Command {
+	var options diffOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff [OPTIONS] OLD NEW",
+		Short: "Show differences between two images in the registry",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *rootOpts.Builder
+			return runDiff(cmd.Context(), dockerCli, options, args[0], args[1])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", "text", `Format the output ("text", "json")`)
+
+	return cmd
+}
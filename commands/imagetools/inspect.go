@@ -14,15 +14,31 @@ import (
 )
 
 type inspectOptions struct {
-	builder string
-	format  string
-	raw     bool
+	builder    string
+	format     string
+	raw        bool
+	sbom       string
+	sbomFilter []string
+	tree       bool
 }
 
 func runInspect(ctx context.Context, dockerCli command.Cli, in inspectOptions, name string) error {
 	if in.format != "" && in.raw {
 		return errors.Errorf("format and raw cannot be used together")
 	}
+	if in.tree && (in.raw || in.sbom != "") {
+		return errors.Errorf("tree cannot be used with raw or sbom")
+	}
+	if in.sbom != "" && (in.format != "" || in.raw) {
+		return errors.Errorf("sbom cannot be used with format or raw")
+	}
+	if len(in.sbomFilter) > 0 && in.sbom == "" {
+		return errors.Errorf("sbom-filter requires sbom to be set")
+	}
+	filter, err := imagetools.ParsePackageFilter(in.sbomFilter)
+	if err != nil {
+		return err
+	}
 
 	b, err := builder.New(dockerCli, builder.WithName(in.builder))
 	if err != nil {
@@ -38,7 +54,14 @@ func runInspect(ctx context.Context, dockerCli command.Cli, in inspectOptions, n
 		return err
 	}
 
-	return p.Print(in.raw, dockerCli.Out())
+	switch {
+	case in.tree:
+		return p.PrintTree(dockerCli.Out())
+	case in.sbom != "":
+		return p.PrintSBOM(in.sbom, filter, dockerCli.Out())
+	default:
+		return p.Print(in.raw, dockerCli.Out())
+	}
 }
 
 func inspectCmd(dockerCli command.Cli, rootOpts RootOptions) *cobra.Command {
@@ -62,5 +85,10 @@ func inspectCmd(dockerCli command.Cli, rootOpts RootOptions) *cobra.Command {
 
 	flags.BoolVar(&options.raw, "raw", false, "Show original, unformatted JSON manifest")
 
+	flags.BoolVar(&options.tree, "tree", false, `Show the tree of manifests, configs, layers, attestations and referrers with their sizes. Use "--format json" for JSON output`)
+
+	flags.StringVar(&options.sbom, "sbom", "", `Show the packages of the SBOM attestations or export them ("packages", "packages-json", "spdx-json", "cyclonedx-json")`)
+	flags.StringArrayVar(&options.sbomFilter, "sbom-filter", []string{}, `Filter the SBOM packages by "name", "version" or "license" pattern (e.g., "name=openssl")`)
+
 	return cmd
 }
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/buildflags"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/util/progress/progressui"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// mirrorConcurrency is the maximum number of images copied at the same time
+// in mirror mode.
+const mirrorConcurrency = 4
+
+type mirrorEntry struct {
+	src  reference.Named
+	dest reference.Named
+
+	srcs []*imagetools.Source
+	desc ocispec.Descriptor
+	dt   []byte
+}
+
+func runMirror(ctx context.Context, dockerCli command.Cli, in createOptions) error {
+	dt, err := os.ReadFile(in.mirror)
+	if err != nil {
+		return err
+	}
+	entries, err := parseMirrorFile(dt)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse %s", in.mirror)
+	}
+
+	annotations, err := buildflags.ParseAnnotations(in.annotations)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse annotations")
+	}
+
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+
+	r := imagetools.New(imageopt)
+
+	eg, ctx2 := errgroup.WithContext(ctx)
+	eg.SetLimit(mirrorConcurrency)
+	for _, e := range entries {
+		e := e
+		eg.Go(func() error {
+			_, desc, err := r.Resolve(ctx2, e.src.String())
+			if err != nil {
+				return err
+			}
+			e.srcs = []*imagetools.Source{{Ref: e.src, Desc: desc}}
+			ann := maps.Clone(annotations)
+			if len(in.platforms) > 0 {
+				e.srcs, err = filterPlatforms(ctx2, r, e.srcs, in.platforms, ann)
+				if err != nil {
+					return errors.Wrapf(err, "failed to filter %s", e.src)
+				}
+			}
+			e.dt, e.desc, err = r.Combine(ctx2, e.srcs, ann, false)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if in.dryrun {
+		for _, e := range entries {
+			fmt.Fprintf(dockerCli.Out(), "%s -> %s (%s)\n", e.src, e.dest, e.desc.Digest)
+		}
+		return nil
+	}
+
+	// new resolver cause need new auth
+	r = imagetools.New(imageopt)
+
+	ctx2, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	printer, err := progress.NewPrinter(ctx2, os.Stderr, progressui.DisplayMode(in.progress))
+	if err != nil {
+		return err
+	}
+
+	eg, _ = errgroup.WithContext(ctx)
+	eg.SetLimit(mirrorConcurrency)
+	pw := progress.WithPrefix(printer, "internal", true)
+
+	for _, e := range entries {
+		e := e
+		eg.Go(func() error {
+			return progress.Wrap(fmt.Sprintf("mirroring %s to %s", e.src, e.dest), pw.Write, func(sub progress.SubLogger) error {
+				return copyAndPush(ctx, r, sub, e.srcs, nil, e.dest, e.desc, e.dt)
+			})
+		})
+	}
+
+	err = eg.Wait()
+	err1 := printer.Wait()
+	if err == nil {
+		err = err1
+	}
+
+	return err
+}
+
+// parseMirrorFile parses a list of images to mirror. Each line contains a
+// source and a destination reference separated by whitespace. Empty lines
+// and lines starting with "#" are ignored. A destination can only be listed
+// once.
+func parseMirrorFile(dt []byte) ([]*mirrorEntry, error) {
+	var entries []*mirrorEntry
+	dests := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(dt))
+	for ln := 1; scanner.Scan(); ln++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("line %d: expected source and destination, got %q", ln, line)
+		}
+		refs, err := parseRefs(fields)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", ln)
+		}
+		dest := reference.TagNameOnly(refs[1]).String()
+		if prev, ok := dests[dest]; ok {
+			return nil, errors.Errorf("line %d: destination %s already used on line %d", ln, refs[1], prev)
+		}
+		dests[dest] = ln
+		entries = append(entries, &mirrorEntry{
+			src:  refs[0],
+			dest: refs[1],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no images to mirror")
+	}
+	return entries, nil
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMirrorFile(t *testing.T) {
+	tcs := []struct {
+		name     string
+		dt       string
+		expected [][2]string
+		err      string
+	}{
+		{
+			name: "simple",
+			dt:   "alpine:3.19 registry.example.com/alpine:3.19\n",
+			expected: [][2]string{
+				{"docker.io/library/alpine:3.19", "registry.example.com/alpine:3.19"},
+			},
+		},
+		{
+			name: "comments and blank lines",
+			dt: `# base images
+alpine:3.19   registry.example.com/alpine:3.19
+
+	# tools
+	busybox	registry.example.com/busybox:latest
+`,
+			expected: [][2]string{
+				{"docker.io/library/alpine:3.19", "registry.example.com/alpine:3.19"},
+				{"docker.io/library/busybox", "registry.example.com/busybox:latest"},
+			},
+		},
+		{
+			name: "same source to several destinations",
+			dt: `alpine registry.example.com/alpine
+alpine mirror.example.com/alpine
+`,
+			expected: [][2]string{
+				{"docker.io/library/alpine", "registry.example.com/alpine"},
+				{"docker.io/library/alpine", "mirror.example.com/alpine"},
+			},
+		},
+		{
+			name: "missing destination",
+			dt:   "# comment\nalpine\n",
+			err:  `line 2: expected source and destination, got "alpine"`,
+		},
+		{
+			name: "too many fields",
+			dt:   "alpine registry.example.com/alpine extra\n",
+			err:  "line 1: expected source and destination",
+		},
+		{
+			name: "invalid reference",
+			dt:   "alpine registry.example.com/Alpine\n",
+			err:  "line 1: invalid reference format",
+		},
+		{
+			name: "duplicate destination",
+			dt: `alpine registry.example.com/alpine
+busybox registry.example.com/busybox
+
+alpine:latest registry.example.com/alpine:latest
+`,
+			err: "line 4: destination registry.example.com/alpine:latest already used on line 1",
+		},
+		{
+			name: "empty",
+			dt:   "# nothing to mirror\n\n",
+			err:  "no images to mirror",
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := parseMirrorFile([]byte(tc.dt))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			var actual [][2]string
+			for _, e := range entries {
+				actual = append(actual, [2]string{e.src.String(), e.dest.String()})
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
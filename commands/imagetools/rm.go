@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/buildx/util/platformutil"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type rmOptions struct {
+	builder   string
+	platforms []string
+	tags      []string
+	tagOnly   bool
+	dryrun    bool
+	progress  string
+}
+
+func runRm(ctx context.Context, dockerCli command.Cli, in rmOptions, names []string) error {
+	if len(in.platforms) > 0 {
+		if in.tagOnly {
+			return errors.New("--tag-only can't be used with --platform")
+		}
+		for _, name := range names {
+			err := runAttestUpdate(ctx, dockerCli, attestOptions{
+				builder:   in.builder,
+				platforms: in.platforms,
+				tags:      in.tags,
+				dryrun:    in.dryrun,
+				progress:  in.progress,
+			}, name, func(ctx context.Context, r *imagetools.Resolver) (*imagetools.IndexUpdate, error) {
+				ps, err := platformutil.Parse(in.platforms)
+				if err != nil {
+					return nil, err
+				}
+				return r.RemovePlatforms(ctx, name, ps)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(in.tags) > 0 {
+		return errors.New("--tag can only be used with --platform")
+	}
+
+	refs, err := parseRefs(names)
+	if err != nil {
+		return err
+	}
+
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+	r := imagetools.New(imageopt)
+
+	if in.tagOnly {
+		for _, ref := range refs {
+			if _, ok := ref.(reference.Tagged); !ok {
+				return errors.Errorf("%s has no tag", ref)
+			}
+		}
+	}
+
+	var deletions []*imagetools.Deletion
+	if !in.tagOnly {
+		for _, name := range names {
+			d, err := r.PlanDelete(ctx, name)
+			if err != nil {
+				return err
+			}
+			deletions = append(deletions, d)
+		}
+	}
+
+	if in.dryrun {
+		if in.tagOnly {
+			for _, ref := range refs {
+				fmt.Fprintf(dockerCli.Out(), "%s\n", ref)
+			}
+			return nil
+		}
+		for _, d := range deletions {
+			fmt.Fprintf(dockerCli.Out(), "%s@%s\t%s\n", reference.TrimNamed(d.Ref), d.Desc.Digest, d.Desc.MediaType)
+		}
+		return nil
+	}
+
+	ctx2, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	printer, err := progress.NewPrinter(ctx2, os.Stderr, progressui.DisplayMode(in.progress))
+	if err != nil {
+		return err
+	}
+	pw := progress.WithPrefix(printer, "internal", true)
+
+	if in.tagOnly {
+		for _, ref := range refs {
+			err = progress.Wrap(fmt.Sprintf("deleting tag %s", ref), pw.Write, func(sub progress.SubLogger) error {
+				return r.DeleteTag(ctx, ref)
+			})
+			if err != nil {
+				break
+			}
+		}
+	} else {
+		for _, d := range deletions {
+			err = progress.Wrap(fmt.Sprintf("deleting %s", d.Ref), pw.Write, func(sub progress.SubLogger) error {
+				sub.Log(1, []byte(fmt.Sprintf("deleting %s\n", d.Desc.Digest)))
+				return r.Delete(ctx, d)
+			})
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	err1 := printer.Wait()
+	if err == nil {
+		err = err1
+	}
+	return err
+}
+
+func rmCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	var options rmOptions
+
+	cmd := &cobra.Command{
+		Use:   "rm [OPTIONS] IMAGE [IMAGE...]",
+		Short: "Delete images from the registry",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *opts.Builder
+			return runRm(cmd.Context(), dockerCli, options, args)
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVar(&options.platforms, "platform", []string{}, "Remove the images of the given platforms from the index instead of deleting it")
+	flags.StringArrayVarP(&options.tags, "tag", "t", []string{}, "Set reference for the new index when removing platforms (defaults to the updated image)")
+	flags.BoolVar(&options.tagOnly, "tag-only", false, "Only delete the tag, keeping the manifest it points to")
+	flags.BoolVar(&options.dryrun, "dry-run", false, "Show the manifests to delete instead of deleting them")
+	flags.StringVar(&options.progress, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "rawjson"). Use plain to show container output`)
+
+	return cmd
+}
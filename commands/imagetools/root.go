@@ -19,8 +19,14 @@ func RootCmd(rootcmd *cobra.Command, dockerCli command.Cli, opts RootOptions) *c
 	}
 
 	cmd.AddCommand(
+		attestCmd(dockerCli, opts),
 		createCmd(dockerCli, opts),
+		diffCmd(dockerCli, opts),
 		inspectCmd(dockerCli, opts),
+		rmCmd(dockerCli, opts),
+		signCmd(dockerCli, opts),
+		tagCmd(dockerCli, opts),
+		verifyCmd(dockerCli, opts),
 	)
 
 	return cmd
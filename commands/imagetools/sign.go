@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/buildx/util/platformutil"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+)
+
+func runSign(ctx context.Context, dockerCli command.Cli, in attestOptions, name string) error {
+	dt, err := os.ReadFile(in.key)
+	if err != nil {
+		return err
+	}
+	k, err := imagetools.LoadKey(dt)
+	if err != nil {
+		return err
+	}
+	return runAttestUpdate(ctx, dockerCli, in, name, func(ctx context.Context, r *imagetools.Resolver) (*imagetools.IndexUpdate, error) {
+		ps, err := platformutil.Parse(in.platforms)
+		if err != nil {
+			return nil, err
+		}
+		return r.Sign(ctx, name, k, ps)
+	})
+}
+
+func signCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	var options attestOptions
+
+	cmd := &cobra.Command{
+		Use:   "sign [OPTIONS] --key KEY IMAGE",
+		Short: "Sign the images of an index with a local key",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *opts.Builder
+			return runSign(cmd.Context(), dockerCli, options, args[0])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.key, "key", "", "Read the PEM encoded private key from file")
+	cmd.MarkFlagRequired("key")
+	addAttestUpdateFlags(cmd, &options)
+
+	return cmd
+}
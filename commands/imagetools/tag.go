@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/buildx/util/progress"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+type tagOptions struct {
+	builder  string
+	progress string
+}
+
+func runTag(ctx context.Context, dockerCli command.Cli, in tagOptions, src string, targets []string) error {
+	tags, err := parseRefs(targets)
+	if err != nil {
+		return err
+	}
+
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+	r := imagetools.New(imageopt)
+
+	ctx2, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	printer, err := progress.NewPrinter(ctx2, os.Stderr, progressui.DisplayMode(in.progress))
+	if err != nil {
+		return err
+	}
+
+	eg, _ := errgroup.WithContext(ctx)
+	pw := progress.WithPrefix(printer, "internal", true)
+
+	for _, t := range tags {
+		t := reference.TagNameOnly(t)
+		eg.Go(func() error {
+			return progress.Wrap(fmt.Sprintf("tagging %s", t.String()), pw.Write, func(sub progress.SubLogger) error {
+				return r.Tag(ctx, src, t)
+			})
+		})
+	}
+
+	err = eg.Wait()
+	err1 := printer.Wait()
+	if err == nil {
+		err = err1
+	}
+	return err
+}
+
+func tagCmd(dockerCli command.Cli, opts RootOptions) *cobra.Command {
+	var options tagOptions
+
+	cmd := &cobra.Command{
+		Use:   "tag [OPTIONS] SOURCE TARGET [TARGET...]",
+		Short: "Tag an image in the registry without pulling it",
+		Args:  cli.RequiresMinArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *opts.Builder
+			return runTag(cmd.Context(), dockerCli, options, args[0], args[1:])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.progress, "progress", "auto", `Set type of progress output ("auto", "plain", "tty", "rawjson"). Use plain to show container output`)
+
+	return cmd
+}
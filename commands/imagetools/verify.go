@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/buildx/util/imagetools"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type verifyOptions struct {
+	builder string
+	policy  string
+	key     string
+	format  string
+}
+
+func runVerify(ctx context.Context, dockerCli command.Cli, in verifyOptions, name string) error {
+	if in.policy == "" && in.key == "" {
+		return errors.New("either --policy or --key must be set")
+	}
+
+	var opt imagetools.VerifyOpt
+	if in.policy != "" {
+		dt, err := os.ReadFile(in.policy)
+		if err != nil {
+			return err
+		}
+		if opt.Policy, err = imagetools.ParsePolicy(dt); err != nil {
+			return err
+		}
+	}
+	if in.key != "" {
+		dt, err := os.ReadFile(in.key)
+		if err != nil {
+			return err
+		}
+		if opt.Key, err = imagetools.LoadKey(dt); err != nil {
+			return err
+		}
+	}
+
+	b, err := builder.New(dockerCli, builder.WithName(in.builder))
+	if err != nil {
+		return err
+	}
+	imageopt, err := b.ImageOpt()
+	if err != nil {
+		return err
+	}
+
+	v, err := imagetools.New(imageopt).Verify(ctx, name, opt)
+	if err != nil {
+		return err
+	}
+	if err := v.Print(in.format, dockerCli.Out()); err != nil {
+		return err
+	}
+	if !v.OK() {
+		return errors.Errorf("verification of %s failed", name)
+	}
+	return nil
+}
+
+func verifyCmd(dockerCli command.Cli, rootOpts RootOptions) *cobra.Command {
+	var options verifyOptions
+
+	cmd := &cobra.Command{
+		Use:   "verify [OPTIONS] IMAGE",
+		Short: "Verify the provenance and signature of an image in the registry",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = *rootOpts.Builder
+			return runVerify(cmd.Context(), dockerCli, options, args[0])
+		},
+		ValidArgsFunction: completion.Disable,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.policy, "policy", "", "Read the provenance policy from file")
+	flags.StringVar(&options.key, "key", "", "Verify the signature of the images with the public key from file")
+	flags.StringVar(&options.format, "format", "text", `Format the output ("text", "json")`)
+
+	return cmd
+}
@@ -37,7 +37,7 @@ func runInspect(ctx context.Context, dockerCli command.Cli, in inspectOptions) e
 	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	nodes, err := b.LoadNodes(timeoutCtx, builder.WithData())
+	nodes, err := b.LoadNodes(timeoutCtx, builder.WithUsage())
 	if in.bootstrap {
 		var ok bool
 		ok, err = b.Boot(ctx)
@@ -45,8 +45,9 @@ func runInspect(ctx context.Context, dockerCli command.Cli, in inspectOptions) e
 			return err
 		}
 		if ok {
-			nodes, err = b.LoadNodes(timeoutCtx, builder.WithData())
+			nodes, err = b.LoadNodes(timeoutCtx, builder.WithUsage())
 		}
+		b.WarnDrift()
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
@@ -82,14 +83,40 @@ func runInspect(ctx context.Context, dockerCli command.Cli, in inspectOptions) e
 
 			if err := n.Err; err != nil {
 				fmt.Fprintf(w, "Error:\t%s\n", err.Error())
+				if !nodes[i].LastSeen.IsZero() {
+					fmt.Fprintf(w, "Last Seen:\t%s\n", nodes[i].LastSeen.UTC().Format(time.RFC3339))
+				}
 			} else {
 				fmt.Fprintf(w, "Status:\t%s\n", nodes[i].DriverInfo.Status)
+				if h := nodes[i].DriverInfo.Health; h != nil {
+					if h.Error != "" {
+						fmt.Fprintf(w, "Health:\tunreachable: %s\n", strings.TrimSpace(h.Error))
+					} else {
+						fmt.Fprintf(w, "Health:\tok (latency %s)\n", h.Latency.Round(time.Millisecond))
+					}
+				}
+				for _, d := range nodes[i].DriverInfo.Drift {
+					fmt.Fprintf(w, "Drift:\t%s\n", d)
+				}
 				if len(n.BuildkitdFlags) > 0 {
 					fmt.Fprintf(w, "BuildKit daemon flags:\t%s\n", strings.Join(n.BuildkitdFlags, " "))
 				}
 				if nodes[i].Version != "" {
 					fmt.Fprintf(w, "BuildKit version:\t%s\n", nodes[i].Version)
 				}
+				if u := nodes[i].Usage; u != nil {
+					fmt.Fprintf(w, "Workers:\t%d\n", u.Workers)
+					fmt.Fprintf(w, "Active Solves:\t%d\n", u.ActiveSolves)
+					if u.Error != "" {
+						fmt.Fprintf(w, "Usage Error:\t%s\n", u.Error)
+					} else {
+						fmt.Fprintf(w, "Disk Usage:\t%s (reclaimable: %s)\n", units.HumanSize(float64(u.DiskUsage)), units.HumanSize(float64(u.Reclaimable)))
+						fmt.Fprintf(w, "Cache Records:\t%d\n", u.CacheRecords)
+					}
+				}
+				if !nodes[i].LastSeen.IsZero() {
+					fmt.Fprintf(w, "Last Seen:\t%s\n", nodes[i].LastSeen.UTC().Format(time.RFC3339))
+				}
 				platforms := platformutil.FormatInGroups(n.Node.Platforms, n.Platforms)
 				if len(platforms) > 0 {
 					fmt.Fprintf(w, "Platforms:\t%s\n", strings.Join(platforms, ", "))
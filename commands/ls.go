@@ -60,11 +60,18 @@ func runLs(ctx context.Context, dockerCli command.Cli, in lsOptions) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
+	loadOpt := builder.WithData()
+	if formatter.Format(in.format).IsJSON() {
+		// load and capacity are only reported in JSON as they are costly to
+		// query on builders with a large cache
+		loadOpt = builder.WithUsage()
+	}
+
 	eg, _ := errgroup.WithContext(timeoutCtx)
 	for _, b := range builders {
 		func(b *builder.Builder) {
 			eg.Go(func() error {
-				_, _ = b.LoadNodes(timeoutCtx, builder.WithData())
+				_, _ = b.LoadNodes(timeoutCtx, loadOpt)
 				return nil
 			})
 		}(b)
@@ -85,6 +92,8 @@ func runLs(ctx context.Context, dockerCli command.Cli, in lsOptions) error {
 				for _, d := range b.Nodes() {
 					if d.Err != nil {
 						_, _ = fmt.Fprintf(dockerCli.Err(), "Failed to get status for %s (%s): %s\n", b.Name, d.Name, strings.TrimSpace(d.Err.Error()))
+					} else if isUnreachable(d) {
+						_, _ = fmt.Fprintf(dockerCli.Err(), "Failed to reach %s (%s): %s\n", b.Name, d.Name, strings.TrimSpace(d.DriverInfo.Health.Error))
 					}
 				}
 			}
@@ -158,7 +167,7 @@ func lsPrint(dockerCli command.Cli, current *store.NodeGroup, builders []*builde
 				continue
 			}
 			for _, n := range b.Nodes() {
-				if n.Err != nil {
+				if n.Err != nil || isUnreachable(n) {
 					if ctx.Format.IsTable() {
 						hasErrors = true
 					}
@@ -251,6 +260,9 @@ func (c *lsContext) Status() string {
 	if c.node.Err != nil {
 		return "error"
 	}
+	if isUnreachable(c.node) {
+		return "unreachable"
+	}
 	if c.node.DriverInfo != nil {
 		return c.node.DriverInfo.Status.String()
 	}
@@ -284,6 +296,10 @@ func (c *lsContext) Error() string {
 	return ""
 }
 
+func isUnreachable(n builder.Node) bool {
+	return n.DriverInfo != nil && n.DriverInfo.Health != nil && n.DriverInfo.Health.Error != ""
+}
+
 var truncMajorPlatforms = []string{
 	"linux/amd64",
 	"linux/arm64",
@@ -161,7 +161,10 @@ func rmAllInactive(ctx context.Context, txn *store.Txn, dockerCli command.Cli, i
 				if err != nil {
 					return errors.Wrapf(err, "cannot load %s", b.Name)
 				}
-				if b.Dynamic {
+				// builders with discovered nodes can be inactive like any
+				// remote builder, other dynamic builders are managed by
+				// their driver
+				if b.Dynamic && !b.Discovered() {
 					return nil
 				}
 				if b.Inactive() {
@@ -92,7 +92,7 @@ func addCommands(cmd *cobra.Command, opts *rootOptions, dockerCli command.Cli) {
 
 	cmd.AddCommand(
 		buildCmd(dockerCli, opts, nil),
-		bakeCmd(dockerCli, opts),
+		bakeCmd(dockerCli, opts, nil),
 		createCmd(dockerCli),
 		dialStdioCmd(dockerCli, opts),
 		rmCmd(dockerCli, opts),
@@ -100,6 +100,7 @@ func addCommands(cmd *cobra.Command, opts *rootOptions, dockerCli command.Cli) {
 		useCmd(dockerCli, opts),
 		inspectCmd(dockerCli, opts),
 		stopCmd(dockerCli, opts),
+		updateCmd(dockerCli, opts),
 		installCmd(dockerCli),
 		uninstallCmd(dockerCli),
 		versionCmd(dockerCli),
@@ -110,6 +111,10 @@ func addCommands(cmd *cobra.Command, opts *rootOptions, dockerCli command.Cli) {
 	if confutil.IsExperimental() {
 		cmd.AddCommand(debugcmd.RootCmd(dockerCli,
 			newDebuggableBuild(dockerCli, opts),
+			newDebuggableBake(dockerCli, opts),
+		))
+		cmd.AddCommand(dapCmd(dockerCli,
+			newDebuggableBuild(dockerCli, opts),
 		))
 		remote.AddControllerCommands(cmd, dockerCli)
 	}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/util/cobrautil/completion"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type updateOptions struct {
+	builder string
+	node    string
+	force   bool
+}
+
+func runUpdate(ctx context.Context, dockerCli command.Cli, in updateOptions) error {
+	b, err := builder.New(dockerCli,
+		builder.WithName(in.builder),
+		builder.WithSkippedValidation(),
+	)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	nodes, err := b.LoadNodes(timeoutCtx, builder.WithData())
+	if err != nil {
+		return err
+	}
+
+	return updateNodes(ctx, dockerCli.Err(), b.Name, b, nodes, in)
+}
+
+// nodeRecreator recreates the BuildKit instances of the nodes of a builder.
+type nodeRecreator interface {
+	Recreate(ctx context.Context, nodeName string, force bool) (bool, error)
+}
+
+// updateNodes recreates the nodes of the builder name that drifted, or all of
+// them with force, and reports the result to w.
+func updateNodes(ctx context.Context, w io.Writer, name string, r nodeRecreator, nodes []builder.Node, in updateOptions) error {
+	if in.node != "" {
+		var found bool
+		for _, n := range nodes {
+			if n.Name == in.node {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("node %q not found for %s", in.node, name)
+		}
+	}
+
+	ok, err := r.Recreate(ctx, in.node, in.force)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(w, "%s is up to date\n", name)
+		return nil
+	}
+	fmt.Fprintf(w, "%s updated\n", name)
+	return nil
+}
+
+func updateCmd(dockerCli command.Cli, rootOpts *rootOptions) *cobra.Command {
+	var options updateOptions
+
+	cmd := &cobra.Command{
+		Use:   "update [OPTIONS] [NAME]",
+		Short: "Update builder instance",
+		Args:  cli.RequiresMaxArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.builder = rootOpts.builder
+			if len(args) > 0 {
+				options.builder = args[0]
+			}
+			return runUpdate(cmd.Context(), dockerCli, options)
+		},
+		ValidArgsFunction: completion.BuilderNames(dockerCli),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.node, "node", "", "Only update the node with given name")
+	flags.BoolVarP(&options.force, "force", "f", false, "Recreate nodes even if no configuration drift is detected")
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/docker/buildx/builder"
+	"github.com/docker/buildx/store"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRecreator struct {
+	recreated bool
+	err       error
+
+	nodeName string
+	force    bool
+}
+
+func (r *fakeRecreator) Recreate(_ context.Context, nodeName string, force bool) (bool, error) {
+	r.nodeName, r.force = nodeName, force
+	return r.recreated, r.err
+}
+
+func TestUpdateNodes(t *testing.T) {
+	nodes := []builder.Node{{Node: store.Node{Name: "node0"}}, {Node: store.Node{Name: "node1"}}}
+
+	buf := &bytes.Buffer{}
+	r := &fakeRecreator{}
+	require.NoError(t, updateNodes(context.TODO(), buf, "mybuilder", r, nodes, updateOptions{}))
+	require.Equal(t, "mybuilder is up to date\n", buf.String())
+	require.Equal(t, "", r.nodeName)
+	require.False(t, r.force)
+
+	buf.Reset()
+	r = &fakeRecreator{recreated: true}
+	require.NoError(t, updateNodes(context.TODO(), buf, "mybuilder", r, nodes, updateOptions{node: "node1", force: true}))
+	require.Equal(t, "mybuilder updated\n", buf.String())
+	require.Equal(t, "node1", r.nodeName)
+	require.True(t, r.force)
+
+	r = &fakeRecreator{}
+	err := updateNodes(context.TODO(), buf, "mybuilder", r, nodes, updateOptions{node: "node2"})
+	require.ErrorContains(t, err, `node "node2" not found for mybuilder`)
+	require.False(t, r.force)
+
+	r = &fakeRecreator{err: errors.New("failed to remove container")}
+	require.ErrorContains(t, updateNodes(context.TODO(), buf, "mybuilder", r, nodes, updateOptions{}), "failed to remove container")
+}
@@ -46,10 +46,12 @@ func RunBuild(ctx context.Context, dockerCli command.Cli, in *controllerapi.Buil
 
 	opts := build.Options{
 		Inputs: build.Inputs{
-			ContextPath:    in.ContextPath,
-			DockerfilePath: in.DockerfileName,
-			InStream:       build.NewSyncMultiReader(inStream),
-			NamedContexts:  contexts,
+			ContextPath:      in.ContextPath,
+			DockerfilePath:   in.DockerfileName,
+			DockerfileInline: in.DockerfileInline,
+			InStream:         build.NewSyncMultiReader(inStream),
+			NamedContexts:    contexts,
+			InvokeDirs:       in.InvokeDirs,
 		},
 		Ref:                    in.Ref,
 		BuildArgs:              in.BuildArgs,
@@ -158,6 +160,11 @@ func RunBuild(ctx context.Context, dockerCli command.Cli, in *controllerapi.Buil
 	}
 	opts.Allow = allow
 
+	opts.Breakpoints, err = build.ParseBreakpoints(in.Breakpoints, in.ResumeFrom)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	if in.CallFunc != nil {
 		opts.CallFunc = &build.CallFunc{
 			Name:         in.CallFunc.Name,
@@ -23,6 +23,12 @@ type BuildxController interface {
 	Disconnect(ctx context.Context, ref string) error
 	ListProcesses(ctx context.Context, ref string) (infos []*controllerapi.ProcessInfo, retErr error)
 	DisconnectProcess(ctx context.Context, ref, pid string) error
+	// CopyFromContainer writes a tar archive of the path of the running container to w.
+	CopyFromContainer(ctx context.Context, ref, path string, w io.Writer) error
+	// CopyToContainer extracts the tar archive read from r to the directory path of the running container.
+	CopyToContainer(ctx context.Context, ref, path string, r io.Reader) error
+	// Diff returns the changes of the running container since its initial rootfs or, if step is true, the changes made by the failed step.
+	Diff(ctx context.Context, ref string, step bool) ([]*controllerapi.FileChange, error)
 	Inspect(ctx context.Context, ref string) (*controllerapi.InspectResponse, error)
 }
 
@@ -36,11 +36,13 @@ func (e *BuildError) PrintBuildDetails(w io.Writer) error {
 	return ebr.Print(w)
 }
 
-func WrapBuild(err error, sessionID string, ref string) error {
+// WrapBuild wraps the error of the build of a session. breakpoint is the
+// digest of the step the build paused at, if the build is paused.
+func WrapBuild(err error, sessionID string, ref string, breakpoint string) error {
 	if err == nil {
 		return nil
 	}
-	return &BuildError{Build: &Build{SessionID: sessionID, Ref: ref}, error: err}
+	return &BuildError{Build: &Build{SessionID: sessionID, Ref: ref, Breakpoint: breakpoint}, error: err}
 }
 
 func (b *Build) WrapError(err error) error {
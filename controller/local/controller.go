@@ -3,6 +3,7 @@ package local
 import (
 	"context"
 	"io"
+	"sync"
 	"sync/atomic"
 
 	"github.com/docker/buildx/build"
@@ -16,14 +17,14 @@ import (
 	"github.com/docker/buildx/util/progress"
 	"github.com/docker/cli/cli/command"
 	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/identity"
 	"github.com/pkg/errors"
 )
 
 func NewLocalBuildxController(ctx context.Context, dockerCli command.Cli, logger progress.SubLogger) control.BuildxController {
 	return &localController{
 		dockerCli: dockerCli,
-		sessionID: "local",
-		processes: processes.NewManager(),
+		sessions:  make(map[string]*localSession),
 	}
 }
 
@@ -32,13 +33,29 @@ type buildConfig struct {
 	// Discussion: https://github.com/docker/buildx/pull/1640#discussion_r1113279719
 	resultCtx    *build.ResultHandle
 	buildOptions *controllerapi.BuildOptions
+	// breakpoint is the digest of the step the build is paused at
+	breakpoint string
+	// invokeConfig is the config of the last container started
+	invokeConfig *controllerapi.InvokeConfig
 }
 
-type localController struct {
-	dockerCli   command.Cli
-	sessionID   string
+// localSession is the result of a build and the containers running on it.
+type localSession struct {
 	buildConfig buildConfig
 	processes   *processes.Manager
+}
+
+func (s *localSession) close() {
+	s.processes.CancelRunningProcesses()
+	if s.buildConfig.resultCtx != nil {
+		s.buildConfig.resultCtx.Done()
+	}
+}
+
+type localController struct {
+	dockerCli  command.Cli
+	sessions   map[string]*localSession
+	sessionsMu sync.Mutex
 
 	buildOnGoing atomic.Bool
 }
@@ -49,12 +66,16 @@ func (b *localController) Build(ctx context.Context, options *controllerapi.Buil
 	}
 	defer b.buildOnGoing.Store(false)
 
+	sessionID := identity.NewID()
 	resp, res, dockerfileMappings, buildErr := cbuild.RunBuild(ctx, b.dockerCli, options, in, progress, true)
 	// NOTE: RunBuild can return *build.ResultHandle even on error.
 	if res != nil {
-		b.buildConfig = buildConfig{
-			resultCtx:    res,
-			buildOptions: options,
+		s := &localSession{
+			buildConfig: buildConfig{
+				resultCtx:    res,
+				buildOptions: options,
+			},
+			processes: processes.NewManager(),
 		}
 		if buildErr != nil {
 			var ref string
@@ -62,49 +83,110 @@ func (b *localController) Build(ctx context.Context, options *controllerapi.Buil
 			if errors.As(buildErr, &ebr) {
 				ref = ebr.Ref
 			}
-			buildErr = controllererrors.WrapBuild(buildErr, b.sessionID, ref)
+			var bpe *build.BreakpointError
+			if errors.As(buildErr, &bpe) {
+				s.buildConfig.breakpoint = bpe.Step.Digest.String()
+			}
+			buildErr = controllererrors.WrapBuild(buildErr, sessionID, ref, s.buildConfig.breakpoint)
 		}
+		b.sessionsMu.Lock()
+		b.sessions[sessionID] = s
+		b.sessionsMu.Unlock()
 	}
 	if buildErr != nil {
 		return "", nil, nil, buildErr
 	}
-	return b.sessionID, resp, dockerfileMappings, nil
+	return sessionID, resp, dockerfileMappings, nil
 }
 
-func (b *localController) ListProcesses(ctx context.Context, sessionID string) (infos []*controllerapi.ProcessInfo, retErr error) {
-	if sessionID != b.sessionID {
+func (b *localController) session(sessionID string) (*localSession, error) {
+	b.sessionsMu.Lock()
+	defer b.sessionsMu.Unlock()
+	s, ok := b.sessions[sessionID]
+	if !ok {
 		return nil, errors.Errorf("unknown session ID %q", sessionID)
 	}
-	return b.processes.ListProcesses(), nil
+	return s, nil
+}
+
+func (b *localController) ListProcesses(ctx context.Context, sessionID string) (infos []*controllerapi.ProcessInfo, retErr error) {
+	s, err := b.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.processes.ListProcesses(), nil
 }
 
 func (b *localController) DisconnectProcess(ctx context.Context, sessionID, pid string) error {
-	if sessionID != b.sessionID {
-		return errors.Errorf("unknown session ID %q", sessionID)
+	s, err := b.session(sessionID)
+	if err != nil {
+		return err
+	}
+	return s.processes.DeleteProcess(pid)
+}
+
+func (b *localController) CopyFromContainer(ctx context.Context, sessionID, path string, w io.Writer) error {
+	s, err := b.session(sessionID)
+	if err != nil {
+		return err
+	}
+	ctr, err := s.processes.Container()
+	if err != nil {
+		return err
+	}
+	return ctr.CopyFrom(ctx, path, w)
+}
+
+func (b *localController) CopyToContainer(ctx context.Context, sessionID, path string, r io.Reader) error {
+	s, err := b.session(sessionID)
+	if err != nil {
+		return err
+	}
+	ctr, err := s.processes.Container()
+	if err != nil {
+		return err
 	}
-	return b.processes.DeleteProcess(pid)
+	return ctr.CopyTo(ctx, path, r)
 }
 
-func (b *localController) cancelRunningProcesses() {
-	b.processes.CancelRunningProcesses()
+func (b *localController) Diff(ctx context.Context, sessionID string, step bool) ([]*controllerapi.FileChange, error) {
+	s, err := b.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if step {
+		if s.buildConfig.resultCtx == nil {
+			return nil, errors.New("no build result is registered")
+		}
+		return s.buildConfig.resultCtx.StepDiff(ctx)
+	}
+	ctr, err := s.processes.Container()
+	if err != nil {
+		return nil, err
+	}
+	return ctr.Diff(ctx)
 }
 
 func (b *localController) Invoke(ctx context.Context, sessionID string, pid string, cfg *controllerapi.InvokeConfig, ioIn io.ReadCloser, ioOut io.WriteCloser, ioErr io.WriteCloser) error {
-	if sessionID != b.sessionID {
-		return errors.Errorf("unknown session ID %q", sessionID)
+	s, err := b.session(sessionID)
+	if err != nil {
+		return err
 	}
 
-	proc, ok := b.processes.Get(pid)
+	proc, ok := s.processes.Get(pid)
 	if !ok {
 		// Start a new process.
-		if b.buildConfig.resultCtx == nil {
+		if s.buildConfig.resultCtx == nil {
 			return errors.New("no build result is registered")
 		}
 		var err error
-		proc, err = b.processes.StartProcess(pid, b.buildConfig.resultCtx, cfg)
+		proc, err = s.processes.StartProcess(pid, s.buildConfig.resultCtx, cfg)
 		if err != nil {
 			return err
 		}
+		b.sessionsMu.Lock()
+		s.buildConfig.invokeConfig = cfg
+		b.sessionsMu.Unlock()
 	}
 
 	// Attach containerIn to this process
@@ -127,26 +209,45 @@ func (b *localController) Kill(context.Context) error {
 }
 
 func (b *localController) Close() error {
-	b.cancelRunningProcesses()
-	if b.buildConfig.resultCtx != nil {
-		b.buildConfig.resultCtx.Done()
+	b.sessionsMu.Lock()
+	for k, s := range b.sessions {
+		s.close()
+		delete(b.sessions, k)
 	}
+	b.sessionsMu.Unlock()
 	// TODO: cancel ongoing builds?
 	return nil
 }
 
 func (b *localController) List(ctx context.Context) (res []string, _ error) {
-	return []string{b.sessionID}, nil
+	b.sessionsMu.Lock()
+	defer b.sessionsMu.Unlock()
+	for k := range b.sessions {
+		res = append(res, k)
+	}
+	return res, nil
 }
 
 func (b *localController) Disconnect(ctx context.Context, key string) error {
-	b.Close()
+	b.sessionsMu.Lock()
+	if s, ok := b.sessions[key]; ok {
+		s.close()
+		delete(b.sessions, key)
+	}
+	b.sessionsMu.Unlock()
 	return nil
 }
 
 func (b *localController) Inspect(ctx context.Context, sessionID string) (*controllerapi.InspectResponse, error) {
-	if sessionID != b.sessionID {
-		return nil, errors.Errorf("unknown session ID %q", sessionID)
+	s, err := b.session(sessionID)
+	if err != nil {
+		return nil, err
 	}
-	return &controllerapi.InspectResponse{Options: b.buildConfig.buildOptions}, nil
+	b.sessionsMu.Lock()
+	defer b.sessionsMu.Unlock()
+	return &controllerapi.InspectResponse{
+		Options:      s.buildConfig.buildOptions,
+		Breakpoint:   s.buildConfig.breakpoint,
+		InvokeConfig: s.buildConfig.invokeConfig,
+	}, nil
 }
@@ -29,6 +29,9 @@ const (
 	Controller_Info_FullMethodName              = "/buildx.controller.v1.Controller/Info"
 	Controller_ListProcesses_FullMethodName     = "/buildx.controller.v1.Controller/ListProcesses"
 	Controller_DisconnectProcess_FullMethodName = "/buildx.controller.v1.Controller/DisconnectProcess"
+	Controller_CopyFromContainer_FullMethodName = "/buildx.controller.v1.Controller/CopyFromContainer"
+	Controller_CopyToContainer_FullMethodName   = "/buildx.controller.v1.Controller/CopyToContainer"
+	Controller_Diff_FullMethodName              = "/buildx.controller.v1.Controller/Diff"
 )
 
 // ControllerClient is the client API for Controller service.
@@ -45,6 +48,9 @@ type ControllerClient interface {
 	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
 	ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error)
 	DisconnectProcess(ctx context.Context, in *DisconnectProcessRequest, opts ...grpc.CallOption) (*DisconnectProcessResponse, error)
+	CopyFromContainer(ctx context.Context, in *CopyFromContainerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataMessage], error)
+	CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CopyToContainerMessage, CopyToContainerResponse], error)
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
 }
 
 type controllerClient struct {
@@ -170,6 +176,48 @@ func (c *controllerClient) DisconnectProcess(ctx context.Context, in *Disconnect
 	return out, nil
 }
 
+func (c *controllerClient) CopyFromContainer(ctx context.Context, in *CopyFromContainerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Controller_ServiceDesc.Streams[3], Controller_CopyFromContainer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyFromContainerRequest, DataMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Controller_CopyFromContainerClient = grpc.ServerStreamingClient[DataMessage]
+
+func (c *controllerClient) CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CopyToContainerMessage, CopyToContainerResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Controller_ServiceDesc.Streams[4], Controller_CopyToContainer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyToContainerMessage, CopyToContainerResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Controller_CopyToContainerClient = grpc.ClientStreamingClient[CopyToContainerMessage, CopyToContainerResponse]
+
+func (c *controllerClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, Controller_Diff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControllerServer is the server API for Controller service.
 // All implementations should embed UnimplementedControllerServer
 // for forward compatibility.
@@ -184,6 +232,9 @@ type ControllerServer interface {
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
 	ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error)
 	DisconnectProcess(context.Context, *DisconnectProcessRequest) (*DisconnectProcessResponse, error)
+	CopyFromContainer(*CopyFromContainerRequest, grpc.ServerStreamingServer[DataMessage]) error
+	CopyToContainer(grpc.ClientStreamingServer[CopyToContainerMessage, CopyToContainerResponse]) error
+	Diff(context.Context, *DiffRequest) (*DiffResponse, error)
 }
 
 // UnimplementedControllerServer should be embedded to have
@@ -223,6 +274,15 @@ func (UnimplementedControllerServer) ListProcesses(context.Context, *ListProcess
 func (UnimplementedControllerServer) DisconnectProcess(context.Context, *DisconnectProcessRequest) (*DisconnectProcessResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DisconnectProcess not implemented")
 }
+func (UnimplementedControllerServer) CopyFromContainer(*CopyFromContainerRequest, grpc.ServerStreamingServer[DataMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyFromContainer not implemented")
+}
+func (UnimplementedControllerServer) CopyToContainer(grpc.ClientStreamingServer[CopyToContainerMessage, CopyToContainerResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyToContainer not implemented")
+}
+func (UnimplementedControllerServer) Diff(context.Context, *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
 func (UnimplementedControllerServer) testEmbeddedByValue() {}
 
 // UnsafeControllerServer may be embedded to opt out of forward compatibility for this service.
@@ -394,6 +454,42 @@ func _Controller_DisconnectProcess_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_CopyFromContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromContainerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServer).CopyFromContainer(m, &grpc.GenericServerStream[CopyFromContainerRequest, DataMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Controller_CopyFromContainerServer = grpc.ServerStreamingServer[DataMessage]
+
+func _Controller_CopyToContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControllerServer).CopyToContainer(&grpc.GenericServerStream[CopyToContainerMessage, CopyToContainerResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Controller_CopyToContainerServer = grpc.ClientStreamingServer[CopyToContainerMessage, CopyToContainerResponse]
+
+func _Controller_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Controller_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Controller_ServiceDesc is the grpc.ServiceDesc for Controller service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -429,6 +525,10 @@ var Controller_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DisconnectProcess",
 			Handler:    _Controller_DisconnectProcess_Handler,
 		},
+		{
+			MethodName: "Diff",
+			Handler:    _Controller_Diff_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -447,6 +547,16 @@ var Controller_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "CopyFromContainer",
+			Handler:       _Controller_CopyFromContainer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyToContainer",
+			Handler:       _Controller_CopyToContainer_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "github.com/docker/buildx/controller/pb/controller.proto",
 }
@@ -160,6 +160,16 @@ func ResolveOptionPaths(options *BuildOptions) (_ *BuildOptions, err error) {
 	}
 	options.SSH = ssh
 
+	var invokeDirs []string
+	for _, dir := range options.InvokeDirs {
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		invokeDirs = append(invokeDirs, dir)
+	}
+	options.InvokeDirs = invokeDirs
+
 	return options, nil
 }
 
@@ -238,6 +238,11 @@ func TestResolvePaths(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "invokedirs",
+			options: &BuildOptions{InvokeDirs: []string{"test1", "/test2"}},
+			want:    &BuildOptions{InvokeDirs: []string{filepath.Join(tmpwd, "test1"), "/test2"}},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -2,6 +2,7 @@ package processes
 
 import (
 	"context"
+	"io"
 	"sync"
 	"sync/atomic"
 
@@ -19,6 +20,9 @@ type Process struct {
 	errCh         chan error
 	processCancel func()
 	serveIOCancel func()
+	stdout        *teeWriter
+	stderr        *teeWriter
+	exited        chan struct{}
 }
 
 // ForwardIO forwards process's io to the specified reader/writer.
@@ -32,6 +36,23 @@ func (p *Process) ForwardIO(in *ioset.In, ioCancelCallback func()) {
 	p.serveIOCancel = ioCancelCallback
 }
 
+// Watch copies the output of the process to stdout and stderr, in addition to
+// the IO set by ForwardIO, until the returned function is called.
+func (p *Process) Watch(stdout, stderr io.Writer) (stop func()) {
+	stopStdout := p.stdout.add(stdout)
+	stopStderr := p.stderr.add(stderr)
+	return func() {
+		stopStdout()
+		stopStderr()
+	}
+}
+
+// Exited returns a channel closed when the process exits.
+// Unlike Done, it can be waited by any number of callers.
+func (p *Process) Exited() <-chan struct{} {
+	return p.exited
+}
+
 // Done returns a channel where error or nil will be sent
 // when the process exits.
 // TODO: change this to Wait()
@@ -94,6 +115,16 @@ func (m *Manager) DeleteProcess(id string) error {
 	return nil
 }
 
+// Container returns the running container of the processes.
+func (m *Manager) Container() (*build.Container, error) {
+	if a := m.container.Load(); a != nil {
+		if ctr := a.(*build.Container); !ctr.IsUnavailable() {
+			return ctr, nil
+		}
+	}
+	return nil, errors.New("no running container")
+}
+
 // StartProcess starts a process in the container.
 // When a container isn't available (i.e. first time invoking or the container has exited) or cfg.Rollback is set,
 // this method will start a new container and run the process in it. Otherwise, this method starts a new process in the
@@ -132,18 +163,68 @@ func (m *Manager) StartProcess(pid string, resultCtx *build.ResultHandle, cfg *p
 		invokeConfig:  cfg,
 		processCancel: processCancelFunc,
 		errCh:         make(chan error),
+		stdout:        &teeWriter{WriteCloser: in.Stdout},
+		stderr:        &teeWriter{WriteCloser: in.Stderr},
+		exited:        make(chan struct{}),
 	}
 	m.processes.Store(pid, p)
 	go func() {
 		var err error
-		if err = ctr.Exec(ctx, cfg, in.Stdin, in.Stdout, in.Stderr); err != nil {
+		if err = ctr.Exec(ctx, cfg, in.Stdin, p.stdout, p.stderr); err != nil {
 			logrus.Debugf("process error: %v", err)
 		}
 		logrus.Debugf("finished process %s %v", pid, cfg.Entrypoint)
 		m.processes.Delete(pid)
 		processCancelFunc()
+		close(p.exited)
 		p.errCh <- err
 	}()
 
 	return p, nil
 }
+
+// teeWriter writes to a WriteCloser and to the writers watching it.
+type teeWriter struct {
+	io.WriteCloser
+
+	mu       sync.Mutex
+	watchers map[int]io.Writer
+	nextID   int
+}
+
+func (w *teeWriter) add(ww io.Writer) (remove func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers == nil {
+		w.watchers = make(map[int]io.Writer)
+	}
+	id := w.nextID
+	w.nextID++
+	w.watchers[id] = ww
+	return func() {
+		w.mu.Lock()
+		delete(w.watchers, id)
+		w.mu.Unlock()
+	}
+}
+
+func (w *teeWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+
+	// NOTE: write to the watchers without the lock so that a blocked watcher
+	// doesn't prevent the others from being removed.
+	w.mu.Lock()
+	watchers := make(map[int]io.Writer, len(w.watchers))
+	for id, ww := range w.watchers {
+		watchers[id] = ww
+	}
+	w.mu.Unlock()
+	for id, ww := range watchers {
+		if _, err := ww.Write(p); err != nil {
+			w.mu.Lock()
+			delete(w.watchers, id)
+			w.mu.Unlock()
+		}
+	}
+	return n, err
+}
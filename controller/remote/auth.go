@@ -0,0 +1,76 @@
+package remote
+
+import (
+	"context"
+	"path"
+
+	"github.com/docker/buildx/controller/pb"
+	"github.com/moby/buildkit/util/grpcerrors"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// authInfo is the identity of a client of the server.
+type authInfo struct {
+	credentials.CommonAuthInfo
+
+	// UID is the user ID of the client process.
+	UID int
+
+	// ReadOnly is true if the client can only follow the sessions.
+	ReadOnly bool
+}
+
+func (*authInfo) AuthType() string {
+	return "peercred"
+}
+
+// readOnlyMethods are the methods read-only clients are allowed to call.
+// They can follow the progress and the processes of a session but can't
+// build, modify or disconnect it, nor read the files of its containers,
+// which run processes as root.
+var readOnlyMethods = map[string]struct{}{
+	pb.Controller_Info_FullMethodName:          {},
+	pb.Controller_List_FullMethodName:          {},
+	pb.Controller_Inspect_FullMethodName:       {},
+	pb.Controller_Status_FullMethodName:        {},
+	pb.Controller_ListProcesses_FullMethodName: {},
+	pb.Controller_Invoke_FullMethodName:        {},
+}
+
+// isReadOnly returns true if the client of the request has read-only access.
+func isReadOnly(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	ai, ok := p.AuthInfo.(*authInfo)
+	return ok && ai.ReadOnly
+}
+
+func checkAccess(ctx context.Context, method string) error {
+	if !isReadOnly(ctx) {
+		return nil
+	}
+	if _, ok := readOnlyMethods[method]; !ok {
+		return grpcerrors.WrapCode(errors.Errorf("read-only client is not allowed to call %s", path.Base(method)), codes.PermissionDenied)
+	}
+	return nil
+}
+
+func authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := checkAccess(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func authStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkAccess(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,45 @@
+package remote
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/buildx/controller/pb"
+	"github.com/moby/buildkit/util/grpcerrors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestCheckAccess(t *testing.T) {
+	readOnly := peer.NewContext(context.TODO(), &peer.Peer{AuthInfo: &authInfo{UID: 1002, ReadOnly: true}})
+	for _, method := range []string{
+		pb.Controller_List_FullMethodName,
+		pb.Controller_Status_FullMethodName,
+		pb.Controller_ListProcesses_FullMethodName,
+		pb.Controller_Invoke_FullMethodName,
+	} {
+		require.NoError(t, checkAccess(readOnly, method), method)
+	}
+	for _, method := range []string{
+		pb.Controller_Build_FullMethodName,
+		pb.Controller_Disconnect_FullMethodName,
+		pb.Controller_DisconnectProcess_FullMethodName,
+		pb.Controller_CopyFromContainer_FullMethodName,
+		pb.Controller_CopyToContainer_FullMethodName,
+		pb.Controller_Diff_FullMethodName,
+	} {
+		err := checkAccess(readOnly, method)
+		require.ErrorContains(t, err, "read-only client is not allowed to call", method)
+		require.Equal(t, codes.PermissionDenied, grpcerrors.Code(err), method)
+	}
+
+	for _, ctx := range []context.Context{
+		context.TODO(),
+		peer.NewContext(context.TODO(), &peer.Peer{AuthInfo: &authInfo{UID: 1001}}),
+		peer.NewContext(context.TODO(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}),
+	} {
+		require.NoError(t, checkAccess(ctx, pb.Controller_Disconnect_FullMethodName))
+	}
+}
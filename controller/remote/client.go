@@ -96,6 +96,93 @@ func (c *Client) DisconnectProcess(ctx context.Context, sessionID, pid string) e
 	return err
 }
 
+func (c *Client) CopyFromContainer(ctx context.Context, sessionID, path string, w io.Writer) error {
+	// cancel the stream if the copy fails on this side
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client().CopyFromContainer(ctx, &pb.CopyFromContainerRequest{SessionID: sessionID, Path: path})
+	if err != nil {
+		return err
+	}
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return errors.New("copy: unexpected end of archive")
+			}
+			return err
+		}
+		if len(msg.Data) > 0 {
+			if _, err := w.Write(msg.Data); err != nil {
+				return err
+			}
+		}
+		if msg.EOF {
+			return nil
+		}
+	}
+}
+
+func (c *Client) CopyToContainer(ctx context.Context, sessionID, path string, r io.Reader) error {
+	// cancel the stream if the copy fails on this side
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client().CopyToContainer(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&pb.CopyToContainerMessage{
+		Input: &pb.CopyToContainerMessage_Init{
+			Init: &pb.CopyToContainerInitMessage{
+				SessionID: sessionID,
+				Path:      path,
+			},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to init copy")
+	}
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.CopyToContainerMessage{
+				Input: &pb.CopyToContainerMessage_Data{
+					Data: &pb.DataMessage{
+						Data: buf[:n],
+					},
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break // break loop and send EOF
+			}
+			return err
+		}
+	}
+	if err := stream.Send(&pb.CopyToContainerMessage{
+		Input: &pb.CopyToContainerMessage_Data{
+			Data: &pb.DataMessage{
+				EOF: true,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func (c *Client) Diff(ctx context.Context, sessionID string, step bool) ([]*pb.FileChange, error) {
+	res, err := c.client().Diff(ctx, &pb.DiffRequest{SessionID: sessionID, Step: step})
+	if err != nil {
+		return nil, err
+	}
+	return res.Changes, nil
+}
+
 func (c *Client) Invoke(ctx context.Context, sessionID string, pid string, invokeConfig *pb.InvokeConfig, in io.ReadCloser, stdout io.WriteCloser, stderr io.WriteCloser) error {
 	if sessionID == "" || pid == "" {
 		return errors.New("build session ID must be specified")
@@ -116,6 +203,25 @@ func (c *Client) Inspect(ctx context.Context, sessionID string) (*pb.InspectResp
 	return c.client().Inspect(ctx, &pb.InspectRequest{SessionID: sessionID})
 }
 
+// Status writes the progress of the build of the session to w, from its
+// beginning until it finishes.
+func (c *Client) Status(ctx context.Context, sessionID string, w progress.Writer) error {
+	stream, err := c.client().Status(ctx, &pb.StatusRequest{SessionID: sessionID})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to receive status")
+		}
+		w.Write(pb.FromControlStatus(resp))
+	}
+}
+
 func (c *Client) Build(ctx context.Context, options *pb.BuildOptions, in io.ReadCloser, progress progress.Writer) (string, *client.SolveResponse, *build.Inputs, error) {
 	ref := identity.NewID()
 	statusChan := make(chan *client.SolveStatus)
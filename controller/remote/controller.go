@@ -41,6 +41,8 @@ var (
 	defaultLogFilename    = fmt.Sprintf("buildx.%s.log", version.Revision)
 	defaultSocketFilename = fmt.Sprintf("buildx.%s.sock", version.Revision)
 	defaultPIDFilename    = fmt.Sprintf("buildx.%s.pid", version.Revision)
+
+	defaultSessionsDirname = "sessions"
 )
 
 type serverConfig struct {
@@ -52,6 +54,19 @@ type serverConfig struct {
 
 	// Specify file to output buildx server log
 	LogFile string `toml:"log_file"`
+
+	// Specify the socket the server listens on. Set a path other users can
+	// access to share the server with them.
+	Socket string `toml:"socket"`
+
+	// Users are the UIDs of the users allowed to connect to the server with
+	// full access, in addition to its owner.
+	Users []int `toml:"users"`
+
+	// ReadOnlyUsers are the UIDs of the users allowed to connect to the server
+	// with read-only access: they can follow the progress and the processes
+	// of the sessions but can't build, modify or disconnect them.
+	ReadOnlyUsers []int `toml:"read_only_users"`
 }
 
 func NewRemoteBuildxController(ctx context.Context, dockerCli command.Cli, opts control.ControlOptions, logger progress.SubLogger) (control.BuildxController, error) {
@@ -60,10 +75,15 @@ func NewRemoteBuildxController(ctx context.Context, dockerCli command.Cli, opts
 		rootDir = rootDataDir(dockerCli)
 	}
 	serverRoot := filepath.Join(rootDir, "shared")
+	config, err := getConfig(dockerCli, opts.ServerConfig)
+	if err != nil {
+		return nil, err
+	}
+	addr := socketPath(config, serverRoot)
 
 	// connect to buildx server if it is already running
 	ctx2, cancel := context.WithTimeout(ctx, 1*time.Second)
-	c, err := newBuildxClientAndCheck(ctx2, filepath.Join(serverRoot, defaultSocketFilename))
+	c, err := newBuildxClientAndCheck(ctx2, addr)
 	cancel()
 	if err != nil {
 		if !errors.Is(err, context.DeadlineExceeded) {
@@ -91,7 +111,7 @@ func NewRemoteBuildxController(ctx context.Context, dockerCli command.Cli, opts
 
 		// wait for buildx server to be ready
 		ctx2, cancel = context.WithTimeout(ctx, 10*time.Second)
-		c, err = newBuildxClientAndCheck(ctx2, filepath.Join(serverRoot, defaultSocketFilename))
+		c, err = newBuildxClientAndCheck(ctx2, addr)
 		cancel()
 		if err != nil {
 			return errors.Wrap(err, "cannot connect to the buildx server")
@@ -148,13 +168,16 @@ func serveCmd(dockerCli command.Cli) *cobra.Command {
 			}()
 
 			// prepare server
-			b := NewServer(func(ctx context.Context, options *controllerapi.BuildOptions, stdin io.Reader, progress progress.Writer) (*client.SolveResponse, *build.ResultHandle, *build.Inputs, error) {
+			b, err := NewServer(func(ctx context.Context, options *controllerapi.BuildOptions, stdin io.Reader, progress progress.Writer) (*client.SolveResponse, *build.ResultHandle, *build.Inputs, error) {
 				return cbuild.RunBuild(ctx, dockerCli, options, stdin, progress, true)
-			})
+			}, filepath.Join(root, defaultSessionsDirname))
+			if err != nil {
+				return err
+			}
 			defer b.Close()
 
 			// serve server
-			addr := filepath.Join(root, defaultSocketFilename)
+			addr := socketPath(config, root)
 			if err := os.Remove(addr); err != nil && !os.IsNotExist(err) { // avoid EADDRINUSE
 				return err
 			}
@@ -168,9 +191,17 @@ func serveCmd(dockerCli command.Cli) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if len(config.Users) > 0 || len(config.ReadOnlyUsers) > 0 {
+				// Clients are authenticated by their credentials so other users
+				// are allowed to connect to the socket.
+				if err := os.Chmod(addr, 0666); err != nil {
+					return err
+				}
+			}
 			rpc := grpc.NewServer(
-				grpc.UnaryInterceptor(grpcerrors.UnaryServerInterceptor),
-				grpc.StreamInterceptor(grpcerrors.StreamServerInterceptor),
+				grpc.Creds(newPeerCredentials(config.Users, config.ReadOnlyUsers)),
+				grpc.ChainUnaryInterceptor(grpcerrors.UnaryServerInterceptor, authUnaryInterceptor),
+				grpc.ChainStreamInterceptor(grpcerrors.StreamServerInterceptor, authStreamInterceptor),
 			)
 			controllerapi.RegisterControllerServer(rpc, b)
 			doneCh := make(chan struct{})
@@ -239,6 +270,13 @@ func getConfig(dockerCli command.Cli, configPath string) (*serverConfig, error)
 	return &config, nil
 }
 
+func socketPath(config *serverConfig, serverRoot string) string {
+	if config.Socket != "" {
+		return config.Socket
+	}
+	return filepath.Join(serverRoot, defaultSocketFilename)
+}
+
 func prepareRootDir(dockerCli command.Cli, config *serverConfig) (string, error) {
 	rootDir := config.Root
 	if rootDir == "" {
@@ -0,0 +1,99 @@
+//go:build linux
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/buildx/controller/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// copyServer serves the copies of the archive of a fake container.
+type copyServer struct {
+	pb.UnimplementedControllerServer
+
+	archive []byte
+	err     error
+
+	path     string
+	received []byte
+	// copiedTo receives the results of the copies to the container.
+	copiedTo chan error
+}
+
+func (s *copyServer) CopyFromContainer(req *pb.CopyFromContainerRequest, stream pb.Controller_CopyFromContainerServer) error {
+	s.path = req.Path
+	return sendArchive(stream, func(w io.Writer) error {
+		if _, err := w.Write(s.archive); err != nil {
+			return err
+		}
+		return s.err
+	})
+}
+
+func (s *copyServer) CopyToContainer(stream pb.Controller_CopyToContainerServer) (err error) {
+	defer func() {
+		s.copiedTo <- err
+	}()
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.path = msg.GetInit().GetPath()
+	return recvArchive(stream, func(r io.Reader) error {
+		dt, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		s.received = dt
+		return s.err
+	})
+}
+
+func TestCopyFromContainer(t *testing.T) {
+	archive := bytes.Repeat([]byte("0123456789"), 100000)
+	s := &copyServer{archive: archive}
+	c := newTestClient(t, s)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.CopyFromContainer(context.TODO(), "session", "/src", buf))
+	require.Equal(t, "/src", s.path)
+	require.Equal(t, archive, buf.Bytes())
+
+	s.err = errors.New("no such file")
+	require.ErrorContains(t, c.CopyFromContainer(context.TODO(), "session", "/src", io.Discard), "no such file")
+}
+
+func TestCopyToContainer(t *testing.T) {
+	archive := bytes.Repeat([]byte("0123456789"), 100000)
+	s := &copyServer{copiedTo: make(chan error, 3)}
+	c := newTestClient(t, s)
+
+	require.NoError(t, c.CopyToContainer(context.TODO(), "session", "/src", bytes.NewReader(archive)))
+	require.Equal(t, "/src", s.path)
+	require.NoError(t, <-s.copiedTo)
+	require.Equal(t, archive, s.received)
+
+	s.err = errors.New("tar not found")
+	require.ErrorContains(t, c.CopyToContainer(context.TODO(), "session", "/src", strings.NewReader("archive")), "tar not found")
+	require.ErrorContains(t, <-s.copiedTo, "tar not found")
+
+	// the stream is canceled if the client fails to read the archive
+	s.err = nil
+	r := io.MultiReader(strings.NewReader("arch"), &errReader{errors.New("read failed")})
+	require.ErrorContains(t, c.CopyToContainer(context.TODO(), "session", "/src", r), "read failed")
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,83 @@
+//go:build linux
+
+package remote
+
+import (
+	"net"
+	"os"
+	"slices"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// peerCredentials authenticates the clients connecting to the unix socket of
+// the server by their user ID. The owner of the server and the users have
+// full access, the read-only users can only follow the sessions.
+type peerCredentials struct {
+	credentials.TransportCredentials
+
+	users         []int
+	readOnlyUsers []int
+}
+
+func newPeerCredentials(users, readOnlyUsers []int) *peerCredentials {
+	return &peerCredentials{
+		TransportCredentials: insecure.NewCredentials(),
+		users:                append([]int{os.Geteuid()}, users...),
+		readOnlyUsers:        readOnlyUsers,
+	}
+}
+
+func (c *peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uid, err := peerUID(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	info := &authInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		UID:            uid,
+	}
+	switch {
+	case slices.Contains(c.users, uid):
+	case slices.Contains(c.readOnlyUsers, uid):
+		info.ReadOnly = true
+	default:
+		return nil, nil, errors.Errorf("user %d is not allowed to connect", uid)
+	}
+	return conn, info, nil
+}
+
+func (c *peerCredentials) Clone() credentials.TransportCredentials {
+	return &peerCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		users:                slices.Clone(c.users),
+		readOnlyUsers:        slices.Clone(c.readOnlyUsers),
+	}
+}
+
+// peerUID returns the user ID of the process on the other side of the unix
+// socket connection.
+func peerUID(conn net.Conn) (int, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, errors.Errorf("unsupported connection %T", conn)
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return 0, err
+	}
+	if credErr != nil {
+		return 0, errors.Wrap(credErr, "failed to get peer credentials")
+	}
+	return int(cred.Uid), nil
+}
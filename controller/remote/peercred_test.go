@@ -0,0 +1,88 @@
+//go:build linux
+
+package remote
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/buildx/controller/pb"
+	"github.com/moby/buildkit/util/grpcerrors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// handshake connects to a unix socket and returns the auth info of the
+// connection on the server side.
+func handshake(t *testing.T, creds *peerCredentials) (*authInfo, error) {
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "buildx.sock"))
+	require.NoError(t, err)
+	defer l.Close()
+	conn, err := net.Dial("unix", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	sconn, err := l.Accept()
+	require.NoError(t, err)
+	defer sconn.Close()
+
+	_, info, err := creds.ServerHandshake(sconn)
+	if err != nil {
+		return nil, err
+	}
+	return info.(*authInfo), nil
+}
+
+func TestPeerCredentials(t *testing.T) {
+	uid := os.Geteuid()
+
+	// the owner of the server has full access
+	info, err := handshake(t, newPeerCredentials(nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, uid, info.UID)
+	require.False(t, info.ReadOnly)
+
+	info, err = handshake(t, &peerCredentials{readOnlyUsers: []int{uid}})
+	require.NoError(t, err)
+	require.True(t, info.ReadOnly)
+
+	_, err = handshake(t, &peerCredentials{users: []int{uid + 1}, readOnlyUsers: []int{uid + 2}})
+	require.ErrorContains(t, err, "is not allowed to connect")
+
+	_, _, err = newPeerCredentials(nil, nil).ServerHandshake(&net.TCPConn{})
+	require.ErrorContains(t, err, "unsupported connection")
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	srv, err := NewServer(nil, "")
+	require.NoError(t, err)
+	creds := &peerCredentials{
+		TransportCredentials: insecure.NewCredentials(),
+		readOnlyUsers:        []int{os.Geteuid()},
+	}
+	c := newTestClient(t, srv,
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(grpcerrors.UnaryServerInterceptor, authUnaryInterceptor),
+		grpc.ChainStreamInterceptor(grpcerrors.StreamServerInterceptor, authStreamInterceptor),
+	)
+
+	_, err = c.List(context.TODO())
+	require.NoError(t, err)
+
+	err = c.Disconnect(context.TODO(), "session")
+	require.ErrorContains(t, err, "read-only client is not allowed to call Disconnect")
+	require.Equal(t, codes.PermissionDenied, grpcerrors.Code(err))
+
+	err = c.CopyFromContainer(context.TODO(), "session", "/", nil)
+	require.ErrorContains(t, err, "read-only client is not allowed to call CopyFromContainer")
+
+	_, err = c.Diff(context.TODO(), "session", false)
+	require.ErrorContains(t, err, "read-only client is not allowed to call Diff")
+
+	_, err = c.client().Build(context.TODO(), &pb.BuildRequest{SessionID: "session"})
+	require.ErrorContains(t, err, "read-only client is not allowed to call Build")
+}
@@ -17,39 +17,89 @@ import (
 	"github.com/docker/buildx/version"
 	"github.com/moby/buildkit/client"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 )
 
 type BuildFunc func(ctx context.Context, options *pb.BuildOptions, stdin io.Reader, progress progress.Writer) (resp *client.SolveResponse, res *build.ResultHandle, inp *build.Inputs, err error)
 
-func NewServer(buildFunc BuildFunc) *Server {
-	return &Server{
+// NewServer returns a server running builds with buildFunc. If stateDir isn't
+// empty, the sessions are persisted in it and the sessions of the previous
+// servers are restored.
+func NewServer(buildFunc BuildFunc, stateDir string) (*Server, error) {
+	m := &Server{
 		buildFunc: buildFunc,
+		session:   make(map[string]*session),
 	}
+	if stateDir != "" {
+		store, err := newSessionStore(stateDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open session store")
+		}
+		sessions, err := store.load()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to restore sessions")
+		}
+		for sessionID, s := range sessions {
+			s.processes = processes.NewManager()
+			s.status = newStatusHistory(maxStatusHistory)
+			s.status.close()
+			m.session[sessionID] = s
+		}
+		m.store = store
+	}
+	return m, nil
 }
 
 type Server struct {
 	buildFunc BuildFunc
 	session   map[string]*session
 	sessionMu sync.Mutex
+	store     *sessionStore
 }
 
 type session struct {
 	buildOnGoing atomic.Bool
-	statusChan   chan *pb.StatusResponse
+	status       *statusHistory
 	cancelBuild  func()
 	buildOptions *pb.BuildOptions
+	breakpoint   string
+	invokeConfig *pb.InvokeConfig
 	inputPipe    *io.PipeWriter
 
+	// restored is true if the session was restored from the store and
+	// needs to be built again before invoking a container.
+	restored bool
+	// restoring is the build of the restored session in progress.
+	restoring *restoration
+
 	result *build.ResultHandle
 
 	processes *processes.Manager
 }
 
+// restoration is the build of a restored session.
+type restoration struct {
+	done chan struct{}
+	err  error
+}
+
 func (s *session) cancelRunningProcesses() {
 	s.processes.CancelRunningProcesses()
 }
 
+// saveSession persists the state of the session. It must be called with
+// sessionMu held.
+func (m *Server) saveSession(sessionID string, s *session) {
+	if m.store == nil || s.buildOptions == nil {
+		return
+	}
+	if err := m.store.save(sessionID, s.buildOptions, s.invokeConfig, s.breakpoint); err != nil {
+		logrus.Warnf("failed to save session %s: %v", sessionID, err)
+	}
+}
+
 func (m *Server) ListProcesses(ctx context.Context, req *pb.ListProcessesRequest) (res *pb.ListProcessesResponse, err error) {
 	m.sessionMu.Lock()
 	defer m.sessionMu.Unlock()
@@ -72,6 +122,133 @@ func (m *Server) DisconnectProcess(ctx context.Context, req *pb.DisconnectProces
 	return res, s.processes.DeleteProcess(req.ProcessID)
 }
 
+func (m *Server) CopyFromContainer(req *pb.CopyFromContainerRequest, stream pb.Controller_CopyFromContainerServer) error {
+	m.sessionMu.Lock()
+	s, ok := m.session[req.SessionID]
+	m.sessionMu.Unlock()
+	if !ok {
+		return errors.Errorf("copy: unknown session ID %q", req.SessionID)
+	}
+	ctr, err := s.processes.Container()
+	if err != nil {
+		return err
+	}
+	return sendArchive(stream, func(w io.Writer) error {
+		return ctr.CopyFrom(stream.Context(), req.Path, w)
+	})
+}
+
+func (m *Server) CopyToContainer(stream pb.Controller_CopyToContainerServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	init := msg.GetInit()
+	if init == nil {
+		return errors.Errorf("unexpected message: %T; wanted init", msg.GetInput())
+	}
+	m.sessionMu.Lock()
+	s, ok := m.session[init.SessionID]
+	m.sessionMu.Unlock()
+	if !ok {
+		return errors.Errorf("copy: unknown session ID %q", init.SessionID)
+	}
+	ctr, err := s.processes.Container()
+	if err != nil {
+		return err
+	}
+	return recvArchive(stream, func(r io.Reader) error {
+		return ctr.CopyTo(stream.Context(), init.Path, r)
+	})
+}
+
+// sendArchive sends the archive written by copyFn to the stream, followed by
+// an EOF message.
+func sendArchive(stream pb.Controller_CopyFromContainerServer, copyFn func(io.Writer) error) error {
+	if err := copyFn(&dataWriter{stream}); err != nil {
+		return err
+	}
+	return stream.Send(&pb.DataMessage{EOF: true})
+}
+
+// recvArchive passes the archive received from the stream, after its init
+// message, to copyFn.
+func recvArchive(stream pb.Controller_CopyToContainerServer, copyFn func(io.Reader) error) error {
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				pw.CloseWithError(err)
+				return
+			}
+			data := msg.GetData()
+			if data == nil {
+				pw.CloseWithError(errors.Errorf("unexpected message: %T; wanted data", msg.GetInput()))
+				return
+			}
+			if len(data.Data) > 0 {
+				if _, err := pw.Write(data.Data); err != nil {
+					return
+				}
+			}
+			if data.EOF {
+				pw.Close()
+				return
+			}
+		}
+	}()
+	err := copyFn(pr)
+	pr.CloseWithError(errors.New("copy finished"))
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&pb.CopyToContainerResponse{})
+}
+
+func (m *Server) Diff(ctx context.Context, req *pb.DiffRequest) (*pb.DiffResponse, error) {
+	m.sessionMu.Lock()
+	s, ok := m.session[req.SessionID]
+	m.sessionMu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("diff: unknown session ID %q", req.SessionID)
+	}
+	var changes []*pb.FileChange
+	if req.Step {
+		if s.result == nil {
+			return nil, errors.New("diff: no build result is registered")
+		}
+		var err error
+		if changes, err = s.result.StepDiff(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		ctr, err := s.processes.Container()
+		if err != nil {
+			return nil, err
+		}
+		if changes, err = ctr.Diff(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.DiffResponse{Changes: changes}, nil
+}
+
+// dataWriter sends the data written to it as DataMessages.
+type dataWriter struct {
+	stream pb.Controller_CopyFromContainerServer
+}
+
+func (w *dataWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.DataMessage{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 func (m *Server) Info(ctx context.Context, req *pb.InfoRequest) (res *pb.InfoResponse, err error) {
 	return &pb.InfoResponse{
 		BuildxVersion: &pb.BuildxVersion{
@@ -117,6 +294,11 @@ func (m *Server) Disconnect(ctx context.Context, req *pb.DisconnectRequest) (res
 		}
 	}
 	delete(m.session, sessionID)
+	if m.store != nil {
+		if err := m.store.remove(sessionID); err != nil {
+			logrus.Warnf("failed to remove session %s: %v", sessionID, err)
+		}
+	}
 	m.sessionMu.Unlock()
 
 	return &pb.DisconnectResponse{}, nil
@@ -142,15 +324,19 @@ func (m *Server) Inspect(ctx context.Context, req *pb.InspectRequest) (*pb.Inspe
 		return nil, errors.New("inspect: empty session ID")
 	}
 	var bo *pb.BuildOptions
+	var breakpoint string
+	var invokeConfig *pb.InvokeConfig
 	m.sessionMu.Lock()
 	if s, ok := m.session[sessionID]; ok {
 		bo = s.buildOptions
+		breakpoint = s.breakpoint
+		invokeConfig = s.invokeConfig
 	} else {
 		m.sessionMu.Unlock()
 		return nil, errors.Errorf("inspect: unknown key %v", sessionID)
 	}
 	m.sessionMu.Unlock()
-	return &pb.InspectResponse{Options: bo}, nil
+	return &pb.InspectResponse{Options: bo, Breakpoint: breakpoint, InvokeConfig: invokeConfig}, nil
 }
 
 func (m *Server) Build(ctx context.Context, req *pb.BuildRequest) (*pb.BuildResponse, error) {
@@ -161,9 +347,6 @@ func (m *Server) Build(ctx context.Context, req *pb.BuildRequest) (*pb.BuildResp
 
 	// Prepare status channel and session
 	m.sessionMu.Lock()
-	if m.session == nil {
-		m.session = make(map[string]*session)
-	}
 	s, ok := m.session[sessionID]
 	if ok {
 		if !s.buildOnGoing.CompareAndSwap(false, true) {
@@ -179,7 +362,14 @@ func (m *Server) Build(ctx context.Context, req *pb.BuildRequest) (*pb.BuildResp
 
 	s.processes = processes.NewManager()
 	statusChan := make(chan *pb.StatusResponse)
-	s.statusChan = statusChan
+	status := newStatusHistory(maxStatusHistory)
+	go func() {
+		for ss := range statusChan {
+			status.add(ss)
+		}
+		status.close()
+	}()
+	s.status = status
 	inR, inW := io.Pipe()
 	defer inR.Close()
 	s.inputPipe = inW
@@ -190,7 +380,6 @@ func (m *Server) Build(ctx context.Context, req *pb.BuildRequest) (*pb.BuildResp
 		m.sessionMu.Lock()
 		s, ok := m.session[sessionID]
 		if ok {
-			s.statusChan = nil
 			s.buildOnGoing.Store(false)
 		}
 		m.sessionMu.Unlock()
@@ -209,6 +398,8 @@ func (m *Server) Build(ctx context.Context, req *pb.BuildRequest) (*pb.BuildResp
 			s.result = res
 			s.cancelBuild = cancel
 			s.buildOptions = req.Options
+			s.breakpoint = ""
+			s.restored = false
 			m.session[sessionID] = s
 			if buildErr != nil {
 				var ref string
@@ -216,8 +407,13 @@ func (m *Server) Build(ctx context.Context, req *pb.BuildRequest) (*pb.BuildResp
 				if errors.As(buildErr, &ebr) {
 					ref = ebr.Ref
 				}
-				buildErr = controllererrors.WrapBuild(buildErr, sessionID, ref)
+				var bpe *build.BreakpointError
+				if errors.As(buildErr, &bpe) {
+					s.breakpoint = bpe.Step.Digest.String()
+				}
+				buildErr = controllererrors.WrapBuild(buildErr, sessionID, ref, s.breakpoint)
 			}
+			m.saveSession(sessionID, s)
 		}
 	} else {
 		m.sessionMu.Unlock()
@@ -243,32 +439,107 @@ func (m *Server) Status(req *pb.StatusRequest, stream pb.Controller_StatusServer
 		return errors.New("status: empty session ID")
 	}
 
-	// Wait and get status channel prepared by Build()
-	var statusChan <-chan *pb.StatusResponse
+	// Wait and get status history prepared by Build()
+	var status *statusHistory
 	for {
 		// TODO: timeout?
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
 		m.sessionMu.Lock()
-		if _, ok := m.session[sessionID]; !ok || m.session[sessionID].statusChan == nil {
+		if _, ok := m.session[sessionID]; !ok || m.session[sessionID].status == nil {
 			m.sessionMu.Unlock()
 			time.Sleep(time.Millisecond) // TODO: wait Build without busy loop and make it cancellable
 			continue
 		}
-		statusChan = m.session[sessionID].statusChan
+		status = m.session[sessionID].status
 		m.sessionMu.Unlock()
 		break
 	}
 
-	// forward status
-	for ss := range statusChan {
-		if ss == nil {
-			break
+	// forward status from the beginning of the build, so that clients
+	// joining late can follow it too.
+	for i := 0; ; {
+		ss, next, err := status.get(stream.Context(), i)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
 		}
 		if err := stream.Send(ss); err != nil {
 			return err
 		}
+		i = next
 	}
+}
 
-	return nil
+// maxStatusHistory is the number of statuses of a build kept for the clients
+// following it.
+const maxStatusHistory = 10000
+
+// statusHistory records the status of a build so that any number of clients
+// can follow it. Past its limit, the oldest statuses are dropped, and the
+// clients joining late only receive the most recent ones.
+type statusHistory struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	statuses []*pb.StatusResponse
+	// dropped is the number of statuses dropped from the start of statuses.
+	dropped int
+	limit   int
+	closed  bool
+}
+
+func newStatusHistory(limit int) *statusHistory {
+	h := &statusHistory{limit: limit}
+	h.cond = sync.NewCond(&h.mu)
+	return h
+}
+
+func (h *statusHistory) add(ss *pb.StatusResponse) {
+	h.mu.Lock()
+	h.statuses = append(h.statuses, ss)
+	if len(h.statuses) > h.limit {
+		h.statuses[0] = nil
+		h.statuses = h.statuses[1:]
+		h.dropped++
+	}
+	h.cond.Broadcast()
+	h.mu.Unlock()
+}
+
+func (h *statusHistory) close() {
+	h.mu.Lock()
+	h.closed = true
+	h.cond.Broadcast()
+	h.mu.Unlock()
+}
+
+// get returns the i-th status, or the oldest one kept if it was dropped,
+// waiting for it if needed, and the index of the next status. It returns
+// io.EOF if the build finished before.
+func (h *statusHistory) get(ctx context.Context, i int) (*pb.StatusResponse, int, error) {
+	stop := context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		h.cond.Broadcast()
+		h.mu.Unlock()
+	})
+	defer stop()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i >= h.dropped+len(h.statuses) && !h.closed {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		h.cond.Wait()
+	}
+	i = max(i, h.dropped)
+	if i >= h.dropped+len(h.statuses) {
+		return nil, 0, io.EOF
+	}
+	return h.statuses[i-h.dropped], i + 1, nil
 }
 
 func (m *Server) Input(stream pb.Controller_InputServer) (err error) {
@@ -363,10 +634,73 @@ func (m *Server) Input(stream pb.Controller_InputServer) (err error) {
 	return eg.Wait()
 }
 
+// restore builds a restored session again, if it wasn't yet. Concurrent
+// callers wait for the build started by the first one.
+func (m *Server) restore(ctx context.Context, sessionID string) error {
+	m.sessionMu.Lock()
+	s, ok := m.session[sessionID]
+	if !ok {
+		m.sessionMu.Unlock()
+		return errors.Errorf("invoke: unknown session ID %v", sessionID)
+	}
+	if !s.restored {
+		m.sessionMu.Unlock()
+		return nil
+	}
+	r := s.restoring
+	if r != nil {
+		m.sessionMu.Unlock()
+		select {
+		case <-r.done:
+			return r.err
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+	r = &restoration{done: make(chan struct{})}
+	s.restoring = r
+	options := s.buildOptions
+	m.sessionMu.Unlock()
+
+	r.err = m.rebuild(ctx, sessionID, options)
+	m.sessionMu.Lock()
+	if s, ok := m.session[sessionID]; ok {
+		// keep the original options, with their exports, for the next
+		// servers and for the clients inspecting the session
+		s.buildOptions = options
+		s.restoring = nil
+		m.saveSession(sessionID, s)
+	}
+	m.sessionMu.Unlock()
+	close(r.done)
+	return r.err
+}
+
+// rebuild builds a restored session again with its options. Its result was
+// exported by the original build already, so the exports are skipped.
+func (m *Server) rebuild(ctx context.Context, sessionID string, options *pb.BuildOptions) error {
+	options = proto.Clone(options).(*pb.BuildOptions)
+	options.Exports = nil
+	options.ExportPush = false
+	options.ExportLoad = false
+	options.CacheTo = nil
+
+	_, err := m.Build(ctx, &pb.BuildRequest{SessionID: sessionID, Options: options})
+	var be *controllererrors.BuildError
+	if err != nil && !errors.As(err, &be) {
+		return errors.Wrap(err, "failed to restore session")
+	}
+	// A build error is expected for a session of a failed build, whose
+	// result is registered anyway.
+	return nil
+}
+
 func (m *Server) Invoke(srv pb.Controller_InvokeServer) error {
 	containerIn, containerOut := ioset.Pipe()
 	defer func() { containerOut.Close(); containerIn.Close() }()
 
+	readOnly := isReadOnly(srv.Context())
+	stopWatch := func() {}
 	initDoneCh := make(chan *processes.Process)
 	initErrCh := make(chan error)
 	eg, egCtx := errgroup.WithContext(context.TODO())
@@ -382,19 +716,35 @@ func (m *Server) Invoke(srv pb.Controller_InvokeServer) error {
 			sessionID := initMessage.SessionID
 			cfg := initMessage.InvokeConfig
 
+			if !readOnly {
+				// The result of a restored session is gone with the previous
+				// server. Build it again, from the cache if it's still there.
+				if err := m.restore(srv.Context(), sessionID); err != nil {
+					return err
+				}
+			}
 			m.sessionMu.Lock()
 			s, ok := m.session[sessionID]
+			m.sessionMu.Unlock()
 			if !ok {
-				m.sessionMu.Unlock()
 				return errors.Errorf("invoke: unknown session ID %v", sessionID)
 			}
-			m.sessionMu.Unlock()
 
 			pid := initMessage.ProcessID
 			if pid == "" {
 				return errors.Errorf("invoke: specify process ID")
 			}
 			proc, ok := s.processes.Get(pid)
+			if readOnly {
+				if !ok {
+					return errors.Errorf("invoke: read-only client can only attach to a running process")
+				}
+				// Follow the output of the process without taking over its IO.
+				stopWatch = proc.Watch(containerIn.Stdout, containerIn.Stderr)
+				go io.Copy(io.Discard, containerIn.Stdin)
+				initDoneCh <- proc
+				return nil
+			}
 			if !ok {
 				// Start a new process.
 				if cfg == nil {
@@ -405,6 +755,10 @@ func (m *Server) Invoke(srv pb.Controller_InvokeServer) error {
 				if err != nil {
 					return err
 				}
+				m.sessionMu.Lock()
+				s.invokeConfig = cfg
+				m.saveSession(sessionID, s)
+				m.sessionMu.Unlock()
 			}
 			// Attach containerIn to this process
 			proc.ForwardIO(&containerIn, srvIOCancel)
@@ -431,6 +785,17 @@ func (m *Server) Invoke(srv pb.Controller_InvokeServer) error {
 		}
 
 		// Wait for IO done
+		if readOnly {
+			defer stopWatch()
+			select {
+			case <-srvIOCtx.Done():
+				return srvIOCtx.Err()
+			case <-proc.Exited():
+				return nil
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
 		select {
 		case <-srvIOCtx.Done():
 			return srvIOCtx.Err()
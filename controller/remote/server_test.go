@@ -0,0 +1,152 @@
+//go:build linux
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func newTestClient(t *testing.T, srv pb.ControllerServer, opts ...grpc.ServerOption) *Client {
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "buildx.sock"))
+	require.NoError(t, err)
+	rpc := grpc.NewServer(opts...)
+	pb.RegisterControllerServer(rpc, srv)
+	go rpc.Serve(l)
+	t.Cleanup(rpc.Stop)
+
+	c, err := NewClient(context.TODO(), l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// statusRecorder records the names of the vertexes written to it.
+type statusRecorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (r *statusRecorder) Write(s *client.SolveStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range s.Vertexes {
+		r.names = append(r.names, v.Name)
+	}
+}
+
+func (r *statusRecorder) WriteBuildRef(string, string) {}
+
+func (r *statusRecorder) ValidateLogSource(digest.Digest, interface{}) bool { return true }
+
+func (r *statusRecorder) ClearLogSource(interface{}) {}
+
+func vertexStatus(name string) *client.SolveStatus {
+	return &client.SolveStatus{Vertexes: []*client.Vertex{{Digest: digest.FromString(name), Name: name}}}
+}
+
+func TestStatusReplay(t *testing.T) {
+	written := make(chan struct{})
+	release := make(chan struct{})
+	srv, err := NewServer(func(ctx context.Context, _ *pb.BuildOptions, _ io.Reader, pw progress.Writer) (*client.SolveResponse, *build.ResultHandle, *build.Inputs, error) {
+		for i := 0; i < 3; i++ {
+			pw.Write(vertexStatus(fmt.Sprintf("step %d", i)))
+		}
+		close(written)
+		<-release
+		pw.Write(vertexStatus("step 3"))
+		return &client.SolveResponse{}, nil, nil, nil
+	}, "")
+	require.NoError(t, err)
+	c := newTestClient(t, srv)
+
+	builder := &statusRecorder{}
+	buildDone := make(chan error, 1)
+	go func() {
+		_, _, _, err := c.Build(context.TODO(), &pb.BuildOptions{}, nil, builder)
+		buildDone <- err
+	}()
+	<-written
+
+	var sessionID string
+	require.Eventually(t, func() bool {
+		keys, err := c.List(context.TODO())
+		require.NoError(t, err)
+		if len(keys) == 0 {
+			return false
+		}
+		sessionID = keys[0]
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// a client joining late receives the progress from the beginning, then
+	// follows the build until it finishes
+	follower := &statusRecorder{}
+	statusDone := make(chan error, 1)
+	go func() {
+		statusDone <- c.Status(context.TODO(), sessionID, follower)
+	}()
+	close(release)
+	require.NoError(t, <-buildDone)
+	require.NoError(t, <-statusDone)
+
+	expected := []string{"step 0", "step 1", "step 2", "step 3"}
+	require.Equal(t, expected, builder.names)
+	require.Equal(t, expected, follower.names)
+
+	// the progress of a finished build can still be replayed
+	follower = &statusRecorder{}
+	require.NoError(t, c.Status(context.TODO(), sessionID, follower))
+	require.Equal(t, expected, follower.names)
+}
+
+func TestStatusHistory(t *testing.T) {
+	h := newStatusHistory(2)
+	for i := 0; i < 3; i++ {
+		h.add(pb.ToControlStatus(vertexStatus(fmt.Sprintf("step %d", i))))
+	}
+
+	// the oldest status was dropped
+	ss, next, err := h.get(context.TODO(), 0)
+	require.NoError(t, err)
+	require.Equal(t, "step 1", ss.Vertexes[0].Name)
+	require.Equal(t, 2, next)
+	ss, next, err = h.get(context.TODO(), next)
+	require.NoError(t, err)
+	require.Equal(t, "step 2", ss.Vertexes[0].Name)
+	require.Equal(t, 3, next)
+
+	// waiting for the next status
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = h.get(ctx, next)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	got := make(chan *pb.StatusResponse, 1)
+	go func() {
+		ss, _, _ := h.get(context.TODO(), next)
+		got <- ss
+	}()
+	h.add(pb.ToControlStatus(vertexStatus("step 3")))
+	ss = <-got
+	require.NotNil(t, ss)
+	require.Equal(t, "step 3", ss.Vertexes[0].Name)
+
+	h.close()
+	_, _, err = h.get(context.TODO(), next+1)
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,139 @@
+package remote
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/buildx/controller/pb"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sessionState is the state of a session persisted across restarts of the
+// server. The build result isn't persisted: a restored session is built again
+// with its options, from the BuildKit cache, when a container is invoked.
+type sessionState struct {
+	Options      json.RawMessage `json:"options"`
+	InvokeConfig json.RawMessage `json:"invokeConfig,omitempty"`
+	Breakpoint   string          `json:"breakpoint,omitempty"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+}
+
+// sessionStateTTL is how long the state of a session is kept after its last
+// update. The states of the sessions unused for longer are removed by the next
+// server.
+const sessionStateTTL = 7 * 24 * time.Hour
+
+// errSessionExpired is returned when loading the state of a session unused
+// for longer than the TTL of the store.
+var errSessionExpired = errors.New("session expired")
+
+// sessionStore persists the state of the sessions as JSON files in a
+// directory.
+type sessionStore struct {
+	dir string
+	ttl time.Duration
+}
+
+func newSessionStore(dir string) (*sessionStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &sessionStore{dir: dir, ttl: sessionStateTTL}, nil
+}
+
+func (st *sessionStore) save(sessionID string, options *pb.BuildOptions, invokeConfig *pb.InvokeConfig, breakpoint string) error {
+	state := sessionState{
+		Breakpoint: breakpoint,
+		UpdatedAt:  time.Now(),
+	}
+	var err error
+	if state.Options, err = protojson.Marshal(options); err != nil {
+		return err
+	}
+	if invokeConfig != nil {
+		if state.InvokeConfig, err = protojson.Marshal(invokeConfig); err != nil {
+			return err
+		}
+	}
+	dt, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(st.path(sessionID), dt, 0600)
+}
+
+func (st *sessionStore) remove(sessionID string) error {
+	if err := os.Remove(st.path(sessionID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// load returns the sessions persisted by the previous servers. Invalid
+// states are skipped and expired ones are removed.
+func (st *sessionStore) load() (map[string]*session, error) {
+	entries, err := os.ReadDir(st.dir)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make(map[string]*session)
+	for _, e := range entries {
+		sessionID, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		s, err := st.loadSession(sessionID)
+		if errors.Is(err, errSessionExpired) {
+			logrus.Debugf("removing expired session %s", sessionID)
+			if err := st.remove(sessionID); err != nil {
+				logrus.Warnf("failed to remove expired session %s: %v", sessionID, err)
+			}
+			continue
+		}
+		if err != nil {
+			logrus.Warnf("failed to restore session %s: %v", sessionID, err)
+			continue
+		}
+		sessions[sessionID] = s
+	}
+	return sessions, nil
+}
+
+func (st *sessionStore) loadSession(sessionID string) (*session, error) {
+	dt, err := os.ReadFile(st.path(sessionID))
+	if err != nil {
+		return nil, err
+	}
+	var state sessionState
+	if err := json.Unmarshal(dt, &state); err != nil {
+		return nil, err
+	}
+	if time.Since(state.UpdatedAt) > st.ttl {
+		return nil, errSessionExpired
+	}
+	s := &session{
+		buildOptions: &pb.BuildOptions{},
+		breakpoint:   state.Breakpoint,
+		restored:     true,
+	}
+	if err := protojson.Unmarshal(state.Options, s.buildOptions); err != nil {
+		return nil, errors.Wrap(err, "invalid build options")
+	}
+	if len(state.InvokeConfig) > 0 {
+		s.invokeConfig = &pb.InvokeConfig{}
+		if err := protojson.Unmarshal(state.InvokeConfig, s.invokeConfig); err != nil {
+			return nil, errors.Wrap(err, "invalid invoke config")
+		}
+	}
+	return s, nil
+}
+
+func (st *sessionStore) path(sessionID string) string {
+	return filepath.Join(st.dir, filepath.Base(sessionID)+".json")
+}
@@ -0,0 +1,136 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStore(t *testing.T) {
+	st, err := newSessionStore(filepath.Join(t.TempDir(), "sessions"))
+	require.NoError(t, err)
+
+	options := &pb.BuildOptions{
+		ContextPath: "/src",
+		Exports:     []*pb.ExportEntry{{Type: "image", Attrs: map[string]string{"push": "true"}}},
+	}
+	invokeConfig := &pb.InvokeConfig{Entrypoint: []string{"sh"}, Tty: true}
+	require.NoError(t, st.save("s1", options, invokeConfig, "sha256:abc"))
+	require.NoError(t, st.save("s2", options, nil, ""))
+	require.NoError(t, os.WriteFile(filepath.Join(st.dir, "invalid.json"), []byte("{"), 0600))
+
+	sessions, err := st.load()
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	s := sessions["s1"]
+	require.True(t, s.restored)
+	require.Equal(t, "sha256:abc", s.breakpoint)
+	require.Equal(t, "/src", s.buildOptions.ContextPath)
+	require.Equal(t, "image", s.buildOptions.Exports[0].Type)
+	require.Equal(t, []string{"sh"}, s.invokeConfig.Entrypoint)
+	require.True(t, s.invokeConfig.Tty)
+	require.Nil(t, sessions["s2"].invokeConfig)
+
+	// the session IDs can't escape the directory of the store
+	require.Equal(t, filepath.Join(st.dir, "s3.json"), st.path("../s3"))
+
+	require.NoError(t, st.remove("s2"))
+	require.NoError(t, st.remove("s2"))
+	sessions, err = st.load()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
+
+func TestSessionStoreExpiry(t *testing.T) {
+	st, err := newSessionStore(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, st.save("recent", &pb.BuildOptions{}, nil, ""))
+
+	options, err := json.Marshal(&pb.BuildOptions{})
+	require.NoError(t, err)
+	dt, err := json.Marshal(sessionState{Options: options, UpdatedAt: time.Now().Add(-st.ttl - time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(st.path("expired"), dt, 0600))
+
+	sessions, err := st.load()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Contains(t, sessions, "recent")
+	_, err = os.Stat(st.path("expired"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRestoreSession(t *testing.T) {
+	dir := t.TempDir()
+	st, err := newSessionStore(dir)
+	require.NoError(t, err)
+	options := &pb.BuildOptions{
+		ContextPath: "/src",
+		Exports:     []*pb.ExportEntry{{Type: "image"}},
+		ExportPush:  true,
+		ExportLoad:  true,
+		CacheTo:     []*pb.CacheOptionsEntry{{Type: "registry"}},
+	}
+	require.NoError(t, st.save("session", options, nil, ""))
+
+	var builds atomic.Int32
+	release := make(chan struct{})
+	var built *pb.BuildOptions
+	m, err := NewServer(func(ctx context.Context, options *pb.BuildOptions, _ io.Reader, _ progress.Writer) (*client.SolveResponse, *build.ResultHandle, *build.Inputs, error) {
+		builds.Add(1)
+		built = options
+		<-release
+		return &client.SolveResponse{}, &build.ResultHandle{}, nil, nil
+	}, dir)
+	require.NoError(t, err)
+
+	// concurrent invokes wait for the same build
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- m.restore(context.TODO(), "session")
+		}()
+	}
+	require.Eventually(t, func() bool { return builds.Load() == 1 }, 10*time.Second, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, builds.Load())
+
+	// the result was exported by the original build
+	require.Equal(t, "/src", built.ContextPath)
+	require.Empty(t, built.Exports)
+	require.False(t, built.ExportPush)
+	require.False(t, built.ExportLoad)
+	require.Empty(t, built.CacheTo)
+
+	// the original options are kept
+	s := m.session["session"]
+	require.False(t, s.restored)
+	require.Len(t, s.buildOptions.Exports, 1)
+	sessions, err := st.load()
+	require.NoError(t, err)
+	require.Len(t, sessions["session"].buildOptions.Exports, 1)
+
+	require.NoError(t, m.restore(context.TODO(), "session"))
+	require.EqualValues(t, 1, builds.Load())
+	require.ErrorContains(t, m.restore(context.TODO(), "unknown"), "unknown session ID")
+}
@@ -0,0 +1,101 @@
+package dap
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/docker/buildx/build"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/progress"
+	"github.com/opencontainers/go-digest"
+)
+
+// LaunchConfig is the configuration of the build of a debug session. The
+// arguments of the launch request override the fields set on the command
+// line.
+type LaunchConfig struct {
+	// Dockerfile is the path of the Dockerfile.
+	Dockerfile string `json:"dockerfile,omitempty"`
+	// ContextPath is the path of the build context.
+	ContextPath string `json:"contextPath,omitempty"`
+	// Target is the stage to build.
+	Target string `json:"target,omitempty"`
+	// Args are build arguments, added to the ones of the command line.
+	Args map[string]string `json:"args,omitempty"`
+	// StopOnEntry stops at the first step of the build.
+	StopOnEntry bool `json:"stopOnEntry,omitempty"`
+}
+
+// BuildFunc runs the build of cfg, writing its progress to pw. The build
+// pauses at the first step after resumeFrom matching the breakpoints bps,
+// in the form of build.ParseBreakpoints, and returns its result with a
+// *build.BreakpointError. If the build fails with a result, both the result
+// and the error are returned.
+type BuildFunc func(ctx context.Context, cfg LaunchConfig, bps []string, resumeFrom string, pw progress.Writer) (Build, error)
+
+// Build is the result of a build being debugged.
+type Build interface {
+	// Steps returns the steps of the build in the order they were solved.
+	Steps() ([]*build.Step, error)
+	// FailedStep returns the step that failed the build, if any.
+	FailedStep() (*build.Step, error)
+	// Exec runs a process in a container started from the result of a step.
+	Exec(ctx context.Context, dgst digest.Digest, cfg *controllerapi.InvokeConfig, stdin io.ReadCloser, stdout, stderr io.WriteCloser) error
+	// Done releases the build.
+	Done()
+}
+
+// NewBuild returns the Build of a build result.
+func NewBuild(res *build.ResultHandle) Build {
+	return &resultBuild{
+		res:   res,
+		steps: make(map[digest.Digest]*build.ResultHandle),
+	}
+}
+
+type resultBuild struct {
+	res *build.ResultHandle
+
+	mu    sync.Mutex
+	steps map[digest.Digest]*build.ResultHandle
+}
+
+func (b *resultBuild) Steps() ([]*build.Step, error) {
+	return b.res.Steps()
+}
+
+func (b *resultBuild) FailedStep() (*build.Step, error) {
+	return b.res.FailedStep()
+}
+
+func (b *resultBuild) Exec(ctx context.Context, dgst digest.Digest, cfg *controllerapi.InvokeConfig, stdin io.ReadCloser, stdout, stderr io.WriteCloser) error {
+	res, err := b.stepResult(ctx, dgst)
+	if err != nil {
+		return err
+	}
+	ctr, err := build.NewContainer(ctx, res, cfg)
+	if err != nil {
+		return err
+	}
+	defer ctr.Cancel()
+	return ctr.Exec(ctx, cfg, stdin, stdout, stderr)
+}
+
+func (b *resultBuild) Done() {
+	b.res.Done()
+}
+
+func (b *resultBuild) stepResult(ctx context.Context, dgst digest.Digest) (*build.ResultHandle, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if res, ok := b.steps[dgst]; ok {
+		return res, nil
+	}
+	res, err := b.res.StepResult(ctx, dgst)
+	if err != nil {
+		return nil, err
+	}
+	b.steps[dgst] = res
+	return res, nil
+}
@@ -0,0 +1,824 @@
+package dap
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/buildx/build"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/progress"
+	"github.com/google/go-dap"
+	gatewayapi "github.com/moby/buildkit/frontend/gateway/pb"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// threadID is the id of the only thread of a build.
+const threadID = 1
+
+// Kinds of the scopes of a frame.
+const (
+	scopeStep = iota + 1
+	scopeEnv
+	scopeArgs
+)
+
+// Opt is the configuration of a Server.
+type Opt struct {
+	// Launch is the default configuration of the build.
+	Launch LaunchConfig
+	// Build runs the build once the client is configured.
+	Build BuildFunc
+	// AttachCommand is the command attaching a terminal to a shell of the
+	// build. The client runs it in a terminal with the address of the shell
+	// appended.
+	AttachCommand []string
+}
+
+// Server is a Debug Adapter Protocol server debugging a build. The build
+// pauses at the breakpoints set on Dockerfile lines or stage names, before
+// exporting anything, and is resumed up to the next step to stop at when
+// the client continues or steps. Steps that already ran are replayed from
+// the cached results, and execution stops at the step that failed the
+// build.
+type Server struct {
+	opt   Opt
+	in    *bufio.Reader
+	out   io.Writer
+	codec *dap.Codec
+
+	sendMu sync.Mutex
+	seq    int
+
+	mu            sync.Mutex
+	launch        LaunchConfig
+	cfg           LaunchConfig
+	dockerfile    string
+	launched      bool
+	configured    bool
+	started       bool
+	running       bool
+	runInTerminal bool
+	bps           breakpoints
+	build         Build
+	thread        *thread
+	exitCode      int
+	wg            sync.WaitGroup
+}
+
+// NewServer returns a server reading requests from in and writing responses
+// and events to out.
+func NewServer(in io.Reader, out io.Writer, opt Opt) *Server {
+	codec := dap.NewCodec()
+	codec.RegisterRequest("exec", func() dap.Message { return &ExecRequest{} }, func() dap.Message { return &ExecResponse{} })
+	return &Server{
+		opt:    opt,
+		in:     bufio.NewReader(in),
+		out:    out,
+		codec:  codec,
+		launch: opt.Launch,
+		bps: breakpoints{
+			lines: make(map[string][]int),
+		},
+	}
+}
+
+// Serve handles the requests of the client until it disconnects.
+func (s *Server) Serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.wg.Wait()
+		s.mu.Lock()
+		if s.build != nil {
+			s.build.Done()
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		dt, err := dap.ReadBaseMessage(s.in)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		msg, err := s.codec.DecodeMessage(dt)
+		if err != nil {
+			var fe *dap.DecodeProtocolMessageFieldError
+			if !errors.As(err, &fe) {
+				return errors.Wrap(err, "failed to decode message")
+			}
+			if fe.SubType == "Request" {
+				s.send(&dap.ErrorResponse{
+					Response: dap.Response{
+						ProtocolMessage: dap.ProtocolMessage{Type: "response"},
+						RequestSeq:      fe.Seq,
+						Command:         fe.FieldValue,
+						Message:         fmt.Sprintf("unsupported request %q", fe.FieldValue),
+					},
+				})
+				continue
+			}
+			logrus.Warnf("ignoring debug adapter message: %v", err)
+			continue
+		}
+		switch m := msg.(type) {
+		case dap.RequestMessage:
+			if s.handle(ctx, m) {
+				return nil
+			}
+		case dap.ResponseMessage:
+			// responses to the reverse requests of the server
+			if r := m.GetResponse(); !r.Success {
+				s.output("stderr", fmt.Sprintf("%s failed: %s\n", r.Command, r.Message))
+			}
+		}
+	}
+}
+
+// handle handles a request and returns true if the session ended.
+func (s *Server) handle(ctx context.Context, req dap.RequestMessage) bool {
+	switch r := req.(type) {
+	case *dap.InitializeRequest:
+		s.mu.Lock()
+		s.runInTerminal = r.Arguments.SupportsRunInTerminalRequest
+		s.mu.Unlock()
+		s.send(&dap.InitializeResponse{
+			Response: newResponse(r.Request),
+			Body: dap.Capabilities{
+				SupportsConfigurationDoneRequest: true,
+				SupportsFunctionBreakpoints:      true,
+				SupportsEvaluateForHovers:        true,
+				SupportsTerminateRequest:         true,
+			},
+		})
+		s.send(&dap.InitializedEvent{Event: newEvent("initialized")})
+	case *dap.LaunchRequest:
+		s.mu.Lock()
+		if len(r.Arguments) > 0 {
+			if err := json.Unmarshal(r.Arguments, &s.launch); err != nil {
+				s.mu.Unlock()
+				s.sendError(r.Request, errors.Wrap(err, "invalid launch arguments"))
+				return false
+			}
+		}
+		s.launched = true
+		s.mu.Unlock()
+		s.send(&dap.LaunchResponse{Response: newResponse(r.Request)})
+		s.start(ctx)
+	case *dap.SetBreakpointsRequest:
+		s.setBreakpoints(r)
+	case *dap.SetFunctionBreakpointsRequest:
+		s.mu.Lock()
+		s.bps.stages = nil
+		var bps []dap.Breakpoint
+		for _, bp := range r.Arguments.Breakpoints {
+			s.bps.stages = append(s.bps.stages, bp.Name)
+			bps = append(bps, dap.Breakpoint{Verified: true})
+		}
+		s.mu.Unlock()
+		s.send(&dap.SetFunctionBreakpointsResponse{
+			Response: newResponse(r.Request),
+			Body:     dap.SetFunctionBreakpointsResponseBody{Breakpoints: bps},
+		})
+	case *dap.SetExceptionBreakpointsRequest:
+		// the build always stops at the step that failed
+		s.send(&dap.SetExceptionBreakpointsResponse{Response: newResponse(r.Request)})
+	case *dap.ConfigurationDoneRequest:
+		s.mu.Lock()
+		s.configured = true
+		s.mu.Unlock()
+		s.send(&dap.ConfigurationDoneResponse{Response: newResponse(r.Request)})
+		s.start(ctx)
+	case *dap.ThreadsRequest:
+		s.send(&dap.ThreadsResponse{
+			Response: newResponse(r.Request),
+			Body:     dap.ThreadsResponseBody{Threads: []dap.Thread{{Id: threadID, Name: "build"}}},
+		})
+	case *dap.StackTraceRequest:
+		s.stackTrace(r)
+	case *dap.ScopesRequest:
+		s.scopes(r)
+	case *dap.VariablesRequest:
+		s.variables(r)
+	case *dap.ContinueRequest:
+		s.resume(ctx, r.Request, stepContinue, func(resp dap.Response) dap.Message {
+			return &dap.ContinueResponse{Response: resp, Body: dap.ContinueResponseBody{AllThreadsContinued: true}}
+		})
+	case *dap.NextRequest:
+		s.resume(ctx, r.Request, stepNext, func(resp dap.Response) dap.Message {
+			return &dap.NextResponse{Response: resp}
+		})
+	case *dap.StepInRequest:
+		s.resume(ctx, r.Request, stepIn, func(resp dap.Response) dap.Message {
+			return &dap.StepInResponse{Response: resp}
+		})
+	case *dap.StepOutRequest:
+		s.resume(ctx, r.Request, stepOut, func(resp dap.Response) dap.Message {
+			return &dap.StepOutResponse{Response: resp}
+		})
+	case *dap.EvaluateRequest:
+		s.evaluate(ctx, r)
+	case *ExecRequest:
+		s.exec(ctx, r)
+	case *dap.DisconnectRequest:
+		s.send(&dap.DisconnectResponse{Response: newResponse(r.Request)})
+		return true
+	case *dap.TerminateRequest:
+		s.send(&dap.TerminateResponse{Response: newResponse(r.Request)})
+		s.send(&dap.TerminatedEvent{Event: newEvent("terminated")})
+		return true
+	default:
+		s.sendError(*req.GetRequest(), errors.Errorf("unsupported request %q", req.GetRequest().Command))
+	}
+	return false
+}
+
+// start starts the build once the client is launched and configured.
+func (s *Server) start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.launched || !s.configured || s.started {
+		return
+	}
+	s.started = true
+
+	cfg := s.launch
+	if cfg.ContextPath == "" {
+		cfg.ContextPath = "."
+	}
+	dockerfile := cfg.Dockerfile
+	if dockerfile == "" {
+		dockerfile = filepath.Join(cfg.ContextPath, "Dockerfile")
+	}
+	if abs, err := filepath.Abs(dockerfile); err == nil {
+		dockerfile = abs
+	}
+	s.dockerfile = dockerfile
+	s.cfg = cfg
+	s.thread = newThread(sourcePathFunc(dockerfile))
+	s.running = true
+
+	// the build pauses at the first step to stop on entry
+	specs := s.bps.specs()
+	mode, override := stepContinue, ""
+	if cfg.StopOnEntry {
+		specs = []string{"*"}
+		mode, override = stepIn, reasonEntry
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.runBuild(ctx, specs, ""); err != nil {
+			s.output("stderr", fmt.Sprintf("ERROR: %v\n", err))
+			s.exit(1)
+			return
+		}
+		s.advance(ctx, mode, override)
+	}()
+}
+
+// runBuild runs the build, pausing at the steps matching specs after the
+// step resumeFrom, and updates the thread with its steps. It returns an
+// error if the build failed without a result to debug.
+func (s *Server) runBuild(ctx context.Context, specs []string, resumeFrom string) error {
+	printer, err := progress.NewPrinter(ctx, &outputFile{s: s}, progressui.PlainMode)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	cfg := s.cfg
+	s.mu.Unlock()
+	b, err := s.opt.Build(ctx, cfg, specs, resumeFrom, printer)
+	if err1 := printer.Wait(); err == nil {
+		err = err1
+	}
+	var bpe *build.BreakpointError
+	var paused *build.Step
+	if errors.As(err, &bpe) {
+		paused = bpe.Step
+		err = nil
+	}
+	if b == nil {
+		if err == nil {
+			err = errors.New("build returned no result")
+		}
+		return err
+	}
+	if err != nil {
+		s.output("stderr", fmt.Sprintf("ERROR: %v\n", err))
+	}
+	steps, err1 := b.Steps()
+	if err1 != nil {
+		b.Done()
+		return err1
+	}
+	failed, err1 := b.FailedStep()
+	if err1 != nil {
+		logrus.Warnf("failed to find the failed step: %v", err1)
+	}
+
+	s.mu.Lock()
+	prev := s.build
+	s.build = b
+	s.thread.update(steps, failed, paused)
+	s.exitCode = 0
+	if err != nil {
+		s.exitCode = 1
+	}
+	s.mu.Unlock()
+	if prev != nil {
+		prev.Done()
+	}
+	return nil
+}
+
+// advance moves the thread to the next step to stop at, resuming the build
+// up to it if it hasn't run yet, and reports where it stopped. The reason
+// of the stop is replaced by override for steps stopped for stepping.
+func (s *Server) advance(ctx context.Context, mode stepMode, override string) {
+	for {
+		s.mu.Lock()
+		bps, err := build.ParseBreakpoints(s.bps.specs(), "")
+		if err != nil {
+			s.mu.Unlock()
+			s.output("stderr", fmt.Sprintf("ERROR: %v\n", err))
+			s.exit(1)
+			return
+		}
+		idx, reason := s.thread.next(mode, bps)
+		if idx >= 0 && s.thread.solved(idx) {
+			s.thread.pos = idx
+			cur := s.thread.current()
+			s.running = false
+			s.mu.Unlock()
+			if override != "" && reason == reasonStep {
+				reason = override
+			}
+			body := dap.StoppedEventBody{
+				Reason:            reason,
+				ThreadId:          threadID,
+				AllThreadsStopped: true,
+				Description:       cur.Name,
+			}
+			if reason == reasonException {
+				body.Description = "Step failed"
+				body.Text = cur.Name
+			}
+			s.send(&dap.StoppedEvent{Event: newEvent("stopped"), Body: body})
+			return
+		}
+		if s.thread.paused < 0 {
+			code := s.exitCode
+			s.thread.pos = len(s.thread.steps)
+			s.running = false
+			s.mu.Unlock()
+			s.exit(code)
+			return
+		}
+		// resume the build up to the next step to stop at, or to its end
+		// if there is none
+		resumeFrom := s.thread.steps[s.thread.paused].Digest.String()
+		var specs []string
+		if idx >= 0 {
+			specs = []string{s.thread.steps[idx].Digest.String()}
+		}
+		s.mu.Unlock()
+		if err := s.runBuild(ctx, specs, resumeFrom); err != nil {
+			s.output("stderr", fmt.Sprintf("ERROR: %v\n", err))
+			s.exit(1)
+			return
+		}
+	}
+}
+
+func (s *Server) exit(code int) {
+	s.send(&dap.ExitedEvent{Event: newEvent("exited"), Body: dap.ExitedEventBody{ExitCode: code}})
+	s.send(&dap.TerminatedEvent{Event: newEvent("terminated")})
+}
+
+func (s *Server) resume(ctx context.Context, req dap.Request, mode stepMode, resp func(dap.Response) dap.Message) {
+	s.mu.Lock()
+	stopped := s.thread != nil && s.thread.current() != nil && !s.running
+	if stopped {
+		s.running = true
+	}
+	s.mu.Unlock()
+	if !stopped {
+		s.sendError(req, errors.New("build is not stopped"))
+		return
+	}
+	s.send(resp(newResponse(req)))
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.advance(ctx, mode, "")
+	}()
+}
+
+func (s *Server) setBreakpoints(r *dap.SetBreakpointsRequest) {
+	path := r.Arguments.Source.Path
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	lines := make([]int, 0, len(r.Arguments.Breakpoints))
+	bps := make([]dap.Breakpoint, 0, len(r.Arguments.Breakpoints))
+	for _, bp := range r.Arguments.Breakpoints {
+		if bp.Line < 1 {
+			bps = append(bps, dap.Breakpoint{Message: "invalid line"})
+			continue
+		}
+		lines = append(lines, bp.Line)
+		bps = append(bps, dap.Breakpoint{
+			Verified: true,
+			Source:   &r.Arguments.Source,
+			Line:     bp.Line,
+		})
+	}
+	s.mu.Lock()
+	s.bps.lines[path] = lines
+	s.mu.Unlock()
+	s.send(&dap.SetBreakpointsResponse{
+		Response: newResponse(r.Request),
+		Body:     dap.SetBreakpointsResponseBody{Breakpoints: bps},
+	})
+}
+
+func (s *Server) stackTrace(r *dap.StackTraceRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.thread == nil || s.thread.current() == nil {
+		s.sendError(r.Request, errors.New("build is not stopped"))
+		return
+	}
+	idxs := append([]int{s.thread.pos}, s.thread.callers(s.thread.pos)...)
+	frames := make([]dap.StackFrame, 0, len(idxs))
+	for _, i := range idxs {
+		frames = append(frames, s.frame(i))
+	}
+	s.send(&dap.StackTraceResponse{
+		Response: newResponse(r.Request),
+		Body:     dap.StackTraceResponseBody{StackFrames: frames, TotalFrames: len(frames)},
+	})
+}
+
+// frame returns the stack frame of a step. The id of the frame is the index
+// of the step plus one.
+func (s *Server) frame(idx int) dap.StackFrame {
+	step := s.thread.steps[idx]
+	f := dap.StackFrame{
+		Id:     idx + 1,
+		Name:   step.Name,
+		Column: 1,
+	}
+	if len(step.Locations) > 0 {
+		loc := step.Locations[0]
+		path := s.thread.sourcePath(loc.Filename)
+		f.Source = &dap.Source{Name: filepath.Base(path), Path: path}
+		f.Line = loc.StartLine
+		f.EndLine = loc.EndLine
+	}
+	return f
+}
+
+func (s *Server) scopes(r *dap.ScopesRequest) {
+	id := r.Arguments.FrameId
+	s.send(&dap.ScopesResponse{
+		Response: newResponse(r.Request),
+		Body: dap.ScopesResponseBody{Scopes: []dap.Scope{
+			{Name: "Step", PresentationHint: "locals", VariablesReference: id<<2 | scopeStep},
+			{Name: "Environment", VariablesReference: id<<2 | scopeEnv},
+			{Name: "Build Arguments", VariablesReference: id<<2 | scopeArgs},
+		}},
+	})
+}
+
+func (s *Server) variables(r *dap.VariablesRequest) {
+	ref := r.Arguments.VariablesReference
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	step, ok := s.step(ref >> 2)
+	if !ok {
+		s.sendError(r.Request, errors.Errorf("invalid variables reference %d", ref))
+		return
+	}
+
+	vars := []dap.Variable{}
+	add := func(name, value string) {
+		vars = append(vars, dap.Variable{Name: name, Value: value, EvaluateName: name})
+	}
+	switch ref & 3 {
+	case scopeStep:
+		add("instruction", step.Name)
+		add("stage", step.Stage)
+		if step.Meta != nil {
+			add("args", strings.Join(step.Meta.Args, " "))
+			add("workdir", step.Meta.Cwd)
+			add("user", step.Meta.User)
+		}
+		if p := step.Op.GetPlatform(); p != nil {
+			add("platform", strings.Join(slices.DeleteFunc([]string{p.OS, p.Architecture, p.Variant}, func(s string) bool { return s == "" }), "/"))
+		}
+		add("digest", step.Digest.String())
+	case scopeEnv:
+		if step.Meta != nil {
+			for _, e := range step.Meta.Env {
+				k, v, _ := strings.Cut(e, "=")
+				add(k, v)
+			}
+		}
+	case scopeArgs:
+		keys := make([]string, 0, len(s.launch.Args))
+		for k := range s.launch.Args {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(k, s.launch.Args[k])
+		}
+	}
+	s.send(&dap.VariablesResponse{
+		Response: newResponse(r.Request),
+		Body:     dap.VariablesResponseBody{Variables: vars},
+	})
+}
+
+// step returns the step of a frame id, the current step for 0.
+func (s *Server) step(frameID int) (*build.Step, bool) {
+	if s.thread == nil {
+		return nil, false
+	}
+	if frameID == 0 {
+		cur := s.thread.current()
+		return cur, cur != nil
+	}
+	if frameID < 1 || frameID > len(s.thread.steps) {
+		return nil, false
+	}
+	return s.thread.steps[frameID-1], true
+}
+
+// evaluate runs the expression as a shell command in the step of the frame
+// in the debug console, or looks up the environment variable of the
+// expression in other contexts.
+func (s *Server) evaluate(ctx context.Context, r *dap.EvaluateRequest) {
+	s.mu.Lock()
+	step, ok := s.step(r.Arguments.FrameId)
+	s.mu.Unlock()
+	if !ok {
+		s.sendError(r.Request, errors.New("build is not stopped"))
+		return
+	}
+
+	if r.Arguments.Context != "repl" {
+		if step.Meta != nil {
+			for _, e := range step.Meta.Env {
+				if k, v, _ := strings.Cut(e, "="); k == r.Arguments.Expression {
+					s.send(&dap.EvaluateResponse{
+						Response: newResponse(r.Request),
+						Body:     dap.EvaluateResponseBody{Result: v},
+					})
+					return
+				}
+			}
+		}
+		s.sendError(r.Request, errors.Errorf("%s is not defined", r.Arguments.Expression))
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		out, code, err := s.run(ctx, step, []string{"/bin/sh", "-c", r.Arguments.Expression})
+		if err != nil {
+			s.sendError(r.Request, err)
+			return
+		}
+		if code != 0 {
+			out += fmt.Sprintf("exit code: %d", code)
+		}
+		s.send(&dap.EvaluateResponse{
+			Response: newResponse(r.Request),
+			Body:     dap.EvaluateResponseBody{Result: out},
+		})
+	}()
+}
+
+// ExecRequest is a custom request running a process in a container started
+// from the result of a step. With Tty set, the process runs in a terminal
+// of the client, which must support the runInTerminal request.
+type ExecRequest struct {
+	dap.Request
+
+	Arguments ExecArguments `json:"arguments"`
+}
+
+// ExecArguments are the arguments of an ExecRequest.
+type ExecArguments struct {
+	// FrameId is the frame of the step, the current step if unset.
+	FrameId int `json:"frameId,omitempty"` //nolint:revive
+	// Args are the command and its arguments, a shell if unset.
+	Args []string `json:"args,omitempty"`
+	// Tty runs the process interactively in a terminal of the client.
+	Tty bool `json:"tty,omitempty"`
+}
+
+// ExecResponse is the response of an ExecRequest.
+type ExecResponse struct {
+	dap.Response
+
+	Body ExecResponseBody `json:"body"`
+}
+
+// ExecResponseBody is the output of a process run without a terminal.
+type ExecResponseBody struct {
+	Output   string `json:"output,omitempty"`
+	ExitCode int    `json:"exitCode"`
+}
+
+func (s *Server) exec(ctx context.Context, r *ExecRequest) {
+	s.mu.Lock()
+	step, ok := s.step(r.Arguments.FrameId)
+	runInTerminal := s.runInTerminal
+	s.mu.Unlock()
+	if !ok {
+		s.sendError(r.Request, errors.New("build is not stopped"))
+		return
+	}
+	args := r.Arguments.Args
+	if len(args) == 0 {
+		args = []string{"/bin/sh"}
+	}
+
+	if r.Arguments.Tty {
+		if !runInTerminal || len(s.opt.AttachCommand) == 0 {
+			s.sendError(r.Request, errors.New("client doesn't support running a terminal"))
+			return
+		}
+		addr, err := s.shell(ctx, step, args)
+		if err != nil {
+			s.sendError(r.Request, err)
+			return
+		}
+		s.send(&dap.RunInTerminalRequest{
+			Request: newRequest("runInTerminal"),
+			Arguments: dap.RunInTerminalRequestArguments{
+				Kind:  "integrated",
+				Title: step.Name,
+				Args:  append(slices.Clone(s.opt.AttachCommand), addr),
+				Env:   map[string]any{"BUILDX_EXPERIMENTAL": "1"},
+			},
+		})
+		s.send(&ExecResponse{Response: newResponse(r.Request)})
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		out, code, err := s.run(ctx, step, args)
+		if err != nil {
+			s.sendError(r.Request, err)
+			return
+		}
+		s.send(&ExecResponse{
+			Response: newResponse(r.Request),
+			Body:     ExecResponseBody{Output: out, ExitCode: code},
+		})
+	}()
+}
+
+// run runs a process without a terminal in the step and returns its
+// combined output and exit code.
+func (s *Server) run(ctx context.Context, step *build.Step, args []string) (string, int, error) {
+	s.mu.Lock()
+	b := s.build
+	s.mu.Unlock()
+
+	var buf syncBuffer
+	err := b.Exec(ctx, step.Digest, &controllerapi.InvokeConfig{
+		Cmd:    args,
+		NoUser: true,
+		NoCwd:  true,
+	}, io.NopCloser(strings.NewReader("")), nopCloser{&buf}, nopCloser{&buf})
+	if err != nil {
+		var exitErr *gatewayapi.ExitError
+		if errors.As(err, &exitErr) {
+			return buf.String(), int(exitErr.ExitCode), nil
+		}
+		return "", 0, err
+	}
+	return buf.String(), 0, nil
+}
+
+func (s *Server) output(category, out string) {
+	s.send(&dap.OutputEvent{
+		Event: newEvent("output"),
+		Body:  dap.OutputEventBody{Category: category, Output: out},
+	})
+}
+
+func (s *Server) send(msg dap.Message) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	s.seq++
+	switch m := msg.(type) {
+	case dap.ResponseMessage:
+		m.GetResponse().Seq = s.seq
+	case dap.EventMessage:
+		m.GetEvent().Seq = s.seq
+	case dap.RequestMessage:
+		m.GetRequest().Seq = s.seq
+	}
+	if err := dap.WriteProtocolMessage(s.out, msg); err != nil {
+		logrus.Warnf("failed to write debug adapter message: %v", err)
+	}
+}
+
+func (s *Server) sendError(req dap.Request, err error) {
+	resp := newResponse(req)
+	resp.Success = false
+	resp.Message = err.Error()
+	s.send(&dap.ErrorResponse{
+		Response: resp,
+		Body: dap.ErrorResponseBody{Error: &dap.ErrorMessage{
+			Format:   err.Error(),
+			ShowUser: true,
+		}},
+	})
+}
+
+func newResponse(req dap.Request) dap.Response {
+	return dap.Response{
+		ProtocolMessage: dap.ProtocolMessage{Type: "response"},
+		RequestSeq:      req.Seq,
+		Command:         req.Command,
+		Success:         true,
+	}
+}
+
+func newEvent(event string) dap.Event {
+	return dap.Event{
+		ProtocolMessage: dap.ProtocolMessage{Type: "event"},
+		Event:           event,
+	}
+}
+
+func newRequest(command string) dap.Request {
+	return dap.Request{
+		ProtocolMessage: dap.ProtocolMessage{Type: "request"},
+		Command:         command,
+	}
+}
+
+// outputFile sends the progress of the build as output events.
+type outputFile struct {
+	s *Server
+}
+
+func (f *outputFile) Read([]byte) (int, error) { return 0, io.EOF }
+func (f *outputFile) Close() error             { return nil }
+func (f *outputFile) Fd() uintptr              { return ^uintptr(0) }
+func (f *outputFile) Name() string             { return os.DevNull }
+
+func (f *outputFile) Write(p []byte) (int, error) {
+	f.s.output("console", string(p))
+	return len(p), nil
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
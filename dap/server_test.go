@@ -0,0 +1,367 @@
+package dap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/docker/buildx/build"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/progress"
+	"github.com/google/go-dap"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	steps := []*build.Step{
+		testStep("[base 1/2] FROM alpine", "base", 1, nil),
+		testStep("[base 2/2] RUN apk add make", "base", 2, &pb.Meta{Env: []string{"PATH=/bin"}}),
+		testStep("[build 1/2] WORKDIR /src", "build", 5, nil),
+		testStep("[build 2/2] RUN make", "build", 6, &pb.Meta{Cwd: "/src"}),
+	}
+	steps[1].Inputs = []digest.Digest{steps[0].Digest}
+	steps[2].Inputs = []digest.Digest{steps[1].Digest}
+	steps[3].Inputs = []digest.Digest{steps[2].Digest}
+	b := &fakeBuild{steps: steps, failed: steps[3]}
+
+	c := newTestClient(t, Opt{
+		Launch: LaunchConfig{ContextPath: "/src", Args: map[string]string{"VERSION": "1"}},
+		Build: func(ctx context.Context, cfg LaunchConfig, bps []string, resumeFrom string, pw progress.Writer) (Build, error) {
+			require.Equal(t, "/src", cfg.ContextPath)
+			require.Equal(t, "release", cfg.Target)
+			require.Equal(t, []string{"/src/Dockerfile:2"}, bps)
+			require.Empty(t, resumeFrom)
+			// the build fails before reaching the breakpoint
+			return b, errors.New("process did not complete successfully")
+		},
+	})
+
+	c.request("initialize", dap.InitializeRequestArguments{AdapterID: "buildx"})
+	c.expectResponse("initialize")
+	c.expectEvent("initialized")
+	c.request("launch", LaunchConfig{Target: "release"})
+	c.expectResponse("launch")
+	c.request("setBreakpoints", dap.SetBreakpointsArguments{
+		Source:      dap.Source{Path: "/src/Dockerfile"},
+		Breakpoints: []dap.SourceBreakpoint{{Line: 2}},
+	})
+	c.expectResponse("setBreakpoints")
+	c.request("configurationDone", nil)
+	c.expectResponse("configurationDone")
+
+	require.Equal(t, reasonBreakpoint, c.expectStopped().Reason)
+
+	var st dap.StackTraceResponse
+	c.request("stackTrace", dap.StackTraceArguments{ThreadId: threadID})
+	c.expectResponse("stackTrace", &st)
+	require.Len(t, st.Body.StackFrames, 2)
+	require.Equal(t, "[base 2/2] RUN apk add make", st.Body.StackFrames[0].Name)
+	require.Equal(t, "/src/Dockerfile", st.Body.StackFrames[0].Source.Path)
+	require.Equal(t, 2, st.Body.StackFrames[0].Line)
+	require.Equal(t, "[build 1/2] WORKDIR /src", st.Body.StackFrames[1].Name)
+
+	var vars dap.VariablesResponse
+	c.request("variables", dap.VariablesArguments{VariablesReference: st.Body.StackFrames[0].Id<<2 | scopeEnv})
+	c.expectResponse("variables", &vars)
+	require.Equal(t, []dap.Variable{{Name: "PATH", Value: "/bin", EvaluateName: "PATH"}}, vars.Body.Variables)
+	c.request("variables", dap.VariablesArguments{VariablesReference: st.Body.StackFrames[0].Id<<2 | scopeArgs})
+	c.expectResponse("variables", &vars)
+	require.Equal(t, []dap.Variable{{Name: "VERSION", Value: "1", EvaluateName: "VERSION"}}, vars.Body.Variables)
+
+	// the base stage has no more steps
+	c.request("next", dap.NextArguments{ThreadId: threadID})
+	c.expectResponse("next")
+	require.Equal(t, reasonStep, c.expectStopped().Reason)
+	c.request("continue", dap.ContinueArguments{ThreadId: threadID})
+	c.expectResponse("continue")
+	require.Equal(t, reasonException, c.expectStopped().Reason)
+
+	var eval dap.EvaluateResponse
+	c.request("evaluate", dap.EvaluateArguments{Expression: "ls", Context: "repl"})
+	c.expectResponse("evaluate", &eval)
+	require.Equal(t, "/bin/sh -c ls\n", eval.Body.Result)
+	require.Equal(t, steps[3].Digest, b.execDigest)
+
+	var exec ExecResponse
+	c.request("exec", ExecArguments{Args: []string{"false"}, FrameId: 1})
+	c.expectResponse("exec", &exec)
+	require.Equal(t, "false\n", exec.Body.Output)
+	require.Equal(t, steps[0].Digest, b.execDigest)
+
+	c.request("continue", dap.ContinueArguments{ThreadId: threadID})
+	c.expectResponse("continue")
+	var exited dap.ExitedEvent
+	c.expectEvent("exited", &exited)
+	require.Equal(t, 1, exited.Body.ExitCode)
+	c.expectEvent("terminated")
+
+	c.request("disconnect", nil)
+	c.expectResponse("disconnect")
+	require.NoError(t, c.close())
+	require.True(t, b.done)
+}
+
+func TestServerPause(t *testing.T) {
+	steps := []*build.Step{
+		testStep("[base 1/2] FROM alpine", "base", 1, nil),
+		testStep("[base 2/2] RUN apk add make", "base", 2, nil),
+		testStep("[build 1/2] WORKDIR /src", "build", 5, nil),
+		testStep("[build 2/2] RUN make", "build", 6, nil),
+	}
+	steps[1].Inputs = []digest.Digest{steps[0].Digest}
+	steps[2].Inputs = []digest.Digest{steps[1].Digest}
+	steps[3].Inputs = []digest.Digest{steps[2].Digest}
+
+	type call struct {
+		bps        []string
+		resumeFrom string
+	}
+	var calls []call
+	var builds []*fakeBuild
+	c := newTestClient(t, Opt{
+		Launch: LaunchConfig{ContextPath: "/src"},
+		Build: func(ctx context.Context, cfg LaunchConfig, specs []string, resumeFrom string, pw progress.Writer) (Build, error) {
+			calls = append(calls, call{bps: specs, resumeFrom: resumeFrom})
+			b := &fakeBuild{steps: steps}
+			builds = append(builds, b)
+			bps, err := build.ParseBreakpoints(specs, "")
+			if err != nil {
+				return nil, err
+			}
+			start := 0
+			if resumeFrom != "" {
+				start = slices.IndexFunc(steps, func(s *build.Step) bool { return s.Digest.String() == resumeFrom }) + 1
+			}
+			for _, s := range steps[start:] {
+				if bps.Match(s) {
+					return b, &build.BreakpointError{Step: s}
+				}
+			}
+			b.exported = true
+			return b, nil
+		},
+	})
+
+	c.request("initialize", dap.InitializeRequestArguments{AdapterID: "buildx"})
+	c.expectResponse("initialize")
+	c.expectEvent("initialized")
+	c.request("launch", nil)
+	c.expectResponse("launch")
+	c.request("setBreakpoints", dap.SetBreakpointsArguments{
+		Source:      dap.Source{Path: "/src/Dockerfile"},
+		Breakpoints: []dap.SourceBreakpoint{{Line: 2}},
+	})
+	c.expectResponse("setBreakpoints")
+	c.request("configurationDone", nil)
+	c.expectResponse("configurationDone")
+
+	// the build pauses at the breakpoint without exporting
+	require.Equal(t, reasonBreakpoint, c.expectStopped().Reason)
+	require.Len(t, builds, 1)
+	require.False(t, builds[0].exported)
+
+	// stepping resumes the build up to the next step
+	c.request("stepIn", dap.StepInArguments{ThreadId: threadID})
+	c.expectResponse("stepIn")
+	require.Equal(t, reasonStep, c.expectStopped().Reason)
+	var st dap.StackTraceResponse
+	c.request("stackTrace", dap.StackTraceArguments{ThreadId: threadID})
+	c.expectResponse("stackTrace", &st)
+	require.Equal(t, "[build 1/2] WORKDIR /src", st.Body.StackFrames[0].Name)
+	require.Len(t, builds, 2)
+	require.False(t, builds[1].exported)
+	require.True(t, builds[0].done)
+
+	// continuing without more breakpoints runs the build to its end
+	c.request("continue", dap.ContinueArguments{ThreadId: threadID})
+	c.expectResponse("continue")
+	var exited dap.ExitedEvent
+	c.expectEvent("exited", &exited)
+	require.Equal(t, 0, exited.Body.ExitCode)
+	c.expectEvent("terminated")
+
+	require.Equal(t, []call{
+		{bps: []string{"/src/Dockerfile:2"}},
+		{bps: []string{steps[2].Digest.String()}, resumeFrom: steps[1].Digest.String()},
+		{resumeFrom: steps[2].Digest.String()},
+	}, calls)
+	require.Len(t, builds, 3)
+	require.True(t, builds[2].exported)
+
+	c.request("disconnect", nil)
+	c.expectResponse("disconnect")
+	require.NoError(t, c.close())
+	require.True(t, builds[2].done)
+}
+
+func TestThreadStepOut(t *testing.T) {
+	steps := []*build.Step{
+		testStep("[base 1/2] FROM alpine", "base", 1, nil),
+		testStep("[base 2/2] RUN true", "base", 2, nil),
+		testStep("[build 1/2] FROM alpine", "build", 4, nil),
+		testStep("[build 2/2] COPY --from=base / /", "build", 5, nil),
+	}
+	steps[1].Inputs = []digest.Digest{steps[0].Digest}
+	steps[3].Inputs = []digest.Digest{steps[2].Digest, steps[1].Digest}
+
+	th := newThread(sourcePathFunc("/src/Dockerfile"))
+	th.update(steps, nil, nil)
+	idx, reason := th.next(stepIn, nil)
+	require.Equal(t, 0, idx)
+	require.Equal(t, reasonStep, reason)
+	th.pos = idx
+	require.Equal(t, []int{3}, th.callers(0))
+
+	bps, err := build.ParseBreakpoints([]string{"build"}, "")
+	require.NoError(t, err)
+	idx, _ = th.next(stepOut, bps)
+	require.Equal(t, 2, idx)
+	th.pos = idx
+	idx, _ = th.next(stepContinue, bps)
+	require.Equal(t, 3, idx)
+	th.pos = idx
+	idx, _ = th.next(stepContinue, bps)
+	require.Equal(t, -1, idx)
+
+	// steps after the one the build is paused at haven't been solved
+	th.update(steps, nil, steps[1])
+	require.Equal(t, 3, th.pos)
+	require.True(t, th.solved(1))
+	require.False(t, th.solved(2))
+}
+
+func testStep(name, stage string, line int, meta *pb.Meta) *build.Step {
+	return &build.Step{
+		Digest:    digest.FromString(name),
+		Op:        &pb.Op{},
+		Name:      name,
+		Stage:     stage,
+		Meta:      meta,
+		Locations: []build.StepLocation{{Filename: "Dockerfile", StartLine: line, EndLine: line}},
+	}
+}
+
+type fakeBuild struct {
+	steps      []*build.Step
+	failed     *build.Step
+	execDigest digest.Digest
+	exported   bool
+	done       bool
+}
+
+func (b *fakeBuild) Steps() ([]*build.Step, error) {
+	return b.steps, nil
+}
+
+func (b *fakeBuild) FailedStep() (*build.Step, error) {
+	return b.failed, nil
+}
+
+func (b *fakeBuild) Exec(ctx context.Context, dgst digest.Digest, cfg *controllerapi.InvokeConfig, stdin io.ReadCloser, stdout, stderr io.WriteCloser) error {
+	b.execDigest = dgst
+	_, err := io.WriteString(stdout, strings.Join(cfg.Cmd, " ")+"\n")
+	return err
+}
+
+func (b *fakeBuild) Done() {
+	b.done = true
+}
+
+type testClient struct {
+	t     *testing.T
+	in    *io.PipeWriter
+	out   *bufio.Reader
+	codec *dap.Codec
+	seq   int
+	errCh chan error
+}
+
+func newTestClient(t *testing.T, opt Opt) *testClient {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	s := NewServer(inR, outW, opt)
+	c := &testClient{
+		t:     t,
+		in:    inW,
+		out:   bufio.NewReader(outR),
+		codec: s.codec,
+		errCh: make(chan error, 1),
+	}
+	go func() {
+		err := s.Serve(context.TODO())
+		outW.Close()
+		c.errCh <- err
+	}()
+	return c
+}
+
+func (c *testClient) request(command string, args interface{}) {
+	c.seq++
+	req := map[string]interface{}{
+		"seq":     c.seq,
+		"type":    "request",
+		"command": command,
+	}
+	if args != nil {
+		req["arguments"] = args
+	}
+	dt, err := json.Marshal(req)
+	require.NoError(c.t, err)
+	require.NoError(c.t, dap.WriteBaseMessage(c.in, dt))
+}
+
+// next returns the next message that isn't an output event.
+func (c *testClient) next() dap.Message {
+	for {
+		dt, err := dap.ReadBaseMessage(c.out)
+		require.NoError(c.t, err)
+		msg, err := c.codec.DecodeMessage(dt)
+		require.NoError(c.t, err)
+		if _, ok := msg.(*dap.OutputEvent); !ok {
+			return msg
+		}
+	}
+}
+
+func (c *testClient) expectResponse(command string, out ...interface{}) {
+	msg := c.next()
+	r, ok := msg.(dap.ResponseMessage)
+	require.True(c.t, ok, "expected response, got %T", msg)
+	require.Equal(c.t, command, r.GetResponse().Command)
+	require.True(c.t, r.GetResponse().Success, r.GetResponse().Message)
+	c.decode(msg, out)
+}
+
+func (c *testClient) expectEvent(event string, out ...interface{}) {
+	msg := c.next()
+	e, ok := msg.(dap.EventMessage)
+	require.True(c.t, ok, "expected event, got %T", msg)
+	require.Equal(c.t, event, e.GetEvent().Event)
+	c.decode(msg, out)
+}
+
+func (c *testClient) expectStopped() dap.StoppedEventBody {
+	var ev dap.StoppedEvent
+	c.expectEvent("stopped", &ev)
+	return ev.Body
+}
+
+func (c *testClient) decode(msg dap.Message, out []interface{}) {
+	for _, o := range out {
+		dt, err := json.Marshal(msg)
+		require.NoError(c.t, err)
+		require.NoError(c.t, json.Unmarshal(dt, o))
+	}
+}
+
+func (c *testClient) close() error {
+	c.in.Close()
+	return <-c.errCh
+}
@@ -0,0 +1,87 @@
+package dap
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/buildx/build"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// shell listens on a unix socket for the terminal of the client and runs
+// args in the step with the connection as its tty. It returns the address
+// of the socket.
+func (s *Server) shell(ctx context.Context, step *build.Step, args []string) (string, error) {
+	dir, err := os.MkdirTemp("", "buildx-dap-")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	addr := filepath.Join(dir, "shell.sock")
+	l, err := net.Listen("unix", addr)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", errors.WithStack(err)
+	}
+
+	s.mu.Lock()
+	b := s.build
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer os.RemoveAll(dir)
+
+		stop := context.AfterFunc(ctx, func() {
+			l.Close()
+		})
+		defer stop()
+		conn, err := l.Accept()
+		l.Close()
+		if err != nil {
+			if ctx.Err() == nil {
+				logrus.Warnf("failed to accept terminal connection: %v", err)
+			}
+			return
+		}
+		defer conn.Close()
+
+		err = b.Exec(ctx, step.Digest, &controllerapi.InvokeConfig{
+			Cmd:    args,
+			NoUser: true,
+			NoCwd:  true,
+			Tty:    true,
+		}, conn, nopCloser{conn}, nopCloser{conn})
+		if err != nil {
+			s.output("stderr", "shell: "+err.Error()+"\n")
+		}
+	}()
+	return addr, nil
+}
+
+// Attach connects the terminal of stdin and stdout to the shell listening on
+// addr. It returns when the shell exits.
+func Attach(ctx context.Context, addr string, stdin io.Reader, stdout io.Writer) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", addr)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to the shell")
+	}
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	go io.Copy(conn, stdin)
+	if _, err := io.Copy(stdout, conn); err != nil && !errors.Is(err, net.ErrClosed) {
+		return errors.Wrap(err, "failed to read from the shell")
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package dap
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/docker/buildx/build"
+	"github.com/opencontainers/go-digest"
+)
+
+// stepMode is the way execution resumes from a stop.
+type stepMode int
+
+const (
+	// stepContinue runs until a breakpoint or the end of the build.
+	stepContinue stepMode = iota
+	// stepNext stops at the next step of the same stage, or at the next step
+	// if the stage has no more steps.
+	stepNext
+	// stepIn stops at the next step, whatever its stage.
+	stepIn
+	// stepOut stops at the next step of another stage.
+	stepOut
+)
+
+// Stop reasons of the stopped events.
+const (
+	reasonEntry      = "entry"
+	reasonStep       = "step"
+	reasonBreakpoint = "breakpoint"
+	reasonException  = "exception"
+)
+
+// breakpoints are the line and stage breakpoints set by the client.
+type breakpoints struct {
+	// lines are the lines of the breakpoints, by absolute source path
+	lines map[string][]int
+	// stages are the names of the stages with a breakpoint
+	stages []string
+}
+
+// specs returns the breakpoints in the form of build.ParseBreakpoints.
+func (b *breakpoints) specs() []string {
+	paths := make([]string, 0, len(b.lines))
+	for p := range b.lines {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var specs []string
+	for _, p := range paths {
+		for _, l := range b.lines[p] {
+			specs = append(specs, p+":"+strconv.Itoa(l))
+		}
+	}
+	return append(specs, b.stages...)
+}
+
+// thread walks the steps of a build. The steps up to the one the build is
+// paused at, or all of them once the build ended, have been solved and are
+// replayed from the cache, the failed step being the last one that ran.
+type thread struct {
+	steps []*build.Step
+	// failed is the index of the failed step, -1 if the build didn't fail
+	failed int
+	// paused is the index of the step the build is paused at, -1 once the
+	// build ended
+	paused int
+	// pos is the index of the current step, -1 before the first stop
+	pos int
+	// sourcePath returns the absolute path of a source file of the build
+	sourcePath func(string) string
+}
+
+func newThread(sourcePath func(string) string) *thread {
+	return &thread{
+		failed:     -1,
+		paused:     -1,
+		pos:        -1,
+		sourcePath: sourcePath,
+	}
+}
+
+// update sets the steps of the build, after it paused or ended, keeping the
+// current step.
+func (t *thread) update(steps []*build.Step, failed, paused *build.Step) {
+	var cur digest.Digest
+	if s := t.current(); s != nil {
+		cur = s.Digest
+	}
+	t.steps = steps
+	t.failed, t.paused, t.pos = -1, -1, -1
+	if failed != nil {
+		t.failed = t.index(failed.Digest)
+	}
+	if paused != nil {
+		t.paused = t.index(paused.Digest)
+	}
+	if cur != "" {
+		t.pos = t.index(cur)
+	}
+}
+
+// current returns the step the thread is stopped at.
+func (t *thread) current() *build.Step {
+	if t.pos < 0 || t.pos >= len(t.steps) {
+		return nil
+	}
+	return t.steps[t.pos]
+}
+
+// last returns the index of the last step that can be stopped at: the
+// failed step, or the last step of a build that is paused or succeeded.
+func (t *thread) last() int {
+	if t.failed >= 0 {
+		return t.failed
+	}
+	return len(t.steps) - 1
+}
+
+// next returns the index of the next step to stop at and the reason of the
+// stop, or -1 if the build ends without another stop. Steps after the one
+// the build is paused at are returned too: the build must be resumed up to
+// them before stopping.
+func (t *thread) next(mode stepMode, bps *build.Breakpoints) (int, string) {
+	last := t.last()
+	if t.pos < 0 && mode != stepContinue {
+		mode = stepIn
+	}
+	if mode == stepNext {
+		stage := t.steps[t.pos].Stage
+		if !slices.ContainsFunc(t.steps[t.pos+1:last+1], func(s *build.Step) bool { return s.Stage == stage }) {
+			mode = stepIn
+		}
+	}
+	for i := t.pos + 1; i <= last; i++ {
+		s := t.steps[i]
+		var reason string
+		switch {
+		case i == t.failed:
+			reason = reasonException
+		case bps.Match(s):
+			reason = reasonBreakpoint
+		case mode == stepIn:
+			reason = reasonStep
+		case mode == stepNext && s.Stage == t.steps[t.pos].Stage:
+			reason = reasonStep
+		case mode == stepOut && s.Stage != t.steps[t.pos].Stage:
+			reason = reasonStep
+		default:
+			continue
+		}
+		return i, reason
+	}
+	return -1, ""
+}
+
+// solved returns true if the step idx has been solved by the build.
+func (t *thread) solved(idx int) bool {
+	return t.paused < 0 || idx <= t.paused
+}
+
+// callers returns the steps of the stages consuming the stage of the step,
+// closest first.
+func (t *thread) callers(idx int) []int {
+	var out []int
+	for len(out) < len(t.steps) {
+		stage := t.steps[idx].Stage
+		next := -1
+		for i := idx + 1; i < len(t.steps) && next < 0; i++ {
+			if t.steps[i].Stage == stage {
+				continue
+			}
+			for _, in := range t.steps[i].Inputs {
+				if j := t.index(in); j >= 0 && t.steps[j].Stage == stage {
+					next = i
+					break
+				}
+			}
+		}
+		if next < 0 {
+			break
+		}
+		out = append(out, next)
+		idx = next
+	}
+	return out
+}
+
+func (t *thread) index(dgst digest.Digest) int {
+	return slices.IndexFunc(t.steps, func(s *build.Step) bool {
+		return s.Digest == dgst
+	})
+}
+
+// sourcePathFunc returns a function mapping the source file names of the
+// build to the absolute path of the Dockerfile. The Dockerfile frontend
+// names the source after the base name of the Dockerfile.
+func sourcePathFunc(dockerfile string) func(string) string {
+	return func(name string) string {
+		if filepath.IsAbs(name) {
+			return name
+		}
+		if filepath.Base(name) == filepath.Base(dockerfile) {
+			return dockerfile
+		}
+		return filepath.Join(filepath.Dir(dockerfile), name)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/docker/buildx/util/imagetools"
 	"github.com/docker/buildx/util/progress"
 	"github.com/docker/cli/opts"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
@@ -114,8 +115,9 @@ func (d *Driver) create(ctx context.Context, l progress.SubLogger) error {
 	}
 
 	cfg := &container.Config{
-		Image: imageName,
-		Env:   d.env,
+		Image:  imageName,
+		Env:    d.env,
+		Labels: driver.ConfigLabels(getBuildkitFlags(d.InitConfig), d.InitConfig.Files, d.InitConfig.DriverOpts),
 	}
 	cfg.Cmd = getBuildkitFlags(d.InitConfig)
 
@@ -318,14 +320,35 @@ func (d *Driver) Info(ctx context.Context) (*driver.Info, error) {
 	if ctn.State.Running {
 		return &driver.Info{
 			Status: driver.Running,
+			Drift:  d.drift(ctn),
 		}, nil
 	}
 
 	return &driver.Info{
 		Status: driver.Stopped,
+		Drift:  d.drift(ctn),
 	}, nil
 }
 
+func (d *Driver) drift(ctn types.ContainerJSON) []driver.Drift {
+	if ctn.Config == nil {
+		return nil
+	}
+	var drifts []driver.Drift
+	imageName := bkimage.DefaultImage
+	if d.image != "" {
+		imageName = d.image
+	}
+	if ctn.Config.Image != imageName {
+		drifts = append(drifts, driver.Drift{
+			Field:   "image",
+			Current: ctn.Config.Image,
+			Desired: imageName,
+		})
+	}
+	return append(drifts, driver.DetectDrift(ctn.Config.Labels, getBuildkitFlags(d.InitConfig), d.InitConfig.Files, d.InitConfig.DriverOpts)...)
+}
+
 func (d *Driver) Version(ctx context.Context) (string, error) {
 	bufStdout := &bytes.Buffer{}
 	bufStderr := &bytes.Buffer{}
@@ -0,0 +1,105 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// LabelBuildkitdFlagsDigest, LabelConfigFilesDigest and
+	// LabelDriverOptsDigest are set on the BuildKit instance by the drivers
+	// that support drift detection to record the configuration it was
+	// created with.
+	LabelBuildkitdFlagsDigest = "com.docker.buildx.buildkitd-flags.digest"
+	LabelConfigFilesDigest    = "com.docker.buildx.buildkitd-config.digest"
+	LabelDriverOptsDigest     = "com.docker.buildx.driver-opts.digest"
+)
+
+// Drift describes a setting that differs between the node stored for a
+// builder and the BuildKit instance that is currently created for it.
+type Drift struct {
+	Field   string
+	Current string `json:",omitempty"`
+	Desired string `json:",omitempty"`
+}
+
+func (d Drift) String() string {
+	if d.Current == "" && d.Desired == "" {
+		return d.Field
+	}
+	return fmt.Sprintf("%s (current: %s, desired: %s)", d.Field, d.Current, d.Desired)
+}
+
+// ConfigLabels returns the labels that record the configuration used to
+// create a BuildKit instance so it can be compared later with DetectDrift.
+// The image driver option isn't part of the digest of the driver options as
+// the drivers compare the image of the instance directly, and report it with
+// its current and desired values.
+func ConfigLabels(buildkitdFlags []string, files map[string][]byte, driverOpts map[string]string) map[string]string {
+	if _, ok := driverOpts["image"]; ok {
+		driverOpts = maps.Clone(driverOpts)
+		delete(driverOpts, "image")
+	}
+	return map[string]string{
+		LabelBuildkitdFlagsDigest: configDigest(buildkitdFlags),
+		LabelConfigFilesDigest:    configDigest(files),
+		LabelDriverOptsDigest:     configDigest(driverOpts),
+	}
+}
+
+// DetectDrift compares the labels of an existing BuildKit instance with the
+// ones that would be set for the current configuration. Labels missing on
+// the instance are ignored as they have been created by an older version.
+func DetectDrift(current map[string]string, buildkitdFlags []string, files map[string][]byte, driverOpts map[string]string) []Drift {
+	desired := ConfigLabels(buildkitdFlags, files, driverOpts)
+	var drifts []Drift
+	for _, f := range []struct {
+		field string
+		label string
+	}{
+		{"flags", LabelBuildkitdFlagsDigest},
+		{"config", LabelConfigFilesDigest},
+		{"driver-opts", LabelDriverOptsDigest},
+	} {
+		v, ok := current[f.label]
+		if !ok {
+			continue
+		}
+		if v != desired[f.label] {
+			drifts = append(drifts, Drift{Field: f.field})
+		}
+	}
+	return drifts
+}
+
+// FormatDrift returns a comma separated list of the drifted fields.
+func FormatDrift(drifts []Drift) string {
+	fields := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		fields = append(fields, d.Field)
+	}
+	return strings.Join(fields, ", ")
+}
+
+func configDigest(v any) string {
+	switch vv := v.(type) {
+	case []string:
+		if len(vv) == 0 {
+			v = nil
+		}
+	case map[string]string:
+		if len(vv) == 0 {
+			v = nil
+		}
+	case map[string][]byte:
+		if len(vv) == 0 {
+			v = nil
+		}
+	}
+	dt, _ := json.Marshal(v)
+	return digest.FromBytes(dt).String()
+}
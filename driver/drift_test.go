@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDrift(t *testing.T) {
+	flags := []string{"--debug"}
+	files := map[string][]byte{"buildkitd.toml": []byte("debug = true")}
+	opts := map[string]string{"image": "moby/buildkit:latest"}
+	labels := ConfigLabels(flags, files, opts)
+
+	require.Empty(t, DetectDrift(labels, flags, files, opts))
+	require.Empty(t, DetectDrift(nil, []string{"--trace"}, nil, nil))
+	require.Empty(t, DetectDrift(ConfigLabels(nil, nil, nil), []string{}, map[string][]byte{}, map[string]string{}))
+
+	drifts := DetectDrift(labels, []string{"--trace"}, files, map[string]string{"image": "moby/buildkit:latest", "network": "host"})
+	require.Equal(t, []Drift{{Field: "flags"}, {Field: "driver-opts"}}, drifts)
+	require.Equal(t, "flags, driver-opts", FormatDrift(drifts))
+
+	// the image is compared by the drivers
+	require.Empty(t, DetectDrift(labels, flags, files, map[string]string{"image": "moby/buildkit:master"}))
+
+	drifts = DetectDrift(labels, flags, map[string][]byte{"buildkitd.toml": []byte("debug = false")}, opts)
+	require.Equal(t, []Drift{{Field: "config"}}, drifts)
+}
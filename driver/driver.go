@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/docker/buildx/store"
 	"github.com/docker/buildx/util/progress"
@@ -56,6 +57,19 @@ type Info struct {
 	Status Status
 	// DynamicNodes must be empty if the actual nodes are statically listed in the store
 	DynamicNodes []store.Node
+	// Drift lists the settings of the node that the BuildKit instance has
+	// not been created with. Recreating the instance is required to apply them.
+	Drift []Drift
+	// Health is the result of the connection health probe for drivers
+	// connecting to an existing BuildKit instance.
+	Health *Health
+}
+
+type Health struct {
+	// Latency is the round-trip time of the probe.
+	Latency time.Duration `json:",omitempty"`
+	// Error is set if the BuildKit instance could not be reached.
+	Error string `json:",omitempty"`
 }
 
 type Auth interface {
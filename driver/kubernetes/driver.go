@@ -138,6 +138,7 @@ func (d *Driver) Info(ctx context.Context) (*driver.Info, error) {
 	if depl.Status.ReadyReplicas <= 0 {
 		return &driver.Info{
 			Status: driver.Stopped,
+			Drift:  d.drift(depl),
 		}, nil
 	}
 	pods, err := podchooser.ListRunningPods(ctx, d.podClient, depl)
@@ -165,9 +166,23 @@ func (d *Driver) Info(ctx context.Context) (*driver.Info, error) {
 	return &driver.Info{
 		Status:       driver.Running,
 		DynamicNodes: dynNodes,
+		Drift:        d.drift(depl),
 	}, nil
 }
 
+func (d *Driver) drift(depl *appsv1.Deployment) []driver.Drift {
+	var drifts []driver.Drift
+	desired := d.deployment.Spec.Template.Spec.Containers[0]
+	if current := depl.Spec.Template.Spec.Containers; len(current) > 0 && current[0].Image != desired.Image {
+		drifts = append(drifts, driver.Drift{
+			Field:   "image",
+			Current: current[0].Image,
+			Desired: desired.Image,
+		})
+	}
+	return append(drifts, driver.DetectDrift(depl.Annotations, desired.Args, d.InitConfig.Files, d.InitConfig.DriverOpts)...)
+}
+
 func (d *Driver) Version(ctx context.Context) (string, error) {
 	return "", nil
 }
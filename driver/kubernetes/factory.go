@@ -141,6 +141,17 @@ func (f *factory) New(ctx context.Context, cfg driver.InitConfig) (driver.Driver
 		return nil, err
 	}
 
+	// record the configuration on the deployment only, so it can be
+	// compared on subsequent runs without restarting the pods
+	annotations := make(map[string]string, len(d.deployment.Annotations)+3)
+	for k, v := range d.deployment.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range driver.ConfigLabels(d.deployment.Spec.Template.Spec.Containers[0].Args, cfg.Files, cfg.DriverOpts) {
+		annotations[k] = v
+	}
+	d.deployment.Annotations = annotations
+
 	d.minReplicas = deploymentOpt.Replicas
 
 	d.deploymentClient = clientset.AppsV1().Deployments(namespace)
@@ -38,6 +38,9 @@ type InitConfig struct {
 	Platforms       []specs.Platform
 	ContextPathHash string
 	DialMeta        map[string][]string
+	// CacheDir is a directory the driver can cache data in across
+	// invocations. Drivers must work without it.
+	CacheDir string
 }
 
 var drivers map[string]Factory
@@ -12,6 +12,8 @@ import (
 
 	"github.com/docker/buildx/driver"
 	util "github.com/docker/buildx/driver/remote/util"
+	"github.com/docker/buildx/store"
+	"github.com/docker/buildx/util/platformutil"
 	"github.com/docker/buildx/util/progress"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/connhelper"
@@ -26,8 +28,13 @@ type Driver struct {
 	// if you add fields, remember to update docs:
 	// https://github.com/docker/docs/blob/main/content/build/drivers/remote.md
 	*tlsOpts
+	sshDialer   *sshDialer
 	defaultLoad bool
 
+	// discovery is set if the endpoint lists the BuildKit instances to use
+	// instead of addressing one
+	discovery bool
+
 	// remote driver caches the client because its Bootstap/Info methods reuse it internally
 	clientOnce sync.Once
 	client     *client.Client
@@ -42,6 +49,9 @@ type tlsOpts struct {
 }
 
 func (d *Driver) Bootstrap(ctx context.Context, l progress.Logger) error {
+	if d.discovery {
+		return errors.Errorf("discovery endpoint %s cannot be bootstrapped", d.InitConfig.EndpointAddr)
+	}
 	c, err := d.Client(ctx)
 	if err != nil {
 		return err
@@ -54,21 +64,57 @@ func (d *Driver) Bootstrap(ctx context.Context, l progress.Logger) error {
 }
 
 func (d *Driver) Info(ctx context.Context) (*driver.Info, error) {
+	if d.discovery {
+		return d.discoveryInfo(ctx)
+	}
+
 	c, err := d.Client(ctx)
 	if err != nil {
 		return &driver.Info{
 			Status: driver.Inactive,
+			Health: &driver.Health{Error: err.Error()},
 		}, nil
 	}
 
+	// listing workers is used as health probe
+	start := time.Now()
 	if _, err := c.ListWorkers(ctx); err != nil {
 		return &driver.Info{
 			Status: driver.Inactive,
+			Health: &driver.Health{Error: err.Error()},
 		}, nil
 	}
 
 	return &driver.Info{
 		Status: driver.Running,
+		Health: &driver.Health{Latency: time.Since(start)},
+	}, nil
+}
+
+func (d *Driver) discoveryInfo(ctx context.Context) (*driver.Info, error) {
+	eps, err := util.DiscoverCached(ctx, d.InitConfig.EndpointAddr, d.InitConfig.CacheDir)
+	if err != nil {
+		return &driver.Info{
+			Status: driver.Inactive,
+			Health: &driver.Health{Error: err.Error()},
+		}, nil
+	}
+	dynNodes := make([]store.Node, 0, len(eps))
+	for _, ep := range eps {
+		pp, err := platformutil.Parse(ep.Platforms)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid platforms for discovered endpoint %s", ep.Endpoint)
+		}
+		dynNodes = append(dynNodes, store.Node{
+			Name:       ep.Name,
+			Endpoint:   ep.Endpoint,
+			Platforms:  pp,
+			DriverOpts: d.InitConfig.DriverOpts,
+		})
+	}
+	return &driver.Info{
+		Status:       driver.Running,
+		DynamicNodes: dynNodes,
 	}, nil
 }
 
@@ -100,6 +146,13 @@ func (d *Driver) Client(ctx context.Context, opts ...client.ClientOpt) (*client.
 }
 
 func (d *Driver) Dial(ctx context.Context) (net.Conn, error) {
+	if d.discovery {
+		return nil, errors.Errorf("cannot dial discovery endpoint %s", d.InitConfig.EndpointAddr)
+	}
+	if d.sshDialer != nil {
+		return d.sshDialer.Dial(ctx)
+	}
+
 	addr := d.InitConfig.EndpointAddr
 	ch, err := connhelper.GetConnectionHelper(addr)
 	if err != nil {
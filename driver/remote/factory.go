@@ -58,6 +58,8 @@ func (f *factory) New(ctx context.Context, cfg driver.InitConfig) (driver.Driver
 
 	tls := &tlsOpts{}
 	tlsEnabled := false
+	sshOpt := sshOpts{}
+	sshClient := sshClientOpenSSH
 	for k, v := range cfg.DriverOpts {
 		switch k {
 		case "servername":
@@ -81,6 +83,23 @@ func (f *factory) New(ctx context.Context, cfg driver.InitConfig) (driver.Driver
 			}
 			tls.key = v
 			tlsEnabled = true
+		case "ssh-client":
+			switch v {
+			case sshClientOpenSSH, sshClientNative:
+				sshClient = v
+			default:
+				return nil, errors.Errorf("invalid value %q for %s, expected %s or %s", v, k, sshClientOpenSSH, sshClientNative)
+			}
+		case "ssh-key":
+			if !filepath.IsAbs(v) {
+				return nil, errors.Errorf("non-absolute path '%s' provided for %s", v, k)
+			}
+			sshOpt.key = v
+		case "ssh-known-hosts":
+			if !filepath.IsAbs(v) {
+				return nil, errors.Errorf("non-absolute path '%s' provided for %s", v, k)
+			}
+			sshOpt.knownHosts = v
 		case "default-load":
 			parsed, err := strconv.ParseBool(v)
 			if err != nil {
@@ -92,6 +111,13 @@ func (f *factory) New(ctx context.Context, cfg driver.InitConfig) (driver.Driver
 		}
 	}
 
+	if util.IsDiscoveryEndpoint(cfg.EndpointAddr) {
+		// connection options are validated by the drivers created for the
+		// discovered endpoints
+		d.discovery = true
+		return d, nil
+	}
+
 	if tlsEnabled {
 		if tls.serverName == "" {
 			// guess servername as hostname of target address
@@ -117,6 +143,24 @@ func (f *factory) New(ctx context.Context, cfg driver.InitConfig) (driver.Driver
 		d.tlsOpts = tls
 	}
 
+	isSSH := strings.HasPrefix(cfg.EndpointAddr, "ssh://")
+	if (sshOpt != sshOpts{}) && sshClient != sshClientNative {
+		return nil, errors.Errorf("ssh-key and ssh-known-hosts require ssh-client=%s", sshClientNative)
+	}
+	if sshClient == sshClientNative {
+		if !isSSH {
+			return nil, errors.Errorf("ssh-client=%s requires an ssh:// endpoint", sshClientNative)
+		}
+		if d.tlsOpts != nil {
+			return nil, errors.New("tls options are not supported with ssh endpoints")
+		}
+		sshDialer, err := newSSHDialer(cfg.EndpointAddr, sshOpt)
+		if err != nil {
+			return nil, err
+		}
+		d.sshDialer = sshDialer
+	}
+
 	return d, nil
 }
 
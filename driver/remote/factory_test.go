@@ -0,0 +1,103 @@
+package remote
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/buildx/driver"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestFactorySSHOpts(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	dir := t.TempDir()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsFile, nil, 0600))
+
+	f := &factory{}
+	for _, tt := range []struct {
+		name     string
+		endpoint string
+		opts     map[string]string
+		err      string
+	}{
+		{
+			name:     "openssh",
+			endpoint: "ssh://user@host",
+		},
+		{
+			name:     "native",
+			endpoint: "ssh://user@host:2222/run/buildkit/buildkitd.sock",
+			opts: map[string]string{
+				"ssh-client":      "native",
+				"ssh-key":         keyFile,
+				"ssh-known-hosts": knownHostsFile,
+			},
+		},
+		{
+			name:     "invalid client",
+			endpoint: "ssh://user@host",
+			opts:     map[string]string{"ssh-client": "foo"},
+			err:      `invalid value "foo" for ssh-client`,
+		},
+		{
+			name:     "key without native client",
+			endpoint: "ssh://user@host",
+			opts:     map[string]string{"ssh-key": keyFile},
+			err:      "require ssh-client=native",
+		},
+		{
+			name:     "native without ssh endpoint",
+			endpoint: "tcp://host:1234",
+			opts:     map[string]string{"ssh-client": "native"},
+			err:      "requires an ssh:// endpoint",
+		},
+		{
+			name:     "relative key path",
+			endpoint: "ssh://user@host",
+			opts:     map[string]string{"ssh-client": "native", "ssh-key": "id_ed25519"},
+			err:      "non-absolute path",
+		},
+		{
+			// keys and known hosts are loaded on dial
+			name:     "missing known hosts and key",
+			endpoint: "ssh://user@host",
+			opts: map[string]string{
+				"ssh-client":      "native",
+				"ssh-key":         filepath.Join(dir, "missing"),
+				"ssh-known-hosts": filepath.Join(dir, "missing"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := f.New(context.TODO(), driver.InitConfig{
+				EndpointAddr: tt.endpoint,
+				DriverOpts:   tt.opts,
+			})
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.opts["ssh-client"] == "native", d.(*Driver).sshDialer != nil)
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	require.Equal(t, `'unix:///run/buildkit/buildkitd.sock'`, shellQuote("unix:///run/buildkit/buildkitd.sock"))
+	require.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
@@ -0,0 +1,376 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	sshhelper "github.com/moby/buildkit/client/connhelper/ssh"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// valid values for driver-opt ssh-client
+	sshClientOpenSSH = "openssh"
+	sshClientNative  = "native"
+
+	sshDefaultPort       = "22"
+	sshKeepAliveInterval = 30 * time.Second
+)
+
+type sshOpts struct {
+	key        string
+	knownHosts string
+}
+
+// sshDialer connects to a BuildKit daemon on a remote host by running
+// "buildctl dial-stdio" over a native SSH connection. The SSH connection is
+// shared by the dialed connections and is closed with the last of them. It
+// is established again by the next dial.
+type sshDialer struct {
+	spec *sshhelper.Spec
+	opts sshOpts
+
+	mu     sync.Mutex
+	client *ssh.Client
+	conns  int
+}
+
+func newSSHDialer(endpoint string, opts sshOpts) (*sshDialer, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := sshhelper.SpecFromURL(u)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Port == "" {
+		spec.Port = sshDefaultPort
+	}
+	if spec.User == "" {
+		spec.User = os.Getenv("USER")
+	}
+	// The known hosts and the keys are loaded by the first dial, so that
+	// commands that don't connect to the node don't require them.
+	return &sshDialer{
+		spec: spec,
+		opts: opts,
+	}, nil
+}
+
+// Dial opens a new session on the shared SSH connection and returns a
+// connection to the stdio of "buildctl dial-stdio".
+func (d *sshDialer) Dial(ctx context.Context) (net.Conn, error) {
+	c, err := d.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := c.NewSession()
+	if err != nil {
+		// the connection may have been closed by the remote, reconnect once
+		d.reset(c)
+		d.release(c)
+		if c, err = d.acquire(ctx); err != nil {
+			return nil, err
+		}
+		if sess, err = c.NewSession(); err != nil {
+			d.release(c)
+			return nil, errors.Wrap(err, "failed to open ssh session")
+		}
+	}
+
+	conn, err := d.start(sess)
+	if err != nil {
+		sess.Close()
+		d.release(c)
+		return nil, err
+	}
+	conn.localAddr = c.LocalAddr()
+	conn.remoteAddr = c.RemoteAddr()
+	conn.onClose = func() { d.release(c) }
+	return conn, nil
+}
+
+func (d *sshDialer) start(sess *ssh.Session) (*sshConn, error) {
+	stdin, err := sess.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := sess.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	sess.Stderr = &sshLogWriter{}
+
+	cmd := "buildctl"
+	if d.spec.Socket != "" {
+		cmd += " --addr " + shellQuote("unix://"+d.spec.Socket)
+	}
+	cmd += " dial-stdio"
+	if err := sess.Start(cmd); err != nil {
+		return nil, errors.Wrapf(err, "failed to run %q on %s", cmd, d.spec.Host)
+	}
+	return &sshConn{
+		sess:   sess,
+		stdin:  stdin,
+		stdout: stdout,
+	}, nil
+}
+
+// acquire returns the shared SSH connection, establishing it if needed. The
+// connection must be released by the caller.
+func (d *sshDialer) acquire(ctx context.Context) (*ssh.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client == nil {
+		c, err := d.connect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d.client = c
+		d.conns = 0
+		go d.keepAlive(c)
+	}
+	d.conns++
+	return d.client, nil
+}
+
+// release releases a connection acquired from c and closes c with the last
+// connection.
+func (d *sshDialer) release(c *ssh.Client) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client != c {
+		// c has been reset already
+		return
+	}
+	d.conns--
+	if d.conns == 0 {
+		d.client = nil
+		c.Close()
+	}
+}
+
+func (d *sshDialer) connect(ctx context.Context) (*ssh.Client, error) {
+	knownHostsFile := d.opts.knownHosts
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load known hosts from %s", knownHostsFile)
+	}
+
+	auth, agentConn, err := sshAuthMethods(d.opts.key)
+	if err != nil {
+		return nil, err
+	}
+	if agentConn != nil {
+		// the agent is only used for the authentication
+		defer agentConn.Close()
+	}
+
+	addr := net.JoinHostPort(d.spec.Host, d.spec.Port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	sshc, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User:            d.spec.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         20 * time.Second,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "failed to establish ssh connection to %s", addr)
+	}
+	return ssh.NewClient(sshc, chans, reqs), nil
+}
+
+// keepAlive periodically checks that the SSH connection is still alive and
+// drops it otherwise so the next dial reconnects.
+func (d *sshDialer) keepAlive(c *ssh.Client) {
+	ticker := time.NewTicker(sshKeepAliveInterval)
+	defer ticker.Stop()
+	done := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			d.reset(c)
+			return
+		case <-ticker.C:
+			if _, _, err := c.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				logrus.Debugf("ssh connection to %s lost: %v", d.spec.Host, err)
+				d.reset(c)
+				return
+			}
+		}
+	}
+}
+
+// reset drops the connection c so that the next dial reconnects.
+func (d *sshDialer) reset(c *ssh.Client) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client == c {
+		d.client = nil
+	}
+	c.Close()
+}
+
+// sshAuthMethods returns the authentication methods for the key, or the
+// default keys, and the keys of the SSH agent. The returned agent connection,
+// if any, must be closed once authenticated.
+func sshAuthMethods(key string) ([]ssh.AuthMethod, io.Closer, error) {
+	var signers []ssh.Signer
+	var agentConn net.Conn
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			if ss, err := agent.NewClient(conn).Signers(); err == nil && len(ss) > 0 {
+				signers = append(signers, ss...)
+				agentConn = conn
+			} else {
+				conn.Close()
+			}
+		}
+	}
+	closeAgent := func() {
+		if agentConn != nil {
+			agentConn.Close()
+		}
+	}
+
+	keys := []string{key}
+	if key == "" {
+		keys = nil
+		if home, err := os.UserHomeDir(); err == nil {
+			for _, f := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+				keys = append(keys, filepath.Join(home, ".ssh", f))
+			}
+		}
+	}
+	for _, k := range keys {
+		dt, err := os.ReadFile(k)
+		if err != nil {
+			if key == "" && os.IsNotExist(err) {
+				continue
+			}
+			closeAgent()
+			return nil, nil, errors.Wrap(err, "could not read ssh key")
+		}
+		signer, err := ssh.ParsePrivateKey(dt)
+		if err != nil {
+			var perr *ssh.PassphraseMissingError
+			if errors.As(err, &perr) {
+				if key == "" {
+					continue
+				}
+				closeAgent()
+				return nil, nil, errors.Errorf("ssh key %s is protected by a passphrase, add it to ssh-agent instead", k)
+			}
+			closeAgent()
+			return nil, nil, errors.Wrapf(err, "could not parse ssh key %s", k)
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) == 0 {
+		return nil, nil, errors.New("no ssh key available, set the ssh-key driver option or start ssh-agent")
+	}
+	var closer io.Closer
+	if agentConn != nil {
+		closer = agentConn
+	}
+	return []ssh.AuthMethod{ssh.PublicKeys(signers...)}, closer, nil
+}
+
+func shellQuote(s string) string {
+	out := "'"
+	for _, r := range s {
+		if r == '\'' {
+			out += `'\''`
+			continue
+		}
+		out += string(r)
+	}
+	return out + "'"
+}
+
+type sshConn struct {
+	sess       *ssh.Session
+	stdin      io.WriteCloser
+	stdout     io.Reader
+	localAddr  net.Addr
+	remoteAddr net.Addr
+	onClose    func()
+	closeOnce  sync.Once
+}
+
+func (c *sshConn) Read(p []byte) (int, error) {
+	return c.stdout.Read(p)
+}
+
+func (c *sshConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+func (c *sshConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.stdin.Close()
+		err = c.sess.Close()
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return err
+}
+
+func (c *sshConn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+func (c *sshConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+func (c *sshConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *sshConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *sshConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+type sshLogWriter struct{}
+
+func (*sshLogWriter) Write(dt []byte) (int, error) {
+	logrus.Debugf("buildctl dial-stdio: %s", dt)
+	return len(dt), nil
+}
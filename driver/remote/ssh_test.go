@@ -0,0 +1,159 @@
+package remote
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testSSHServer is an SSH server running the command of the sessions as an
+// echo of their stdin.
+type testSSHServer struct {
+	addr   string
+	conns  atomic.Int32
+	hostPK ssh.PublicKey
+}
+
+func newTestSSHServer(t *testing.T, clientPK ssh.PublicKey) *testSSHServer {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientPK.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	cfg.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	s := &testSSHServer{addr: l.Addr().String(), hostPK: hostSigner.PublicKey()}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, cfg)
+		}
+	}()
+	return s
+}
+
+func (s *testSSHServer) serve(conn net.Conn, cfg *ssh.ServerConfig) {
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	s.conns.Add(1)
+	defer s.conns.Add(-1)
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		for nc := range chans {
+			ch, reqs, err := nc.Accept()
+			if err != nil {
+				continue
+			}
+			go func() {
+				for req := range reqs {
+					req.Reply(req.Type == "exec", nil)
+					if req.Type == "exec" {
+						go func() {
+							io.Copy(ch, ch)
+							ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+							ch.Close()
+						}()
+					}
+				}
+			}()
+		}
+	}()
+	sconn.Wait()
+}
+
+func TestSSHDialer(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	dir := t.TempDir()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600))
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	srv := newTestSSHServer(t, signer.PublicKey())
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(knownhosts.Line([]string{knownhosts.Normalize(srv.addr)}, srv.hostPK)+"\n"), 0600))
+
+	d, err := newSSHDialer("ssh://user@"+srv.addr, sshOpts{key: keyFile, knownHosts: knownHostsFile})
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+	c1, err := d.Dial(ctx)
+	require.NoError(t, err)
+	c2, err := d.Dial(ctx)
+	require.NoError(t, err)
+
+	_, err = c1.Write([]byte("ping"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(c1, buf)
+	require.NoError(t, err)
+	require.Equal(t, "ping", string(buf))
+	require.Equal(t, int32(1), srv.conns.Load())
+
+	// the ssh connection is closed with the last dialed connection
+	require.NoError(t, c1.Close())
+	require.Equal(t, int32(1), srv.conns.Load())
+	require.NoError(t, c2.Close())
+	require.Eventually(t, func() bool { return srv.conns.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	// and established again by the next dial
+	c3, err := d.Dial(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), srv.conns.Load())
+	require.NoError(t, c3.Close())
+}
+
+func TestSSHDialerLoadsOnDial(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	// creating the dialer doesn't need the known hosts nor a key
+	d, err := newSSHDialer("ssh://user@127.0.0.1:1", sshOpts{})
+	require.NoError(t, err)
+
+	_, err = d.Dial(context.TODO())
+	require.ErrorContains(t, err, "failed to load known hosts")
+
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsFile, nil, 0600))
+	d, err = newSSHDialer("ssh://user@127.0.0.1:1", sshOpts{knownHosts: knownHostsFile})
+	require.NoError(t, err)
+	_, err = d.Dial(context.TODO())
+	require.ErrorContains(t, err, "no ssh key available")
+}
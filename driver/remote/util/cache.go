@@ -0,0 +1,100 @@
+package remoteutil
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// DiscoveryCacheTTL is how long endpoints discovered from DNS SRV records are
+// reused before looking up the records again.
+const DiscoveryCacheTTL = time.Minute
+
+type discoveryCacheEntry struct {
+	Endpoint  string               `json:"endpoint"`
+	Endpoints []DiscoveredEndpoint `json:"endpoints"`
+	// Time is when the endpoints have been discovered.
+	Time time.Time `json:"time"`
+	// ModTime and Size are the ones of the endpoints file when discovered
+	// through the file scheme.
+	ModTime time.Time `json:"modTime,omitempty"`
+	Size    int64     `json:"size,omitempty"`
+}
+
+// DiscoverCached is like Discover but reuses the endpoints discovered by a
+// previous call, stored in dir. Endpoints read from a file are reused until
+// the file changes, the ones looked up from DNS SRV records for
+// DiscoveryCacheTTL. If dir is empty, nothing is cached.
+func DiscoverCached(ctx context.Context, ep string, dir string) ([]DiscoveredEndpoint, error) {
+	if dir == "" {
+		return Discover(ctx, ep)
+	}
+	u, err := url.Parse(ep)
+	if err != nil {
+		return Discover(ctx, ep)
+	}
+	fn := filepath.Join(dir, digest.FromString(ep).Encoded()+".json")
+
+	var fi os.FileInfo
+	if u.Scheme == SchemeFile {
+		if fi, err = os.Stat(u.Path); err != nil {
+			return Discover(ctx, ep)
+		}
+	}
+	if e, err := readDiscoveryCache(fn); err == nil && e.Endpoint == ep {
+		switch {
+		case fi != nil && fi.ModTime().Equal(e.ModTime) && fi.Size() == e.Size:
+			return e.Endpoints, nil
+		case fi == nil && time.Since(e.Time) >= 0 && time.Since(e.Time) < DiscoveryCacheTTL:
+			return e.Endpoints, nil
+		}
+	}
+
+	eps, err := Discover(ctx, ep)
+	if err != nil {
+		return nil, err
+	}
+	e := discoveryCacheEntry{
+		Endpoint:  ep,
+		Endpoints: eps,
+		Time:      time.Now(),
+	}
+	if fi != nil {
+		e.ModTime, e.Size = fi.ModTime(), fi.Size()
+	}
+	// a failure to cache only means discovering the endpoints again next time
+	if err := writeDiscoveryCache(fn, e); err != nil {
+		logrus.Debugf("failed to cache endpoints discovered from %s: %v", ep, err)
+	}
+	return eps, nil
+}
+
+func readDiscoveryCache(fn string) (*discoveryCacheEntry, error) {
+	dt, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var e discoveryCacheEntry
+	if err := json.Unmarshal(dt, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func writeDiscoveryCache(fn string, e discoveryCacheEntry) error {
+	dt, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fn), 0700); err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(fn, dt, 0600)
+}
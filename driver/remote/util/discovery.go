@@ -0,0 +1,120 @@
+package remoteutil
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SchemeDNSSRV discovers endpoints by looking up DNS SRV records, e.g.
+	// dns+srv://_buildkit._tcp.example.com
+	SchemeDNSSRV = "dns+srv"
+	// SchemeFile discovers endpoints by reading a JSON or YAML file, e.g.
+	// file:///etc/buildx/endpoints.yaml
+	SchemeFile = "file"
+)
+
+// DiscoveredEndpoint is a BuildKit endpoint found through a discovery
+// endpoint.
+type DiscoveredEndpoint struct {
+	Name      string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Endpoint  string   `json:"endpoint" yaml:"endpoint"`
+	Platforms []string `json:"platforms,omitempty" yaml:"platforms,omitempty"`
+}
+
+type endpointsFile struct {
+	Endpoints []DiscoveredEndpoint `yaml:"endpoints"`
+}
+
+// IsDiscoveryEndpoint returns true if the endpoint does not address a
+// BuildKit instance but a list of them that needs to be discovered.
+func IsDiscoveryEndpoint(ep string) bool {
+	scheme, _, ok := strings.Cut(ep, "://")
+	return ok && (scheme == SchemeDNSSRV || scheme == SchemeFile)
+}
+
+// Discover returns the BuildKit endpoints listed by a discovery endpoint.
+// The list is looked up again on every call so changes to the DNS records
+// or the file are picked up without updating the builder. See DiscoverCached
+// to avoid the lookup on every load of the builder.
+func Discover(ctx context.Context, ep string) ([]DiscoveredEndpoint, error) {
+	u, err := url.Parse(ep)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse endpoint %s", ep)
+	}
+	var res []DiscoveredEndpoint
+	switch u.Scheme {
+	case SchemeDNSSRV:
+		res, err = discoverSRV(ctx, u)
+	case SchemeFile:
+		res, err = discoverFile(u)
+	default:
+		return nil, errors.Errorf("unsupported discovery scheme %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range res {
+		if IsDiscoveryEndpoint(r.Endpoint) {
+			return nil, errors.Errorf("discovered endpoint %s cannot be a discovery endpoint", r.Endpoint)
+		}
+		if err := IsValidEndpoint(r.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	if len(res) == 0 {
+		return nil, errors.Errorf("no endpoint discovered from %s", ep)
+	}
+	return res, nil
+}
+
+func discoverSRV(ctx context.Context, u *url.URL) ([]DiscoveredEndpoint, error) {
+	scheme := "tcp"
+	if v := u.Query().Get("scheme"); v != "" {
+		scheme = v
+	}
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", u.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to lookup SRV records for %s", u.Host)
+	}
+	res := make([]DiscoveredEndpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		host := strings.TrimSuffix(addr.Target, ".")
+		res = append(res, DiscoveredEndpoint{
+			Name:     host,
+			Endpoint: scheme + "://" + net.JoinHostPort(host, strconv.Itoa(int(addr.Port))),
+		})
+	}
+	return res, nil
+}
+
+func discoverFile(u *url.URL) ([]DiscoveredEndpoint, error) {
+	dt, err := os.ReadFile(u.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read endpoints file")
+	}
+	var f endpointsFile
+	if err := yaml.Unmarshal(dt, &f); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse endpoints file %s", u.Path)
+	}
+	for i, e := range f.Endpoints {
+		if e.Endpoint == "" {
+			return nil, errors.Errorf("missing endpoint for entry %d in %s", i, u.Path)
+		}
+		if e.Name == "" {
+			if eu, err := url.Parse(e.Endpoint); err == nil && eu.Hostname() != "" {
+				f.Endpoints[i].Name = eu.Hostname()
+			} else {
+				f.Endpoints[i].Name = strconv.Itoa(i)
+			}
+		}
+	}
+	return f.Endpoints, nil
+}
@@ -0,0 +1,102 @@
+package remoteutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDiscoveryEndpoint(t *testing.T) {
+	require.True(t, IsDiscoveryEndpoint("dns+srv://_buildkit._tcp.example.com"))
+	require.True(t, IsDiscoveryEndpoint("file:///etc/buildx/endpoints.yaml"))
+	require.False(t, IsDiscoveryEndpoint("tcp://localhost:1234"))
+	require.False(t, IsDiscoveryEndpoint("file"))
+}
+
+func TestDiscoverFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlFile := filepath.Join(dir, "endpoints.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(`
+endpoints:
+  - name: amd64
+    endpoint: tcp://10.0.0.1:1234
+    platforms: [linux/amd64]
+  - endpoint: tcp://arm64.example.com:1234
+    platforms:
+      - linux/arm64
+      - linux/arm/v7
+`), 0600))
+	eps, err := Discover(context.TODO(), "file://"+yamlFile)
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredEndpoint{
+		{Name: "amd64", Endpoint: "tcp://10.0.0.1:1234", Platforms: []string{"linux/amd64"}},
+		{Name: "arm64.example.com", Endpoint: "tcp://arm64.example.com:1234", Platforms: []string{"linux/arm64", "linux/arm/v7"}},
+	}, eps)
+
+	jsonFile := filepath.Join(dir, "endpoints.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"endpoints": [{"endpoint": "unix:///run/buildkit/buildkitd.sock"}]}`), 0600))
+	eps, err = Discover(context.TODO(), "file://"+jsonFile)
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredEndpoint{{Name: "0", Endpoint: "unix:///run/buildkit/buildkitd.sock"}}, eps)
+
+	for name, dt := range map[string]string{
+		"empty":     `endpoints: []`,
+		"missing":   `endpoints: [{name: foo}]`,
+		"invalid":   `endpoints: [{endpoint: "http://foo"}]`,
+		"recursive": `endpoints: [{endpoint: "dns+srv://_buildkit._tcp.example.com"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := filepath.Join(dir, name+".yaml")
+			require.NoError(t, os.WriteFile(f, []byte(dt), 0600))
+			_, err := Discover(context.TODO(), "file://"+f)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDiscoverCached(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+
+	f := filepath.Join(dir, "endpoints.yaml")
+	require.NoError(t, os.WriteFile(f, []byte(`endpoints: [{name: foo, endpoint: "tcp://foo:1234"}]`), 0600))
+	ep := "file://" + f
+
+	eps, err := DiscoverCached(context.TODO(), ep, cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredEndpoint{{Name: "foo", Endpoint: "tcp://foo:1234"}}, eps)
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// the cached endpoints are returned while the file doesn't change
+	cached := filepath.Join(cacheDir, entries[0].Name())
+	dt, err := os.ReadFile(cached)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cached, []byte(strings.ReplaceAll(string(dt), "tcp://foo:1234", "tcp://cached:1234")), 0600))
+	eps, err = DiscoverCached(context.TODO(), ep, cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredEndpoint{{Name: "foo", Endpoint: "tcp://cached:1234"}}, eps)
+
+	// and discovered again once it does
+	require.NoError(t, os.WriteFile(f, []byte(`endpoints: [{name: bar, endpoint: "tcp://bar:1234"}]`), 0600))
+	require.NoError(t, os.Chtimes(f, time.Now(), time.Now().Add(time.Minute)))
+	eps, err = DiscoverCached(context.TODO(), ep, cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredEndpoint{{Name: "bar", Endpoint: "tcp://bar:1234"}}, eps)
+
+	// errors are not cached
+	require.NoError(t, os.WriteFile(f, []byte(`endpoints: []`), 0600))
+	require.NoError(t, os.Chtimes(f, time.Now(), time.Now().Add(2*time.Minute)))
+	_, err = DiscoverCached(context.TODO(), ep, cacheDir)
+	require.Error(t, err)
+	require.NoError(t, os.Remove(f))
+	_, err = DiscoverCached(context.TODO(), ep, cacheDir)
+	require.Error(t, err)
+}
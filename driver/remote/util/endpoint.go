@@ -8,7 +8,9 @@ import (
 )
 
 var schemes = []string{
+	"dns+srv",
 	"docker-container",
+	"file",
 	"kube-pod",
 	"npipe",
 	"ssh",
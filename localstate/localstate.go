@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/docker/buildx/util/confutil"
 	"github.com/pkg/errors"
@@ -16,6 +17,7 @@ import (
 const (
 	refsDir  = "refs"
 	groupDir = "__group__"
+	nodesDir = "nodes"
 )
 
 type State struct {
@@ -41,6 +43,12 @@ type StateGroup struct {
 	Refs []string
 }
 
+// NodeState is the state of a builder node, recorded when it is loaded.
+type NodeState struct {
+	// LastSeen is the last time the node answered
+	LastSeen time.Time
+}
+
 type LocalState struct {
 	cfg *confutil.Config
 }
@@ -111,11 +119,45 @@ func (ls *LocalState) SaveGroup(id string, stg StateGroup) error {
 	return ls.cfg.AtomicWriteFile(filepath.Join(refDir, id), dt, 0600)
 }
 
+func (ls *LocalState) ReadNode(builderName, nodeName string) (*NodeState, error) {
+	if err := ls.validateNode(builderName, nodeName); err != nil {
+		return nil, err
+	}
+	dt, err := os.ReadFile(filepath.Join(ls.cfg.Dir(), nodesDir, builderName, nodeName))
+	if err != nil {
+		return nil, err
+	}
+	var st NodeState
+	if err := json.Unmarshal(dt, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func (ls *LocalState) SaveNode(builderName, nodeName string, st NodeState) error {
+	if err := ls.validateNode(builderName, nodeName); err != nil {
+		return err
+	}
+	nodeDir := filepath.Join(nodesDir, builderName)
+	if err := ls.cfg.MkdirAll(nodeDir, 0700); err != nil {
+		return err
+	}
+	dt, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return ls.cfg.AtomicWriteFile(filepath.Join(nodeDir, nodeName), dt, 0644)
+}
+
 func (ls *LocalState) RemoveBuilder(builderName string) error {
 	if builderName == "" {
 		return errors.Errorf("builder name empty")
 	}
 
+	if err := os.RemoveAll(filepath.Join(ls.cfg.Dir(), nodesDir, builderName)); err != nil {
+		return err
+	}
+
 	dir := filepath.Join(ls.cfg.Dir(), refsDir, builderName)
 	if _, err := os.Lstat(dir); err != nil {
 		if !os.IsNotExist(err) {
@@ -147,6 +189,10 @@ func (ls *LocalState) RemoveBuilderNode(builderName string, nodeName string) err
 		return errors.Errorf("node name empty")
 	}
 
+	if err := os.RemoveAll(filepath.Join(ls.cfg.Dir(), nodesDir, builderName, nodeName)); err != nil {
+		return err
+	}
+
 	dir := filepath.Join(ls.cfg.Dir(), refsDir, builderName, nodeName)
 	if _, err := os.Lstat(dir); err != nil {
 		if !os.IsNotExist(err) {
@@ -219,14 +265,21 @@ func (ls *LocalState) removeGroup(id string) error {
 }
 
 func (ls *LocalState) validate(builderName, nodeName, id string) error {
+	if err := ls.validateNode(builderName, nodeName); err != nil {
+		return err
+	}
+	if id == "" {
+		return errors.Errorf("ref ID empty")
+	}
+	return nil
+}
+
+func (ls *LocalState) validateNode(builderName, nodeName string) error {
 	if builderName == "" {
 		return errors.Errorf("builder name empty")
 	}
 	if nodeName == "" {
 		return errors.Errorf("node name empty")
 	}
-	if id == "" {
-		return errors.Errorf("ref ID empty")
-	}
 	return nil
 }
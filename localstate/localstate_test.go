@@ -1,8 +1,10 @@
 package localstate
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/docker/buildx/util/confutil"
 	"github.com/stretchr/testify/require"
@@ -36,6 +38,27 @@ func TestRemoveBuilderNode(t *testing.T) {
 	require.NoError(t, l.RemoveBuilderNode(testBuilderName, testNodeName))
 }
 
+func TestNode(t *testing.T) {
+	l := newls(t)
+	_, err := l.ReadNode(testBuilderName, testNodeName)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	st := NodeState{LastSeen: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+	require.NoError(t, l.SaveNode(testBuilderName, testNodeName, st))
+	n, err := l.ReadNode(testBuilderName, testNodeName)
+	require.NoError(t, err)
+	require.Equal(t, st, *n)
+
+	require.NoError(t, l.RemoveBuilderNode(testBuilderName, testNodeName))
+	_, err = l.ReadNode(testBuilderName, testNodeName)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, l.SaveNode(testBuilderName, testNodeName, st))
+	require.NoError(t, l.RemoveBuilder(testBuilderName))
+	_, err = l.ReadNode(testBuilderName, testNodeName)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func newls(t *testing.T) *LocalState {
 	t.Helper()
 	tmpdir := t.TempDir()
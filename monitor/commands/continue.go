@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"io"
+
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/monitor/types"
+	"github.com/docker/buildx/util/progress"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+type ContinueCmd struct {
+	m types.Monitor
+
+	stdout   io.WriteCloser
+	progress *progress.Printer
+
+	invokeConfig *controllerapi.InvokeConfig
+}
+
+func NewContinueCmd(m types.Monitor, stdout io.WriteCloser, progress *progress.Printer, invokeConfig *controllerapi.InvokeConfig) types.Command {
+	return &ContinueCmd{m, stdout, progress, invokeConfig}
+}
+
+func (cm *ContinueCmd) Info() types.CommandInfo {
+	return types.CommandInfo{
+		Name:        "continue",
+		HelpMessage: "resumes the build paused at a breakpoint",
+		HelpMessageLong: `
+Usage:
+  continue
+
+The build runs until the next breakpoint or its end. The interactive container
+is restarted on the new result.
+`,
+	}
+}
+
+func (cm *ContinueCmd) Exec(ctx context.Context, args []string) error {
+	ref := cm.m.AttachedSessionID()
+	if ref == "" {
+		return errors.Errorf("no attaching session")
+	}
+	res, err := cm.m.Inspect(ctx, ref)
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect the current build session")
+	}
+	if res.Breakpoint == "" {
+		return errors.Errorf("build of session %q is not paused at a breakpoint", ref)
+	}
+	bo := proto.Clone(res.Options).(*controllerapi.BuildOptions)
+	bo.ResumeFrom = res.Breakpoint
+	rebuild(ctx, cm.m, cm.stdout, cm.progress, bo, cm.invokeConfig)
+	return nil
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/buildx/monitor/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+)
+
+// containerPathPrefix marks a path of the interactive container.
+const containerPathPrefix = "container:"
+
+type CpCmd struct {
+	m types.Monitor
+
+	stdout io.WriteCloser
+}
+
+func NewCpCmd(m types.Monitor, stdout io.WriteCloser) types.Command {
+	return &CpCmd{m, stdout}
+}
+
+func (cm *CpCmd) Info() types.CommandInfo {
+	return types.CommandInfo{
+		Name:        "cp",
+		HelpMessage: "copy files between the interactive container and the local filesystem",
+		HelpMessageLong: `
+Usage:
+  cp container:SRC_PATH DEST_DIR
+  cp SRC_PATH container:DEST_DIR
+
+SRC_PATH is copied into the directory DEST_DIR. Paths of the interactive
+container are prefixed with "container:". Files are copied by running tar
+in the container, as root. If the image has no tar, files are copied from the
+result of the build or of the step instead, without the changes made by the
+processes of the container. Copying to the container requires tar in it.
+
+Example:
+  cp container:/src/test-logs ./out
+`,
+	}
+}
+
+func (cm *CpCmd) Exec(ctx context.Context, args []string) error {
+	ref := cm.m.AttachedSessionID()
+	if ref == "" {
+		return errors.Errorf("no attaching session")
+	}
+	if len(args) != 3 {
+		return errors.Errorf("source and destination must be passed")
+	}
+	src, dest := args[1], args[2]
+	switch srcCtr, destCtr := strings.HasPrefix(src, containerPathPrefix), strings.HasPrefix(dest, containerPathPrefix); {
+	case srcCtr && !destCtr:
+		if err := cm.copyFromContainer(ctx, ref, strings.TrimPrefix(src, containerPathPrefix), dest); err != nil {
+			return err
+		}
+	case !srcCtr && destCtr:
+		if err := cm.copyToContainer(ctx, ref, src, strings.TrimPrefix(dest, containerPathPrefix)); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("one of source and destination must be a path of the container (%q)", containerPathPrefix+"PATH")
+	}
+	fmt.Fprintf(cm.stdout, "Copied %s to %s\n", src, dest)
+	return nil
+}
+
+func (cm *CpCmd) copyFromContainer(ctx context.Context, ref, src, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(cm.m.CopyFromContainer(ctx, ref, src, pw))
+	}()
+	err := archive.Untar(pr, dest, &archive.TarOptions{NoLchown: true})
+	pr.CloseWithError(err)
+	return errors.Wrapf(err, "failed to copy %s", src)
+}
+
+func (cm *CpCmd) copyToContainer(ctx context.Context, ref, src, dest string) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(src); err != nil {
+		return err
+	}
+	rc, err := archive.TarWithOptions(filepath.Dir(src), &archive.TarOptions{
+		IncludeFiles: []string{filepath.Base(src)},
+	})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return errors.Wrapf(cm.m.CopyToContainer(ctx, ref, dest, rc), "failed to copy %s", src)
+}
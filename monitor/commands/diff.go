@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/docker/buildx/build"
+	"github.com/docker/buildx/monitor/types"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+type DiffCmd struct {
+	m types.Monitor
+
+	stdout io.WriteCloser
+}
+
+func NewDiffCmd(m types.Monitor, stdout io.WriteCloser) types.Command {
+	return &DiffCmd{m, stdout}
+}
+
+func (cm *DiffCmd) Info() types.CommandInfo {
+	return types.CommandInfo{
+		Name:        "diff",
+		HelpMessage: "list the files changed in the interactive container",
+		HelpMessageLong: `
+Usage:
+  diff [FLAGS]
+
+Flags:
+  --step List the files changed by the failed step instead.
+
+Lists the files added (A), modified (M) and deleted (D) in the interactive
+container since its initial rootfs, that is restored by "rollback". With
+--step, lists the files changed by the failed step, from its initial rootfs
+to its rootfs when it failed. The running rootfs and the rootfs of a failed
+step are listed by running find and stat as root, the container must provide
+them. /etc/hosts, /etc/resolv.conf, /etc/hostname, /proc, /sys and /dev are
+set up by the runtime and ignored.
+`,
+	}
+}
+
+func (cm *DiffCmd) Exec(ctx context.Context, args []string) error {
+	ref := cm.m.AttachedSessionID()
+	if ref == "" {
+		return errors.Errorf("no attaching session")
+	}
+	var step bool
+	if len(args) >= 2 {
+		if args[1] != "--step" || len(args) > 2 {
+			return errors.Errorf("unknown arguments %v", args[1:])
+		}
+		step = true
+	}
+	changes, err := cm.m.Diff(ctx, ref, step)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(cm.stdout, 1, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "KIND\tSIZE\tPATH")
+	for _, c := range changes {
+		size := units.HumanSize(float64(c.Size))
+		if c.Kind == build.FileModified && c.OldSize != c.Size {
+			size = units.HumanSize(float64(c.OldSize)) + " -> " + size
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Kind, size, c.Path)
+	}
+	tw.Flush()
+	return nil
+}
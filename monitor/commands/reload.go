@@ -11,6 +11,7 @@ import (
 	"github.com/docker/buildx/util/progress"
 	"github.com/moby/buildkit/solver/errdefs"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 )
 
 type ReloadCmd struct {
@@ -54,36 +55,51 @@ func (cm *ReloadCmd) Exec(ctx context.Context, args []string) error {
 	if bo == nil {
 		return errors.Errorf("no build option is provided")
 	}
-	if ref := cm.m.AttachedSessionID(); ref != "" {
-		if err := cm.m.Disconnect(ctx, ref); err != nil {
+	if bo.ResumeFrom != "" {
+		// Reloading restarts the build from the first breakpoint.
+		bo = proto.Clone(bo).(*controllerapi.BuildOptions)
+		bo.ResumeFrom = ""
+	}
+	rebuild(ctx, cm.m, cm.stdout, cm.progress, bo, cm.invokeConfig)
+	return nil
+}
+
+// rebuild builds the session again with the options and restarts the
+// interactive container on the new result.
+func rebuild(ctx context.Context, m types.Monitor, stdout io.Writer, progress *progress.Printer, bo *controllerapi.BuildOptions, invokeConfig *controllerapi.InvokeConfig) {
+	if ref := m.AttachedSessionID(); ref != "" {
+		if err := m.Disconnect(ctx, ref); err != nil {
 			fmt.Println("disconnect error", err)
 		}
 	}
 	var resultUpdated bool
-	cm.progress.Unpause()
-	ref, _, _, err := cm.m.Build(ctx, bo, nil, cm.progress) // TODO: support stdin, hold build ref
-	cm.progress.Pause()
+	progress.Unpause()
+	ref, _, _, err := m.Build(ctx, bo, nil, progress) // TODO: support stdin, hold build ref
+	progress.Pause()
 	if err != nil {
 		var be *controllererrors.BuildError
 		if errors.As(err, &be) {
-			ref = be.Ref
+			ref = be.SessionID
 			resultUpdated = true
 		} else {
 			fmt.Printf("failed to reload: %v\n", err)
 		}
-		// report error
-		for _, s := range errdefs.Sources(err) {
-			s.Print(cm.stdout)
+		if be != nil && be.Breakpoint != "" {
+			fmt.Fprintf(stdout, "Build %v\n", err)
+		} else {
+			// report error
+			for _, s := range errdefs.Sources(err) {
+				s.Print(stdout)
+			}
+			fmt.Fprintf(stdout, "ERROR: %v\n", err)
 		}
-		fmt.Fprintf(cm.stdout, "ERROR: %v\n", err)
 	} else {
 		resultUpdated = true
 	}
-	cm.m.AttachSession(ref)
+	m.AttachSession(ref)
 	if resultUpdated {
 		// rollback the running container with the new result
-		id := cm.m.Rollback(ctx, cm.invokeConfig)
-		fmt.Fprintf(cm.stdout, "Interactive container was restarted with process %q. Press Ctrl-a-c to switch to the new container\n", id)
+		id := m.Rollback(ctx, invokeConfig)
+		fmt.Fprintf(stdout, "Interactive container was restarted with process %q. Press Ctrl-a-c to switch to the new container\n", id)
 	}
-	return nil
 }
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/docker/buildx/build"
+	controllererrors "github.com/docker/buildx/controller/errdefs"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/monitor/types"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildAttachesFailedSession(t *testing.T) {
+	ctx := context.TODO()
+	printer, err := progress.NewPrinter(ctx, os.Stderr, progressui.QuietMode)
+	require.NoError(t, err)
+	defer printer.Wait()
+	// the monitor pauses the progress while it is attached
+	require.NoError(t, printer.Pause())
+
+	// the session of a failed build is kept for debugging, under an ID
+	// different from the build ref of the history
+	m := &fakeMonitor{
+		buildErr: controllererrors.WrapBuild(errors.New("process did not complete successfully"), "session-2", "buildref-2", ""),
+		attached: "session-1",
+	}
+	var stdout bytes.Buffer
+	rebuild(ctx, m, &stdout, printer, &controllerapi.BuildOptions{}, &controllerapi.InvokeConfig{})
+
+	require.Equal(t, []string{"session-1"}, m.disconnected)
+	require.Equal(t, "session-2", m.attached)
+	require.True(t, m.rolledBack)
+	require.Contains(t, stdout.String(), "process did not complete successfully")
+}
+
+type fakeMonitor struct {
+	types.Monitor
+
+	buildErr     error
+	attached     string
+	disconnected []string
+	rolledBack   bool
+}
+
+func (m *fakeMonitor) Build(ctx context.Context, options *controllerapi.BuildOptions, in io.ReadCloser, progress progress.Writer) (string, *client.SolveResponse, *build.Inputs, error) {
+	return "", nil, nil, m.buildErr
+}
+
+func (m *fakeMonitor) Disconnect(ctx context.Context, sessionID string) error {
+	m.disconnected = append(m.disconnected, sessionID)
+	return nil
+}
+
+func (m *fakeMonitor) AttachedSessionID() string {
+	return m.attached
+}
+
+func (m *fakeMonitor) AttachSession(ref string) {
+	m.attached = ref
+}
+
+func (m *fakeMonitor) Rollback(ctx context.Context, cfg *controllerapi.InvokeConfig) string {
+	m.rolledBack = true
+	return "pid"
+}
@@ -30,19 +30,90 @@ type MonitorBuildResult struct {
 	Err  error
 }
 
+// MonitorOpt configures the monitor.
+type MonitorOpt func(*monitorOpts)
+
+type monitorOpts struct {
+	recorder *Recorder
+}
+
+// WithRecorder records the IO of the monitor and of the interactive
+// containers, and the progress of the builds started from the monitor, with r.
+func WithRecorder(r *Recorder) MonitorOpt {
+	return func(opt *monitorOpts) {
+		opt.recorder = r
+	}
+}
+
 // RunMonitor provides an interactive session for running and managing containers via specified IO.
-func RunMonitor(ctx context.Context, curRef string, options *controllerapi.BuildOptions, invokeConfig *controllerapi.InvokeConfig, c control.BuildxController, stdin io.ReadCloser, stdout io.WriteCloser, stderr console.File, progress *progress.Printer) (*MonitorBuildResult, error) {
+func RunMonitor(ctx context.Context, curRef string, options *controllerapi.BuildOptions, invokeConfig *controllerapi.InvokeConfig, c control.BuildxController, stdin io.ReadCloser, stdout io.WriteCloser, stderr console.File, progress *progress.Printer, opts ...MonitorOpt) (*MonitorBuildResult, error) {
 	defer func() {
 		if err := c.Disconnect(ctx, curRef); err != nil {
 			logrus.Warnf("disconnect error: %v", err)
 		}
 	}()
 
+	return runMonitor(ctx, curRef, options, invokeConfig, c, stdin, stdout, stderr, progress, opts, func(m *monitor) {
+		// Start container automatically
+		fmt.Fprintf(stdout, "Launching interactive container. Press Ctrl-a-c to switch to monitor console\n")
+		invokeConfig.Rollback = false
+		invokeConfig.Initial = false
+		id := m.Rollback(ctx, invokeConfig)
+		fmt.Fprintf(stdout, "Interactive container was restarted with process %q. Press Ctrl-a-c to switch to the new container\n", id)
+	})
+}
+
+// AttachMonitor provides an interactive session for a session started by
+// another client, attached to one of its running processes. Unlike RunMonitor,
+// the session isn't disconnected when the monitor exits.
+func AttachMonitor(ctx context.Context, ref string, c control.BuildxController, stdin io.ReadCloser, stdout io.WriteCloser, stderr console.File, progress *progress.Printer, opts ...MonitorOpt) error {
+	invokeConfig := &controllerapi.InvokeConfig{Tty: true}
+	_, err := runMonitor(ctx, ref, nil, invokeConfig, c, stdin, stdout, stderr, progress, opts, func(m *monitor) {
+		infos, err := m.ListProcesses(ctx, ref)
+		if err != nil {
+			fmt.Fprintf(stdout, "failed to list processes: %v\n", err)
+			return
+		}
+		if len(infos) == 0 {
+			// The session may have been restored by a new server: start the
+			// container it was running.
+			if res, err := m.Inspect(ctx, ref); err == nil && res.InvokeConfig != nil {
+				cfg := res.InvokeConfig
+				cfg.Tty = true
+				id := m.Rollback(ctx, cfg)
+				fmt.Fprintf(stdout, "Interactive container was restarted with process %q. Press Ctrl-a-c to switch to monitor console\n", id)
+				return
+			}
+			fmt.Fprintf(stdout, "No process is running in session %q. Press Ctrl-a-c to switch to monitor console\n", ref)
+			return
+		}
+		pid := infos[0].ProcessID
+		m.Attach(ctx, pid)
+		fmt.Fprintf(stdout, "Attached to process %q. Press Ctrl-a-c to switch to monitor console\n", pid)
+	})
+	return err
+}
+
+func runMonitor(ctx context.Context, curRef string, options *controllerapi.BuildOptions, invokeConfig *controllerapi.InvokeConfig, c control.BuildxController, stdin io.ReadCloser, stdout io.WriteCloser, stderr console.File, progress *progress.Printer, opts []MonitorOpt, start func(m *monitor)) (*MonitorBuildResult, error) {
 	if err := progress.Pause(); err != nil {
 		return nil, err
 	}
 	defer progress.Unpause()
 
+	opt := &monitorOpts{}
+	for _, o := range opts {
+		o(opt)
+	}
+	var stderrW io.WriteCloser = nopCloser{stderr}
+	if r := opt.recorder; r != nil {
+		r.stopProgress()
+		stopResize := r.watchResize()
+		defer stopResize()
+		stdin = r.recordIn(stdin)
+		stdout = r.recordOut(stdout)
+		stderrW = r.recordOut(stderrW)
+	}
+
 	monitorIn, monitorOut := ioset.Pipe()
 	defer func() {
 		monitorIn.Close()
@@ -74,7 +145,7 @@ func RunMonitor(ctx context.Context, curRef string, options *controllerapi.Build
 		muxIO: ioset.NewMuxIO(ioset.In{
 			Stdin:  io.NopCloser(stdin),
 			Stdout: nopCloser{stdout},
-			Stderr: nopCloser{stderr},
+			Stderr: stderrW,
 		}, []ioset.MuxOut{monitorOutCtx, containerOutCtx}, 1, func(prev int, res int) string {
 			if prev == 0 && res == 0 {
 				// No toggle happened because container I/O isn't enabled.
@@ -82,24 +153,23 @@ func RunMonitor(ctx context.Context, curRef string, options *controllerapi.Build
 			}
 			return "Switched IO\n"
 		}),
+		recorder: opt.recorder,
 	}
 	m.ref.Store(curRef)
-
-	// Start container automatically
-	fmt.Fprintf(stdout, "Launching interactive container. Press Ctrl-a-c to switch to monitor console\n")
-	invokeConfig.Rollback = false
-	invokeConfig.Initial = false
-	id := m.Rollback(ctx, invokeConfig)
-	fmt.Fprintf(stdout, "Interactive container was restarted with process %q. Press Ctrl-a-c to switch to the new container\n", id)
+	m.attachedPid.Store("")
+	start(m)
 
 	availableCommands := []types.Command{
 		commands.NewReloadCmd(m, stdout, progress, options, invokeConfig),
+		commands.NewContinueCmd(m, stdout, progress, invokeConfig),
 		commands.NewRollbackCmd(m, invokeConfig, stdout),
 		commands.NewListCmd(m, stdout),
 		commands.NewDisconnectCmd(m),
 		commands.NewKillCmd(m),
 		commands.NewAttachCmd(m, stdout),
 		commands.NewExecCmd(m, invokeConfig, stdout),
+		commands.NewCpCmd(m, stdout),
+		commands.NewDiffCmd(m, stdout),
 		commands.NewPsCmd(m, stdout),
 	}
 	registeredCommands := make(map[string]types.Command)
@@ -242,9 +312,20 @@ type monitor struct {
 	attachedPid  atomic.Value
 
 	lastBuildResult *MonitorBuildResult
+
+	recorder *Recorder
 }
 
 func (m *monitor) Build(ctx context.Context, options *controllerapi.BuildOptions, in io.ReadCloser, progress progress.Writer) (ref string, resp *client.SolveResponse, input *build.Inputs, err error) {
+	if r := m.recorder; r != nil {
+		// record the progress of the builds started from the monitor, like
+		// the one of the first build
+		if err := r.startProgress(); err != nil {
+			return "", nil, nil, err
+		}
+		defer r.stopProgress()
+		progress = r.Progress(progress)
+	}
 	ref, resp, _, err = m.BuildxController.Build(ctx, options, in, progress)
 	m.lastBuildResult = &MonitorBuildResult{Resp: resp, Err: err} // Record build result
 	return
@@ -0,0 +1,195 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/containerd/console"
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/asciicast"
+	"github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/sirupsen/logrus"
+)
+
+// Recorder records a debugging session as an asciicast file: the progress of
+// the builds, and the IO of the monitor and of the interactive containers.
+type Recorder struct {
+	f     *os.File
+	w     *asciicast.Writer
+	con   console.Console
+	input bool
+
+	mu       sync.Mutex
+	statusCh chan *client.SolveStatus
+	done     chan struct{}
+}
+
+// NewRecorder creates the file at path, readable only by the current user,
+// and records the session in it. The size of the recorded terminal is the one
+// of con, if it's a terminal. The input of the session is recorded only if
+// input is true, as it may contain passwords typed in the containers.
+func NewRecorder(path string, con console.File, input bool) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	header := asciicast.Header{
+		Width:  80,
+		Height: 24,
+		Title:  "buildx debug",
+		Env: map[string]string{
+			"TERM":  os.Getenv("TERM"),
+			"SHELL": os.Getenv("SHELL"),
+		},
+	}
+	r := &Recorder{f: f, input: input}
+	if c, err := console.ConsoleFromFile(con); err == nil {
+		if size, err := c.Size(); err == nil && size.Width > 0 && size.Height > 0 {
+			header.Width, header.Height = int(size.Width), int(size.Height)
+		}
+		r.con = c
+	}
+	if r.w, err = asciicast.NewWriter(f, header); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := r.startProgress(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Progress returns a progress writer writing to w and recording the progress.
+func (r *Recorder) Progress(w progress.Writer) progress.Writer {
+	return &recordedProgress{Writer: w, r: r}
+}
+
+type recordedProgress struct {
+	progress.Writer
+	r *Recorder
+}
+
+func (p *recordedProgress) Write(s *client.SolveStatus) {
+	p.r.mu.Lock()
+	if p.r.statusCh != nil {
+		s2 := *s
+		p.r.statusCh <- &s2
+	}
+	p.r.mu.Unlock()
+	p.Writer.Write(s)
+}
+
+// startProgress starts recording the progress of a build until stopProgress
+// is called. The progress is recorded in plain mode as it doesn't depend on
+// the size of the terminal.
+func (r *Recorder) startProgress() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.statusCh != nil {
+		return nil
+	}
+	d, err := progressui.NewDisplay(&crlfWriter{r.w.Writer(asciicast.Output)}, progressui.PlainMode)
+	if err != nil {
+		return err
+	}
+	statusCh := make(chan *client.SolveStatus)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.UpdateFrom(context.TODO(), statusCh)
+	}()
+	r.statusCh, r.done = statusCh, done
+	return nil
+}
+
+// stopProgress stops recording the progress, once the build finished, so
+// that it doesn't mix with the IO of the monitor.
+func (r *Recorder) stopProgress() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.statusCh == nil {
+		return
+	}
+	close(r.statusCh)
+	r.statusCh = nil
+	<-r.done
+}
+
+// recordResize records a resize of the terminal.
+func (r *Recorder) recordResize(msg *controllerapi.ResizeMessage) {
+	if err := r.w.Resize(msg.Cols, msg.Rows); err != nil {
+		logrus.Debugf("failed to record resize: %v", err)
+	}
+}
+
+// watchResize records the resizes of the terminal until stop is called.
+func (r *Recorder) watchResize() (stop func()) {
+	if r.con == nil {
+		return func() {}
+	}
+	resizeCh, stopNotify := notifyResize(r.con)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case msg := <-resizeCh:
+				r.recordResize(msg)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		stopNotify()
+		close(done)
+	}
+}
+
+// Close stops recording and closes the file.
+func (r *Recorder) Close() error {
+	r.stopProgress()
+	return r.f.Close()
+}
+
+// recordIn returns in, recording what is read from it if the input is
+// recorded.
+func (r *Recorder) recordIn(in io.ReadCloser) io.ReadCloser {
+	if !r.input {
+		return in
+	}
+	return &readCloser{io.TeeReader(in, r.w.Writer(asciicast.Input)), in}
+}
+
+// recordOut returns out, recording what is written to it.
+func (r *Recorder) recordOut(out io.WriteCloser) io.WriteCloser {
+	return &writeCloser{io.MultiWriter(out, r.w.Writer(asciicast.Output)), out}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type writeCloser struct {
+	io.Writer
+	io.Closer
+}
+
+// crlfWriter translates the line feeds written to it for a terminal in raw
+// mode.
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (w *crlfWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	controllerapi "github.com/docker/buildx/controller/pb"
+	"github.com/docker/buildx/util/asciicast"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+type nopProgress struct{}
+
+func (nopProgress) Write(*client.SolveStatus) {}
+
+func (nopProgress) WriteBuildRef(string, string) {}
+
+func (nopProgress) ValidateLogSource(digest.Digest, interface{}) bool { return true }
+
+func (nopProgress) ClearLogSource(interface{}) {}
+
+// readEvents returns the events of the recording at p as "TYPE DATA" strings.
+func readEvents(t *testing.T, p string) []string {
+	f, err := os.Open(p)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := asciicast.NewReader(f)
+	require.NoError(t, err)
+	var events []string
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			return events
+		}
+		require.NoError(t, err)
+		events = append(events, string(e.Type)+" "+e.Data)
+	}
+}
+
+func writeVertex(w interface{ Write(*client.SolveStatus) }, name string) {
+	now := time.Now()
+	w.Write(&client.SolveStatus{Vertexes: []*client.Vertex{{
+		Digest:    digest.FromString(name),
+		Name:      name,
+		Started:   &now,
+		Completed: &now,
+	}}})
+}
+
+func TestRecorder(t *testing.T) {
+	for _, input := range []bool{false, true} {
+		p := filepath.Join(t.TempDir(), "session.cast")
+		con, err := os.Create(filepath.Join(t.TempDir(), "console"))
+		require.NoError(t, err)
+		defer con.Close()
+
+		r, err := NewRecorder(p, con, input)
+		require.NoError(t, err)
+		writeVertex(r.Progress(nopProgress{}), "initial")
+		r.stopProgress()
+
+		in := r.recordIn(io.NopCloser(strings.NewReader("ls\r")))
+		_, err = io.ReadAll(in)
+		require.NoError(t, err)
+		_, err = r.recordOut(nopCloser{io.Discard}).Write([]byte("bin\r\n"))
+		require.NoError(t, err)
+		r.recordResize(&controllerapi.ResizeMessage{Cols: 120, Rows: 40})
+
+		// the progress of a rebuild is recorded too
+		require.NoError(t, r.startProgress())
+		writeVertex(r.Progress(nopProgress{}), "rebuild")
+		r.stopProgress()
+		writeVertex(r.Progress(nopProgress{}), "ignored")
+		require.NoError(t, r.Close())
+
+		fi, err := os.Stat(p)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+		events := strings.Join(readEvents(t, p), "\n")
+		require.Contains(t, events, "initial")
+		require.Contains(t, events, "o bin\r\n")
+		require.Contains(t, events, "r 120x40")
+		require.Contains(t, events, "rebuild")
+		require.NotContains(t, events, "ignored")
+		if input {
+			require.Contains(t, events, "i ls\r")
+		} else {
+			require.NotContains(t, events, "i ls")
+		}
+	}
+}
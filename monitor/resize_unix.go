@@ -0,0 +1,44 @@
+//go:build !windows
+// +build !windows
+
+package monitor
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/containerd/console"
+	controllerapi "github.com/docker/buildx/controller/pb"
+)
+
+// notifyResize sends the size of con to the returned channel each time the
+// terminal is resized, until stop is called.
+func notifyResize(con console.Console) (<-chan *controllerapi.ResizeMessage, func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGWINCH)
+	resizeCh := make(chan *controllerapi.ResizeMessage)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+			case <-done:
+				return
+			}
+			size, err := con.Size()
+			if err != nil {
+				continue
+			}
+			select {
+			case resizeCh <- &controllerapi.ResizeMessage{Rows: uint32(size.Height), Cols: uint32(size.Width)}:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return resizeCh, func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
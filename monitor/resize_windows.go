@@ -0,0 +1,12 @@
+package monitor
+
+import (
+	"github.com/containerd/console"
+	controllerapi "github.com/docker/buildx/controller/pb"
+)
+
+// notifyResize returns a channel that never receives as the resizes of the
+// console aren't notified on Windows.
+func notifyResize(con console.Console) (<-chan *controllerapi.ResizeMessage, func()) {
+	return nil, func() {}
+}
@@ -2,7 +2,12 @@ package tests
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net"
@@ -53,6 +58,7 @@ var buildTests = []func(t *testing.T, sb integration.Sandbox){
 	testImageIDOutput,
 	testBuildLocalExport,
 	testBuildRegistryExport,
+	testBuildRegistryExportSignKey,
 	testBuildRegistryExportAttestations,
 	testBuildTarExport,
 	testBuildMobyFromLocalImage,
@@ -324,6 +330,40 @@ func testBuildRegistryExport(t *testing.T, sb integration.Sandbox) {
 	require.Equal(t, img.Layers[0]["bar"].Data, []byte("foo"))
 }
 
+func testBuildRegistryExportSignKey(t *testing.T, sb integration.Sandbox) {
+	if isMobyWorker(sb) {
+		t.Skip("image index not pushed by docker worker")
+	}
+	dir := createTestProject(t)
+
+	registry, err := sb.NewRegistry()
+	if errors.Is(err, integration.ErrRequirements) {
+		t.Skip(err.Error())
+	}
+	require.NoError(t, err)
+	target := registry + "/buildx/registry:latest"
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privDt, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDt, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+	keyDir := t.TempDir()
+	privFile := filepath.Join(keyDir, "key.pem")
+	pubFile := filepath.Join(keyDir, "key.pub")
+	require.NoError(t, os.WriteFile(privFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDt}), 0600))
+	require.NoError(t, os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDt}), 0600))
+
+	out, err := buildCmd(sb, withArgs(fmt.Sprintf("--output=type=image,name=%s,push=true", target), "--sign-key", privFile, dir))
+	require.NoError(t, err, string(out))
+
+	cmd := buildxCmd(sb, withArgs("imagetools", "verify", "--key", pubFile, target))
+	dt, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(dt))
+	require.Contains(t, string(dt), "Index: PASS")
+}
+
 func testBuildRegistryExportAttestations(t *testing.T, sb integration.Sandbox) {
 	dir := createTestProject(t)
 
@@ -0,0 +1,216 @@
+// Package asciicast reads and writes terminal sessions in the asciicast v2
+// format (https://docs.asciinema.org/manual/asciicast/v2/).
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const Version = 2
+
+// EventType is the type of an event of the session.
+type EventType string
+
+const (
+	// Output is data written to the terminal.
+	Output EventType = "o"
+	// Input is data read from the terminal.
+	Input EventType = "i"
+	// Resize is a resize of the terminal, with "COLSxROWS" as data.
+	Resize EventType = "r"
+	// Marker is a named point of the session.
+	Marker EventType = "m"
+)
+
+// Header is the first line of an asciicast file.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Event is an event of the session, at Time since its beginning.
+type Event struct {
+	Time time.Duration
+	Type EventType
+	Data string
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Time.Seconds(), e.Type, e.Data})
+}
+
+func (e *Event) UnmarshalJSON(dt []byte) error {
+	var v []json.RawMessage
+	if err := json.Unmarshal(dt, &v); err != nil {
+		return err
+	}
+	if len(v) != 3 {
+		return errors.Errorf("invalid event: expected 3 elements, got %d", len(v))
+	}
+	var t float64
+	if err := json.Unmarshal(v[0], &t); err != nil {
+		return errors.Wrap(err, "invalid event time")
+	}
+	if err := json.Unmarshal(v[1], &e.Type); err != nil {
+		return errors.Wrap(err, "invalid event type")
+	}
+	if err := json.Unmarshal(v[2], &e.Data); err != nil {
+		return errors.Wrap(err, "invalid event data")
+	}
+	e.Time = time.Duration(t * float64(time.Second))
+	return nil
+}
+
+// Writer records the events of a session. It is safe for concurrent use.
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	enc     *json.Encoder
+	start   time.Time
+	partial map[EventType][]byte
+	err     error
+}
+
+// NewWriter writes the header to w and returns a Writer recording the events
+// from now on.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	header.Version = Version
+	if header.Timestamp == 0 {
+		header.Timestamp = time.Now().Unix()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(header); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:       w,
+		enc:     enc,
+		start:   time.Now(),
+		partial: make(map[EventType][]byte),
+	}, nil
+}
+
+// Write records p as an event of type typ. An incomplete UTF-8 sequence at
+// the end of p is held until the next event of the same type.
+func (w *Writer) Write(typ EventType, p []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	dt := append(w.partial[typ], p...)
+	n := completeLen(dt)
+	w.partial[typ] = append([]byte(nil), dt[n:]...)
+	if n == 0 {
+		return nil
+	}
+	return w.encode(Event{Time: time.Since(w.start), Type: typ, Data: string(dt[:n])})
+}
+
+// Resize records a resize of the terminal.
+func (w *Writer) Resize(cols, rows uint32) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	return w.encode(Event{Time: time.Since(w.start), Type: Resize, Data: fmt.Sprintf("%dx%d", cols, rows)})
+}
+
+// Writer returns an io.Writer recording the data written to it as events of
+// type typ.
+func (w *Writer) Writer(typ EventType) io.Writer {
+	return &eventWriter{w: w, typ: typ}
+}
+
+func (w *Writer) encode(e Event) error {
+	if err := w.enc.Encode(e); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+type eventWriter struct {
+	w   *Writer
+	typ EventType
+}
+
+func (ew *eventWriter) Write(p []byte) (int, error) {
+	if err := ew.w.Write(ew.typ, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// completeLen returns the length of dt without its trailing incomplete UTF-8
+// sequence, if any.
+func completeLen(dt []byte) int {
+	for i := len(dt) - 1; i >= 0 && i >= len(dt)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(dt[i]) {
+			continue
+		}
+		if !utf8.FullRune(dt[i:]) {
+			return i
+		}
+		break
+	}
+	return len(dt)
+}
+
+// Reader reads the events of a session.
+type Reader struct {
+	Header Header
+	s      *bufio.Scanner
+}
+
+// NewReader reads the header from r and returns a Reader for the events.
+func NewReader(r io.Reader) (*Reader, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("missing asciicast header")
+	}
+	var header Header
+	if err := json.Unmarshal(s.Bytes(), &header); err != nil {
+		return nil, errors.Wrap(err, "invalid asciicast header")
+	}
+	if header.Version != Version {
+		return nil, errors.Errorf("unsupported asciicast version %d", header.Version)
+	}
+	return &Reader{Header: header, s: s}, nil
+}
+
+// Next returns the next event. It returns io.EOF after the last one.
+func (r *Reader) Next() (*Event, error) {
+	for r.s.Scan() {
+		if len(r.s.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(r.s.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		return &e, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,75 @@
+package asciicast
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRead(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{Width: 80, Height: 24, Title: "test"})
+	require.NoError(t, err)
+
+	_, err = io.WriteString(w.Writer(Output), "hello\r\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Write(Input, []byte("ls\r")))
+	require.NoError(t, w.Resize(120, 40))
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+	require.Equal(t, Version, r.Header.Version)
+	require.Equal(t, 80, r.Header.Width)
+	require.Equal(t, 24, r.Header.Height)
+	require.Equal(t, "test", r.Header.Title)
+	require.NotZero(t, r.Header.Timestamp)
+
+	var events []Event
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		events = append(events, *e)
+	}
+	require.Len(t, events, 3)
+	require.Equal(t, Output, events[0].Type)
+	require.Equal(t, "hello\r\n", events[0].Data)
+	require.Equal(t, Input, events[1].Type)
+	require.Equal(t, "ls\r", events[1].Data)
+	require.Equal(t, Resize, events[2].Type)
+	require.Equal(t, "120x40", events[2].Data)
+	require.LessOrEqual(t, events[0].Time, events[2].Time)
+}
+
+func TestWriteSplitRune(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{Width: 80, Height: 24})
+	require.NoError(t, err)
+
+	dt := []byte("a€b")
+	require.NoError(t, w.Write(Output, dt[:2]))
+	require.NoError(t, w.Write(Output, dt[2:]))
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+	e, err := r.Next()
+	require.NoError(t, err)
+	require.Equal(t, "a", e.Data)
+	e, err = r.Next()
+	require.NoError(t, err)
+	require.Equal(t, "€b", e.Data)
+	_, err = r.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadInvalidHeader(t *testing.T) {
+	_, err := NewReader(bytes.NewBufferString(`{"version":1,"width":80,"height":24}` + "\n"))
+	require.ErrorContains(t, err, "unsupported asciicast version")
+
+	_, err = NewReader(bytes.NewBufferString(""))
+	require.ErrorContains(t, err, "missing asciicast header")
+}
@@ -0,0 +1,441 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// inTotoStatementDSSEMime is the media type of the attestations added as
+	// DSSE envelopes whose predicate has no specific media type.
+	inTotoStatementDSSEMime = "application/vnd.in-toto.statement+dsse"
+
+	annotationPredicateType   = "in-toto.io/predicate-type"
+	annotationReferenceDigest = "vnd.docker.reference.digest"
+	annotationReferenceType   = "vnd.docker.reference.type"
+	attestationManifestType   = "attestation-manifest"
+)
+
+// Attestation describes an attestation attached to an image of an index.
+type Attestation struct {
+	Platform      string             `json:"platform"`
+	Subject       digest.Digest      `json:"subject"`
+	Manifest      digest.Digest      `json:"manifest"`
+	PredicateType string             `json:"predicateType"`
+	Layer         ocispec.Descriptor `json:"layer"`
+}
+
+// Blob is a content to push along with an index.
+type Blob struct {
+	Desc ocispec.Descriptor
+	Data []byte
+}
+
+// IndexUpdate is an index modified from an existing one. The new blobs it
+// refers to must be pushed before the index itself with PushUpdate.
+type IndexUpdate struct {
+	Source *Source
+	Blobs  []Blob
+	Desc   ocispec.Descriptor
+	Data   []byte
+	// Referrers are the manifests whose subject is the new index, pushed
+	// after it.
+	Referrers []Blob
+}
+
+type attestIndex struct {
+	ref   reference.Named
+	desc  ocispec.Descriptor
+	index ocispec.Index
+	// images are the platforms of the image manifests by digest
+	images map[digest.Digest]string
+	// manifests are the attestation manifests by subject digest
+	manifests map[digest.Digest]ocispec.Manifest
+	attDescs  map[digest.Digest]ocispec.Descriptor
+}
+
+func (r *Resolver) loadAttestIndex(ctx context.Context, in string) (*attestIndex, error) {
+	ref, err := parseRef(in)
+	if err != nil {
+		return nil, err
+	}
+	dt, desc, err := r.Get(ctx, ref.String())
+	if err != nil {
+		return nil, err
+	}
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+	default:
+		return nil, errors.Errorf("%s is not an image index, attestations can only be attached to the images of an index", in)
+	}
+
+	ai := &attestIndex{
+		ref:       ref,
+		desc:      desc,
+		images:    make(map[digest.Digest]string),
+		manifests: make(map[digest.Digest]ocispec.Manifest),
+		attDescs:  make(map[digest.Digest]ocispec.Descriptor),
+	}
+	if err := json.Unmarshal(dt, &ai.index); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, d := range ai.index.Manifests {
+		if subject := attestationSubject(d); subject != "" {
+			dt, err := r.GetDescriptor(ctx, ref.String(), d)
+			if err != nil {
+				return nil, err
+			}
+			var mfst ocispec.Manifest
+			if err := json.Unmarshal(dt, &mfst); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			ai.manifests[subject] = mfst
+			ai.attDescs[subject] = d
+		} else if d.Platform != nil {
+			ai.images[d.Digest] = platforms.Format(*d.Platform)
+		}
+	}
+	return ai, nil
+}
+
+// ListAttestations returns the attestations attached to the images of an
+// index.
+func (r *Resolver) ListAttestations(ctx context.Context, in string) ([]Attestation, error) {
+	ai, err := r.loadAttestIndex(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	var res []Attestation
+	for _, d := range ai.index.Manifests {
+		subject := attestationSubject(d)
+		if subject == "" {
+			continue
+		}
+		for _, l := range ai.manifests[subject].Layers {
+			res = append(res, Attestation{
+				Platform:      ai.images[subject],
+				Subject:       subject,
+				Manifest:      d.Digest,
+				PredicateType: l.Annotations[annotationPredicateType],
+				Layer:         l,
+			})
+		}
+	}
+	return res, nil
+}
+
+// AddAttestation attaches an in-toto statement, or a DSSE envelope of a
+// statement, to the images of an index matching the given platforms, or all
+// of them if none is given. The statement is added to the attestation
+// manifest of each image. Statements without subject are bound to the image
+// they are attached to.
+func (r *Resolver) AddAttestation(ctx context.Context, in string, dt []byte, ps []ocispec.Platform) (*IndexUpdate, error) {
+	ai, err := r.loadAttestIndex(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	mt, err := statementMediaType(dt)
+	if err != nil {
+		return nil, err
+	}
+	stmt, envelope, err := parseStatement(dt, mt)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &IndexUpdate{
+		Source: &Source{Ref: ai.ref, Desc: ai.desc},
+	}
+	var found bool
+	for _, d := range ai.index.Manifests {
+		if attestationSubject(d) != "" || d.Platform == nil || !matchPlatform(*d.Platform, ps) {
+			continue
+		}
+		found = true
+
+		layerDt := dt
+		if envelope == nil && len(stmt.Subject) == 0 {
+			s := *stmt
+			s.Subject = []intoto.Subject{{
+				Name:   "_",
+				Digest: map[string]string{d.Digest.Algorithm().String(): d.Digest.Encoded()},
+			}}
+			if layerDt, err = json.Marshal(s); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		layer := ocispec.Descriptor{
+			MediaType: mt,
+			Digest:    digest.FromBytes(layerDt),
+			Size:      int64(len(layerDt)),
+			Annotations: map[string]string{
+				annotationPredicateType: stmt.PredicateType,
+			},
+		}
+		if err := ai.attach(u, d.Digest, Blob{Desc: layer, Data: layerDt}, func(l ocispec.Descriptor) bool {
+			return l.Digest == layer.Digest
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no image found in %s for the given platforms", in)
+	}
+
+	if err := ai.update(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// attach adds the layer to the attestation manifest of the image with the
+// given digest. The existing layers for which replace returns true are
+// removed.
+func (ai *attestIndex) attach(u *IndexUpdate, subject digest.Digest, layer Blob, replace func(ocispec.Descriptor) bool) error {
+	u.Blobs = append(u.Blobs, layer)
+
+	var layers []ocispec.Descriptor
+	for _, l := range ai.manifests[subject].Layers {
+		if !replace(l) {
+			layers = append(layers, l)
+		}
+	}
+	blobs, err := attestationManifest(subject, append(layers, layer.Desc))
+	if err != nil {
+		return err
+	}
+	u.Blobs = append(u.Blobs, blobs...)
+	ai.attDescs[subject] = blobs[len(blobs)-1].Desc
+	return nil
+}
+
+// RemoveAttestations removes the attestations with the given predicate type
+// from the images of an index matching the given platforms, or all of them
+// if none is given. Attestation manifests left without attestations are
+// removed from the index.
+func (r *Resolver) RemoveAttestations(ctx context.Context, in string, predicateType string, ps []ocispec.Platform) (*IndexUpdate, error) {
+	ai, err := r.loadAttestIndex(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &IndexUpdate{
+		Source: &Source{Ref: ai.ref, Desc: ai.desc},
+	}
+	var removed bool
+	for subject, mfst := range ai.manifests {
+		if p, ok := ai.images[subject]; ok && len(ps) > 0 {
+			pp, err := platforms.Parse(p)
+			if err != nil {
+				return nil, err
+			}
+			if !matchPlatform(pp, ps) {
+				continue
+			}
+		}
+		var layers []ocispec.Descriptor
+		for _, l := range mfst.Layers {
+			if l.Annotations[annotationPredicateType] != predicateType {
+				layers = append(layers, l)
+			}
+		}
+		if len(layers) == len(mfst.Layers) {
+			continue
+		}
+		removed = true
+		if len(layers) == 0 {
+			delete(ai.attDescs, subject)
+			continue
+		}
+		blobs, err := attestationManifest(subject, layers)
+		if err != nil {
+			return nil, err
+		}
+		u.Blobs = append(u.Blobs, blobs...)
+		ai.attDescs[subject] = blobs[len(blobs)-1].Desc
+	}
+	if !removed {
+		return nil, errors.Errorf("no %s attestation found in %s", predicateType, in)
+	}
+
+	if err := ai.update(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// update sets the new index of u with the current attestation manifests.
+func (ai *attestIndex) update(u *IndexUpdate) error {
+	idx := ai.index
+	idx.MediaType = ocispec.MediaTypeImageIndex
+	idx.SchemaVersion = 2
+	idx.Manifests = nil
+	for _, d := range ai.index.Manifests {
+		if attestationSubject(d) != "" {
+			continue
+		}
+		idx.Manifests = append(idx.Manifests, d)
+	}
+	for _, d := range ai.index.Manifests {
+		if attestationSubject(d) != "" || d.Platform == nil {
+			continue
+		}
+		if att, ok := ai.attDescs[d.Digest]; ok {
+			idx.Manifests = append(idx.Manifests, att)
+		}
+	}
+
+	dt, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal index")
+	}
+	u.Data = dt
+	u.Desc = ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromBytes(dt),
+		Size:      int64(len(dt)),
+	}
+	return nil
+}
+
+// PushUpdate pushes the blobs and the index of an update with the tag of
+// dest, then its referrers. The content of the source index is copied first
+// if dest is in another repository.
+func (r *Resolver) PushUpdate(ctx context.Context, u *IndexUpdate, dest reference.Named) error {
+	if u.Source.Ref.Name() != dest.Name() {
+		if err := r.Copy(ctx, u.Source, dest); err != nil {
+			return err
+		}
+	}
+	for _, b := range u.Blobs {
+		ref, err := reference.WithDigest(reference.TrimNamed(dest), b.Desc.Digest)
+		if err != nil {
+			return err
+		}
+		if err := r.Push(ctx, ref, b.Desc, b.Data); err != nil {
+			return err
+		}
+	}
+	if err := r.Push(ctx, dest, u.Desc, u.Data); err != nil {
+		return err
+	}
+	for _, b := range u.Referrers {
+		if err := r.pushReferrer(ctx, dest, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attestationManifest returns the config and manifest blobs of an
+// attestation manifest for the image with the given digest. The manifest is
+// the last blob.
+func attestationManifest(subject digest.Digest, layers []ocispec.Descriptor) ([]Blob, error) {
+	config := ocispec.Image{
+		Platform: ocispec.Platform{
+			Architecture: "unknown",
+			OS:           "unknown",
+		},
+		RootFS: ocispec.RootFS{
+			Type: "layers",
+		},
+	}
+	for _, l := range layers {
+		config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, l.Digest)
+	}
+	configDt, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	configDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageConfig,
+		Digest:    digest.FromBytes(configDt),
+		Size:      int64(len(configDt)),
+	}
+
+	mfstDt, err := json.MarshalIndent(ocispec.Manifest{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    layers,
+	}, "", "  ")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	mfstDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(mfstDt),
+		Size:      int64(len(mfstDt)),
+		Platform: &ocispec.Platform{
+			Architecture: "unknown",
+			OS:           "unknown",
+		},
+		Annotations: map[string]string{
+			annotationReferenceDigest: subject.String(),
+			annotationReferenceType:   attestationManifestType,
+		},
+	}
+
+	return []Blob{
+		{Desc: configDesc, Data: configDt},
+		{Desc: mfstDesc, Data: mfstDt},
+	}, nil
+}
+
+// statementMediaType returns the media type of an in-toto statement or of a
+// DSSE envelope, identified by its in-toto payload type.
+func statementMediaType(dt []byte) (string, error) {
+	var env dsseEnvelope
+	if err := json.Unmarshal(dt, &env); err != nil {
+		return "", errors.Wrap(err, "failed to parse attestation")
+	}
+	switch env.PayloadType {
+	case "":
+		return inTotoGenericMime, nil
+	case intoto.PayloadType:
+		return inTotoStatementDSSEMime, nil
+	default:
+		return "", errors.Errorf("unsupported DSSE payload type %q", env.PayloadType)
+	}
+}
+
+// parseStatement parses an in-toto statement, or a DSSE envelope containing
+// one if the media type is a DSSE one. The envelope is returned if the
+// statement was wrapped in one.
+func parseStatement(dt []byte, mime string) (*intoto.Statement, *dsseEnvelope, error) {
+	payload, envelope, err := decodeDSSE(dt, mime)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode DSSE payload")
+	}
+
+	var stmt intoto.Statement
+	if err := json.Unmarshal(payload, &stmt); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse in-toto statement")
+	}
+	if stmt.PredicateType == "" {
+		return nil, nil, errors.New("invalid in-toto statement, predicateType is required")
+	}
+	if stmt.Type == "" {
+		stmt.Type = intoto.StatementInTotoV01
+	}
+	return &stmt, envelope, nil
+}
+
+func matchPlatform(p ocispec.Platform, ps []ocispec.Platform) bool {
+	if len(ps) == 0 {
+		return true
+	}
+	return platforms.Any(ps...).Match(p)
+}
@@ -0,0 +1,170 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestations(t *testing.T) {
+	ctx := context.Background()
+	r := New(Opt{})
+
+	ref := "oci-layout://" + t.TempDir() + ":v1"
+	writeTestIndex(t, r, ref, "linux/amd64", "linux/arm64")
+
+	atts, err := r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Empty(t, atts)
+
+	stmt, err := json.Marshal(intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			PredicateType: "https://openvex.dev/ns/v0.2.0",
+		},
+		Predicate: map[string]interface{}{"statements": []interface{}{}},
+	})
+	require.NoError(t, err)
+
+	u, err := r.AddAttestation(ctx, ref, stmt, []ocispec.Platform{platforms.MustParse("linux/arm64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	atts, err = r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+	require.Equal(t, "linux/arm64", atts[0].Platform)
+	require.Equal(t, "https://openvex.dev/ns/v0.2.0", atts[0].PredicateType)
+
+	// statement without subject is bound to the image
+	dt, err := r.GetDescriptor(ctx, ref, atts[0].Layer)
+	require.NoError(t, err)
+	var s intoto.Statement
+	require.NoError(t, json.Unmarshal(dt, &s))
+	require.Equal(t, intoto.StatementInTotoV01, s.Type)
+	require.Len(t, s.Subject, 1)
+	require.Equal(t, atts[0].Subject.Encoded(), s.Subject[0].Digest["sha256"])
+
+	u, err = r.AddAttestation(ctx, ref, stmt, nil)
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	atts, err = r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, atts, 2)
+
+	// the loader sees the attestation manifests
+	res, err := newLoader(r.resolver()).Load(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, res.refs, 2)
+
+	u, err = r.RemoveAttestations(ctx, ref, "https://openvex.dev/ns/v0.2.0", []ocispec.Platform{platforms.MustParse("linux/amd64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	atts, err = r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+	require.Equal(t, "linux/arm64", atts[0].Platform)
+
+	_, err = r.RemoveAttestations(ctx, ref, "https://slsa.dev/provenance/v0.2", nil)
+	require.Error(t, err)
+
+	_, err = r.AddAttestation(ctx, ref, []byte(`{"predicate": {}}`), nil)
+	require.ErrorContains(t, err, "predicateType is required")
+
+	// DSSE envelopes are attached as they are
+	env, err := json.Marshal(dsseEnvelope{
+		PayloadType: intoto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(stmt),
+	})
+	require.NoError(t, err)
+	u, err = r.AddAttestation(ctx, ref, env, []ocispec.Platform{platforms.MustParse("linux/amd64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	atts, err = r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, atts, 2)
+	var found bool
+	for _, a := range atts {
+		if a.Platform != "linux/amd64" {
+			continue
+		}
+		found = true
+		require.Equal(t, inTotoStatementDSSEMime, a.Layer.MediaType)
+		require.Equal(t, "https://openvex.dev/ns/v0.2.0", a.PredicateType)
+		dt, err := r.GetDescriptor(ctx, ref, a.Layer)
+		require.NoError(t, err)
+		require.Equal(t, env, dt)
+	}
+	require.True(t, found)
+
+	_, err = r.AddAttestation(ctx, ref, []byte(`{"payloadType": "text/plain", "payload": "aGVsbG8="}`), nil)
+	require.ErrorContains(t, err, `unsupported DSSE payload type "text/plain"`)
+}
+
+// writeTestIndex pushes an index with an empty image for each platform.
+func writeTestIndex(t *testing.T, r *Resolver, ref string, pp ...string) {
+	ctx := context.Background()
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+
+	var blobs []Blob
+	idx := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+	}
+	for _, p := range pp {
+		platform := platforms.MustParse(p)
+		configDt, err := json.Marshal(ocispec.Image{Platform: platform})
+		require.NoError(t, err)
+		configDesc := ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageConfig,
+			Digest:    digest.FromBytes(configDt),
+			Size:      int64(len(configDt)),
+		}
+		mfstDt, err := json.Marshal(ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    configDesc,
+			Layers:    []ocispec.Descriptor{},
+		})
+		require.NoError(t, err)
+		mfstDesc := ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(mfstDt),
+			Size:      int64(len(mfstDt)),
+			Platform:  &platform,
+		}
+		blobs = append(blobs, Blob{Desc: configDesc, Data: configDt}, Blob{Desc: mfstDesc, Data: mfstDt})
+		idx.Manifests = append(idx.Manifests, mfstDesc)
+	}
+	for _, b := range blobs {
+		dref, err := reference.WithDigest(reference.TrimNamed(named), b.Desc.Digest)
+		require.NoError(t, err)
+		require.NoError(t, r.Push(ctx, dref, b.Desc, b.Data))
+	}
+
+	idxDt, err := json.Marshal(idx)
+	require.NoError(t, err)
+	require.NoError(t, r.Push(ctx, named, ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromBytes(idxDt),
+		Size:      int64(len(idxDt)),
+	}, idxDt))
+}
+
+func pushTestUpdate(t *testing.T, r *Resolver, u *IndexUpdate, ref string) {
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+	require.NoError(t, r.PushUpdate(context.Background(), u, named))
+}
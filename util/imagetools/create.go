@@ -7,6 +7,7 @@ import (
 	"maps"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
@@ -191,6 +192,118 @@ func (r *Resolver) Combine(ctx context.Context, srcs []*Source, ann map[exptypes
 	}, nil
 }
 
+// FilterPlatforms replaces the sources with the manifests matching one of the
+// given platforms. Indexes are expanded into the matching manifests and the
+// attestation manifests referencing them, so they can be combined and copied
+// without the manifests of the other platforms. The annotations of the
+// expanded indexes are returned so they can be set on the combined index.
+func (r *Resolver) FilterPlatforms(ctx context.Context, srcs []*Source, ps []ocispec.Platform) ([]*Source, map[exptypes.AnnotationKey]string, error) {
+	matcher := platforms.Any(ps...)
+
+	var mu sync.Mutex
+	ann := make(map[exptypes.AnnotationKey]string)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	res := make([][]*Source, len(srcs))
+	for i, src := range srcs {
+		func(i int, src *Source) {
+			eg.Go(func() error {
+				dt, err := r.GetDescriptor(ctx, src.Ref.String(), src.Desc)
+				if err != nil {
+					return err
+				}
+				mt := src.Desc.MediaType
+				if mt == "" {
+					if mt, err = detectMediaType(dt); err != nil {
+						return err
+					}
+				}
+				switch mt {
+				case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+					var idx ocispec.Index
+					if err := json.Unmarshal(dt, &idx); err != nil {
+						return errors.WithStack(err)
+					}
+					for _, d := range filterDescriptors(idx.Manifests, matcher) {
+						res[i] = append(res[i], &Source{Ref: src.Ref, Desc: d})
+					}
+					mu.Lock()
+					for k, v := range idx.Annotations {
+						ann[exptypes.AnnotationKey{Type: exptypes.AnnotationIndex, Key: k}] = v
+					}
+					mu.Unlock()
+				default:
+					desc := src.Desc
+					desc.MediaType = mt
+					p := desc.Platform
+					if p == nil {
+						p = &ocispec.Platform{}
+					}
+					if p.OS == "" || p.Architecture == "" {
+						if err := r.loadPlatform(ctx, p, src.Ref.String(), dt); err != nil {
+							return err
+						}
+					}
+					desc.Platform = p
+					if matcher.Match(*p) {
+						res[i] = []*Source{{Ref: src.Ref, Desc: desc}}
+					}
+				}
+				return nil
+			})
+		}(i, src)
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	var out []*Source
+	for _, ss := range res {
+		out = append(out, ss...)
+	}
+	if len(out) == 0 {
+		pp := make([]string, len(ps))
+		for i, p := range ps {
+			pp[i] = platforms.Format(p)
+		}
+		return nil, nil, errors.Errorf("no manifest found for platforms %s", strings.Join(pp, ", "))
+	}
+	return out, ann, nil
+}
+
+// filterDescriptors returns the descriptors of an index matching the given
+// platform along with the attestation manifests referencing them.
+func filterDescriptors(descs []ocispec.Descriptor, matcher platforms.Matcher) []ocispec.Descriptor {
+	keep := make(map[digest.Digest]struct{})
+	for _, d := range descs {
+		if d.Platform != nil && attestationSubject(d) == "" && matcher.Match(*d.Platform) {
+			keep[d.Digest] = struct{}{}
+		}
+	}
+	var out []ocispec.Descriptor
+	for _, d := range descs {
+		if _, ok := keep[d.Digest]; ok {
+			out = append(out, d)
+		} else if subject := attestationSubject(d); subject != "" {
+			if _, ok := keep[subject]; ok {
+				out = append(out, d)
+			}
+		}
+	}
+	return out
+}
+
+// attestationSubject returns the digest of the image manifest an
+// attestation manifest refers to.
+func attestationSubject(d ocispec.Descriptor) digest.Digest {
+	for _, k := range annotationReferences {
+		if v, ok := d.Annotations[k]; ok {
+			return digest.Digest(v)
+		}
+	}
+	return ""
+}
+
 func (r *Resolver) Push(ctx context.Context, ref reference.Named, desc ocispec.Descriptor, dt []byte) error {
 	ctx = remotes.WithMediaTypeKeyPrefix(ctx, "application/vnd.in-toto+json", "intoto")
 
@@ -200,18 +313,37 @@ func (r *Resolver) Push(ctx context.Context, ref reference.Named, desc ocispec.D
 		return err
 	}
 	cw, err := p.Push(ctx, desc)
-	if err != nil {
-		if errdefs.IsAlreadyExists(err) {
-			return nil
-		}
+	if err == nil {
+		err = content.Copy(ctx, cw, bytes.NewReader(dt), desc.Size, desc.Digest)
+	}
+	if err != nil && !errdefs.IsAlreadyExists(err) {
 		return err
 	}
 
-	err = content.Copy(ctx, cw, bytes.NewReader(dt), desc.Size, desc.Digest)
-	if errdefs.IsAlreadyExists(err) {
-		return nil
+	if IsOCILayout(ref) {
+		return tagOCILayout(ref, desc)
+	}
+	return nil
+}
+
+// Tag pushes the manifest referenced by in with the tag of dest, without
+// pulling the image. The content is copied first if dest is in another
+// repository. The manifest is pushed as is and keeps its digest.
+func (r *Resolver) Tag(ctx context.Context, in string, dest reference.Named) error {
+	ref, err := parseRef(in)
+	if err != nil {
+		return err
 	}
-	return err
+	dt, desc, err := r.Get(ctx, ref.String())
+	if err != nil {
+		return err
+	}
+	if ref.Name() != dest.Name() {
+		if err := r.Copy(ctx, &Source{Ref: ref, Desc: desc}, dest); err != nil {
+			return err
+		}
+	}
+	return r.Push(ctx, dest, desc, dt)
 }
 
 func (r *Resolver) Copy(ctx context.Context, src *Source, dest reference.Named) error {
@@ -229,20 +361,22 @@ func (r *Resolver) Copy(ctx context.Context, src *Source, dest reference.Named)
 		return err
 	}
 
-	refspec := reference.TrimNamed(src.Ref).String()
-	u, err := url.Parse("dummy://" + refspec)
-	if err != nil {
-		return err
-	}
-
 	desc := src.Desc
-	desc.Annotations = maps.Clone(desc.Annotations)
-	if desc.Annotations == nil {
-		desc.Annotations = make(map[string]string)
-	}
+	if !IsOCILayout(src.Ref) {
+		refspec := reference.TrimNamed(src.Ref).String()
+		u, err := url.Parse("dummy://" + refspec)
+		if err != nil {
+			return err
+		}
+
+		desc.Annotations = maps.Clone(desc.Annotations)
+		if desc.Annotations == nil {
+			desc.Annotations = make(map[string]string)
+		}
 
-	source, repo := u.Hostname(), strings.TrimPrefix(u.Path, "/")
-	desc.Annotations["containerd.io/distribution.source."+source] = repo
+		source, repo := u.Hostname(), strings.TrimPrefix(u.Path, "/")
+		desc.Annotations["containerd.io/distribution.source."+source] = repo
+	}
 
 	err = contentutil.CopyChain(ctx, contentutil.FromPusher(p), contentutil.FromFetcher(f), desc)
 	if err != nil {
@@ -0,0 +1,46 @@
+package imagetools
+
+import (
+	"testing"
+
+	"github.com/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterDescriptors(t *testing.T) {
+	image := func(p string) ocispec.Descriptor {
+		pp := platforms.MustParse(p)
+		return ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString(p),
+			Platform:  &pp,
+		}
+	}
+	attestation := func(subject ocispec.Descriptor) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString("attestation " + subject.Digest.String()),
+			Platform:  &ocispec.Platform{OS: "unknown", Architecture: "unknown"},
+			Annotations: map[string]string{
+				"vnd.docker.reference.digest": subject.Digest.String(),
+				"vnd.docker.reference.type":   "attestation-manifest",
+			},
+		}
+	}
+
+	amd64 := image("linux/amd64")
+	arm64 := image("linux/arm64")
+	armv7 := image("linux/arm/v7")
+	descs := []ocispec.Descriptor{
+		amd64, arm64, armv7,
+		attestation(amd64), attestation(arm64), attestation(armv7),
+	}
+
+	res := filterDescriptors(descs, platforms.Any(platforms.MustParse("linux/amd64"), platforms.MustParse("linux/arm64")))
+	require.Equal(t, []ocispec.Descriptor{amd64, arm64, attestation(amd64), attestation(arm64)}, res)
+
+	res = filterDescriptors(descs, platforms.Any(platforms.MustParse("windows/amd64")))
+	require.Empty(t, res)
+}
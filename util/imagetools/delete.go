@@ -0,0 +1,116 @@
+package imagetools
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Deletion is the manifest deleted by Delete, referenced by Ref. Only this
+// manifest is deleted: the attestation manifests of an index may be shared
+// with other indexes, so they are left to the garbage collection of the
+// registry like layers and configs.
+type Deletion struct {
+	Ref  reference.Named
+	Desc ocispec.Descriptor
+}
+
+// PlanDelete resolves the manifest to delete for the reference in.
+func (r *Resolver) PlanDelete(ctx context.Context, in string) (*Deletion, error) {
+	ref, err := parseRef(in)
+	if err != nil {
+		return nil, err
+	}
+	if IsOCILayout(ref) {
+		return nil, errors.Errorf("deleting from an OCI layout is not supported: %s", in)
+	}
+	_, desc, err := r.Resolve(ctx, ref.String())
+	if err != nil {
+		return nil, err
+	}
+	return &Deletion{Ref: ref, Desc: desc}, nil
+}
+
+// Delete deletes the manifest of d from the registry by digest.
+func (r *Resolver) Delete(ctx context.Context, d *Deletion) error {
+	return r.deleteManifest(ctx, d.Ref, d.Desc.Digest.String())
+}
+
+// DeleteTag deletes the tag of ref without deleting the manifest it points
+// to. Tag deletion is optional in the distribution spec and not all the
+// registries support it.
+func (r *Resolver) DeleteTag(ctx context.Context, ref reference.Named) error {
+	tagged, ok := ref.(reference.Tagged)
+	if !ok {
+		return errors.Errorf("%s has no tag", ref)
+	}
+	if _, ok := ref.(reference.Digested); ok {
+		return errors.Errorf("%s is referenced by digest, only the tag can be set to delete it", ref)
+	}
+	return r.deleteManifest(ctx, ref, tagged.Tag())
+}
+
+func (r *Resolver) deleteManifest(ctx context.Context, ref reference.Named, tagOrDigest string) error {
+	resp, err := r.registryRequest(ctx, ref, http.MethodDelete, "/manifests/"+tagOrDigest, http.Header{}, "pull,push,delete")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		return nil
+	case http.StatusNotFound:
+		return errors.Wrapf(errdefs.ErrNotFound, "failed to delete %s from %s", tagOrDigest, reference.TrimNamed(ref))
+	case http.StatusMethodNotAllowed:
+		return errors.Errorf("failed to delete %s from %s: deletion is not supported by the registry", tagOrDigest, reference.TrimNamed(ref))
+	default:
+		return errors.Errorf("failed to delete %s from %s: %s", tagOrDigest, reference.TrimNamed(ref), resp.Status)
+	}
+}
+
+// RemovePlatforms removes the images matching the given platforms from an
+// index. Attestation manifests of removed images, or of images that are not
+// in the index anymore, are removed as well.
+func (r *Resolver) RemovePlatforms(ctx context.Context, in string, ps []ocispec.Platform) (*IndexUpdate, error) {
+	if len(ps) == 0 {
+		return nil, errors.New("no platform to remove")
+	}
+	ai, err := r.loadAttestIndex(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []ocispec.Descriptor
+	var removed, images int
+	for _, d := range ai.index.Manifests {
+		if attestationSubject(d) == "" && d.Platform != nil {
+			if platforms.Any(ps...).Match(*d.Platform) {
+				delete(ai.attDescs, d.Digest)
+				removed++
+				continue
+			}
+			images++
+		}
+		manifests = append(manifests, d)
+	}
+	if removed == 0 {
+		return nil, errors.Errorf("no image found in %s for the given platforms", in)
+	}
+	if images == 0 {
+		return nil, errors.Errorf("removing all the images of %s is not allowed, delete the index instead", in)
+	}
+	ai.index.Manifests = manifests
+
+	u := &IndexUpdate{
+		Source: &Source{Ref: ai.ref, Desc: ai.desc},
+	}
+	if err := ai.update(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
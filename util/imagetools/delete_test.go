@@ -0,0 +1,108 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containerd/platforms"
+	"github.com/docker/buildx/util/resolver"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemovePlatforms(t *testing.T) {
+	ctx := context.Background()
+	r := New(Opt{})
+
+	ref := "oci-layout://" + t.TempDir() + ":v1"
+	writeTestIndex(t, r, ref, "linux/amd64", "linux/arm64", "linux/riscv64")
+
+	stmt, err := json.Marshal(intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			PredicateType: "https://openvex.dev/ns/v0.2.0",
+		},
+		Predicate: map[string]interface{}{},
+	})
+	require.NoError(t, err)
+	u, err := r.AddAttestation(ctx, ref, stmt, nil)
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	u, err = r.RemovePlatforms(ctx, ref, []ocispec.Platform{platforms.MustParse("linux/arm64"), platforms.MustParse("linux/riscv64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	var idx ocispec.Index
+	require.NoError(t, json.Unmarshal(u.Data, &idx))
+	require.Len(t, idx.Manifests, 2)
+	require.Equal(t, "linux/amd64", platforms.Format(*idx.Manifests[0].Platform))
+	require.Equal(t, idx.Manifests[0].Digest.String(), idx.Manifests[1].Annotations[annotationReferenceDigest])
+
+	atts, err := r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+	require.Equal(t, "linux/amd64", atts[0].Platform)
+
+	_, err = r.RemovePlatforms(ctx, ref, []ocispec.Platform{platforms.MustParse("linux/arm64")})
+	require.ErrorContains(t, err, "no image found")
+	_, err = r.RemovePlatforms(ctx, ref, []ocispec.Platform{platforms.MustParse("linux/amd64")})
+	require.ErrorContains(t, err, "not allowed")
+
+	_, err = r.PlanDelete(ctx, ref)
+	require.ErrorContains(t, err, "OCI layout")
+}
+
+func TestDelete(t *testing.T) {
+	idx := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`)
+	dgst := digest.FromBytes(idx)
+
+	var mu sync.Mutex
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, path.Base(req.URL.Path))
+			mu.Unlock()
+			w.WriteHeader(http.StatusAccepted)
+		case req.URL.Path == "/v2/test/app/manifests/latest", req.URL.Path == "/v2/test/app/manifests/"+dgst.String():
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(idx)))
+			if req.Method == http.MethodGet {
+				_, _ = w.Write(idx)
+			}
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	plainHTTP := true
+	r := New(Opt{
+		RegistryConfig: map[string]resolver.RegistryConfig{
+			host: {PlainHTTP: &plainHTTP},
+		},
+	})
+
+	ctx := context.Background()
+	d, err := r.PlanDelete(ctx, host+"/test/app:latest")
+	require.NoError(t, err)
+	require.Equal(t, dgst, d.Desc.Digest)
+	require.NoError(t, r.Delete(ctx, d))
+
+	// attestation manifests possibly shared with other indexes are kept
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{dgst.String()}, deleted)
+}
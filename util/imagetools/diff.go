@@ -0,0 +1,316 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Diff describes the differences between two images or indexes.
+type Diff struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	AddedPlatforms   []string    `json:"addedPlatforms,omitempty"`
+	RemovedPlatforms []string    `json:"removedPlatforms,omitempty"`
+	Images           []ImageDiff `json:"images,omitempty"`
+}
+
+// ImageDiff describes the differences between the images of a platform that
+// is available in both the old and the new reference.
+type ImageDiff struct {
+	Platform  string        `json:"platform"`
+	OldDigest digest.Digest `json:"oldDigest"`
+	NewDigest digest.Digest `json:"newDigest"`
+
+	AddedLayers   []ocispec.Descriptor `json:"addedLayers,omitempty"`
+	RemovedLayers []ocispec.Descriptor `json:"removedLayers,omitempty"`
+	Config        []Change             `json:"config,omitempty"`
+	Packages      []Change             `json:"packages,omitempty"`
+}
+
+// Change is a value that has been added, removed or modified. Old is nil
+// for added values and New is nil for removed ones, so that they can be told
+// apart from values set to an empty string, like ENV FOO=.
+type Change struct {
+	Name string  `json:"name"`
+	Old  *string `json:"old,omitempty"`
+	New  *string `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("+ %s: %s", c.Name, changeValue(*c.New))
+	case c.New == nil:
+		return fmt.Sprintf("- %s: %s", c.Name, changeValue(*c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Name, changeValue(*c.Old), changeValue(*c.New))
+	}
+}
+
+// changeValue quotes empty values so they remain visible.
+func changeValue(v string) string {
+	if v == "" {
+		return `""`
+	}
+	return v
+}
+
+// Diff compares the images referenced by oldRef and newRef: their platforms
+// and, for each platform in common, their layers, config and SBOM packages.
+func (r *Resolver) Diff(ctx context.Context, oldRef, newRef string) (*Diff, error) {
+	var oldRes, newRes *result
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		var err error
+		oldRes, err = newLoader(r.resolver()).Load(ctx, oldRef)
+		return errors.Wrapf(err, "failed to load %s", oldRef)
+	})
+	eg.Go(func() error {
+		var err error
+		newRes, err = newLoader(r.resolver()).Load(ctx, newRef)
+		return errors.Wrapf(err, "failed to load %s", newRef)
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	d, err := diffResults(oldRes, newRes)
+	if err != nil {
+		return nil, err
+	}
+	d.Old = oldRef
+	d.New = newRef
+	return d, nil
+}
+
+func diffResults(oldRes, newRes *result) (*Diff, error) {
+	d := &Diff{}
+	for _, p := range newRes.platforms {
+		if _, ok := oldRes.images[p]; !ok {
+			d.AddedPlatforms = append(d.AddedPlatforms, p)
+		}
+	}
+	for _, p := range oldRes.platforms {
+		if _, ok := newRes.images[p]; !ok {
+			d.RemovedPlatforms = append(d.RemovedPlatforms, p)
+		}
+	}
+
+	oldSBOM, err := oldRes.SBOM()
+	if err != nil {
+		return nil, err
+	}
+	newSBOM, err := newRes.SBOM()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range oldRes.platforms {
+		newDgst, ok := newRes.images[p]
+		if !ok {
+			continue
+		}
+		oldDgst := oldRes.images[p]
+		if oldDgst == newDgst {
+			continue
+		}
+		id := ImageDiff{
+			Platform:  p,
+			OldDigest: oldDgst,
+			NewDigest: newDgst,
+		}
+		id.AddedLayers, id.RemovedLayers = diffLayers(oldRes.manifests[oldDgst].manifest.Layers, newRes.manifests[newDgst].manifest.Layers)
+		id.Config = diffConfig(oldRes.assets[p].config, newRes.assets[p].config)
+		id.Packages = diffChanges(sbomPackages(oldSBOM[p]), sbomPackages(newSBOM[p]))
+		d.Images = append(d.Images, id)
+	}
+	return d, nil
+}
+
+func diffLayers(oldLayers, newLayers []ocispec.Descriptor) (added, removed []ocispec.Descriptor) {
+	oldSet := make(map[digest.Digest]struct{}, len(oldLayers))
+	for _, l := range oldLayers {
+		oldSet[l.Digest] = struct{}{}
+	}
+	newSet := make(map[digest.Digest]struct{}, len(newLayers))
+	for _, l := range newLayers {
+		newSet[l.Digest] = struct{}{}
+		if _, ok := oldSet[l.Digest]; !ok {
+			added = append(added, l)
+		}
+	}
+	for _, l := range oldLayers {
+		if _, ok := newSet[l.Digest]; !ok {
+			removed = append(removed, l)
+		}
+	}
+	return added, removed
+}
+
+func diffConfig(oldImg, newImg *ocispec.Image) []Change {
+	return diffChanges(configFields(oldImg), configFields(newImg))
+}
+
+// configFields flattens the fields of an image config that are relevant to
+// compare images into a map of names and values.
+func configFields(img *ocispec.Image) map[string]string {
+	m := make(map[string]string)
+	if img == nil {
+		return m
+	}
+	set := func(k, v string) {
+		if v != "" {
+			m[k] = v
+		}
+	}
+	setList := func(k string, v []string) {
+		if len(v) > 0 {
+			dt, _ := json.Marshal(v)
+			m[k] = string(dt)
+		}
+	}
+	setKeys := func(k string, v map[string]struct{}) {
+		for kk := range v {
+			m[k+"."+kk] = kk
+		}
+	}
+	c := img.Config
+	set("User", c.User)
+	set("WorkingDir", c.WorkingDir)
+	set("StopSignal", c.StopSignal)
+	setList("Entrypoint", c.Entrypoint)
+	setList("Cmd", c.Cmd)
+	for _, e := range c.Env {
+		k, v, _ := strings.Cut(e, "=")
+		m["Env."+k] = v
+	}
+	for k, v := range c.Labels {
+		m["Labels."+k] = v
+	}
+	setKeys("ExposedPorts", c.ExposedPorts)
+	setKeys("Volumes", c.Volumes)
+	return m
+}
+
+// sbomPackages returns the versions of the packages listed in the SPDX
+// documents of an SBOM by package name.
+func sbomPackages(sbom sbomStub) map[string]string {
+	versions := make(map[string][]string)
+	for _, p := range sbomPackageList("", sbom) {
+		versions[p.Name] = append(versions[p.Name], p.Version)
+	}
+	m := make(map[string]string, len(versions))
+	for name, vv := range versions {
+		sort.Strings(vv)
+		m[name] = strings.Join(dedupe(vv), ", ")
+		if m[name] == "" {
+			m[name] = "(no version)"
+		}
+	}
+	return m
+}
+
+func diffChanges(oldValues, newValues map[string]string) []Change {
+	var changes []Change
+	for k, v := range newValues {
+		if ov, ok := oldValues[k]; !ok {
+			changes = append(changes, Change{Name: k, New: &v})
+		} else if ov != v {
+			changes = append(changes, Change{Name: k, Old: &ov, New: &v})
+		}
+	}
+	for k, v := range oldValues {
+		if _, ok := newValues[k]; !ok {
+			changes = append(changes, Change{Name: k, Old: &v})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func dedupe(s []string) []string {
+	var res []string
+	for i, v := range s {
+		if i > 0 && s[i-1] == v {
+			continue
+		}
+		if v == "" {
+			continue
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+// Print writes the diff to out, as text or as JSON if format is "json".
+func (d *Diff) Print(format string, out io.Writer) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case "", "text":
+	default:
+		return errors.Errorf("unsupported format %q", format)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Old:\t%s\n", d.Old)
+	_, _ = fmt.Fprintf(w, "New:\t%s\n", d.New)
+	_ = w.Flush()
+
+	if len(d.AddedPlatforms) == 0 && len(d.RemovedPlatforms) == 0 && len(d.Images) == 0 {
+		_, _ = fmt.Fprintf(out, "\nNo differences\n")
+		return nil
+	}
+
+	if len(d.AddedPlatforms) > 0 || len(d.RemovedPlatforms) > 0 {
+		_, _ = fmt.Fprintf(out, "\nPlatforms:\n")
+		for _, p := range d.AddedPlatforms {
+			_, _ = fmt.Fprintf(out, "%s+ %s\n", defaultPfx, p)
+		}
+		for _, p := range d.RemovedPlatforms {
+			_, _ = fmt.Fprintf(out, "%s- %s\n", defaultPfx, p)
+		}
+	}
+
+	for _, id := range d.Images {
+		_, _ = fmt.Fprintf(out, "\n%s:\n", id.Platform)
+		_, _ = fmt.Fprintf(out, "%sDigest: %s -> %s\n", defaultPfx, id.OldDigest, id.NewDigest)
+		if len(id.AddedLayers) > 0 || len(id.RemovedLayers) > 0 {
+			_, _ = fmt.Fprintf(out, "%sLayers:\n", defaultPfx)
+			for _, l := range id.RemovedLayers {
+				_, _ = fmt.Fprintf(out, "%s- %s (%d bytes)\n", defaultPfx+defaultPfx, l.Digest, l.Size)
+			}
+			for _, l := range id.AddedLayers {
+				_, _ = fmt.Fprintf(out, "%s+ %s (%d bytes)\n", defaultPfx+defaultPfx, l.Digest, l.Size)
+			}
+		}
+		if len(id.Config) > 0 {
+			_, _ = fmt.Fprintf(out, "%sConfig:\n", defaultPfx)
+			for _, c := range id.Config {
+				_, _ = fmt.Fprintf(out, "%s%s\n", defaultPfx+defaultPfx, c)
+			}
+		}
+		if len(id.Packages) > 0 {
+			_, _ = fmt.Fprintf(out, "%sPackages:\n", defaultPfx)
+			for _, c := range id.Packages {
+				_, _ = fmt.Fprintf(out, "%s%s\n", defaultPfx+defaultPfx, c)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package imagetools
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLayers(t *testing.T) {
+	l1 := ocispec.Descriptor{Digest: digest.FromString("l1")}
+	l2 := ocispec.Descriptor{Digest: digest.FromString("l2")}
+	l3 := ocispec.Descriptor{Digest: digest.FromString("l3")}
+
+	added, removed := diffLayers([]ocispec.Descriptor{l1, l2}, []ocispec.Descriptor{l1, l3})
+	require.Equal(t, []ocispec.Descriptor{l3}, added)
+	require.Equal(t, []ocispec.Descriptor{l2}, removed)
+
+	added, removed = diffLayers([]ocispec.Descriptor{l1}, []ocispec.Descriptor{l1})
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestDiffConfig(t *testing.T) {
+	oldImg := &ocispec.Image{
+		Config: ocispec.ImageConfig{
+			Env:        []string{"PATH=/usr/bin", "FOO=bar"},
+			Entrypoint: []string{"/app"},
+			Labels: map[string]string{
+				"version": "1.4.0",
+			},
+		},
+	}
+	newImg := &ocispec.Image{
+		Config: ocispec.ImageConfig{
+			Env:        []string{"PATH=/usr/local/bin:/usr/bin", "BAR="},
+			Entrypoint: []string{"/app"},
+			User:       "nobody",
+			Labels: map[string]string{
+				"version": "1.4.1",
+			},
+		},
+	}
+
+	require.Equal(t, []Change{
+		{Name: "Env.BAR", New: ptrstr("")},
+		{Name: "Env.FOO", Old: ptrstr("bar")},
+		{Name: "Env.PATH", Old: ptrstr("/usr/bin"), New: ptrstr("/usr/local/bin:/usr/bin")},
+		{Name: "Labels.version", Old: ptrstr("1.4.0"), New: ptrstr("1.4.1")},
+		{Name: "User", New: ptrstr("nobody")},
+	}, diffConfig(oldImg, newImg))
+	require.Empty(t, diffConfig(oldImg, oldImg))
+}
+
+func TestChangeString(t *testing.T) {
+	require.Equal(t, `+ Env.FOO: ""`, Change{Name: "Env.FOO", New: ptrstr("")}.String())
+	require.Equal(t, "- Env.FOO: bar", Change{Name: "Env.FOO", Old: ptrstr("bar")}.String())
+	require.Equal(t, `~ Env.FOO: "" -> bar`, Change{Name: "Env.FOO", Old: ptrstr(""), New: ptrstr("bar")}.String())
+	require.Equal(t, `~ Env.FOO: bar -> ""`, Change{Name: "Env.FOO", Old: ptrstr("bar"), New: ptrstr("")}.String())
+}
+
+func TestSBOMPackages(t *testing.T) {
+	spdx := func(pkgs ...map[string]interface{}) interface{} {
+		var pp []interface{}
+		for _, p := range pkgs {
+			pp = append(pp, p)
+		}
+		return map[string]interface{}{"packages": pp}
+	}
+	oldSBOM := sbomStub{
+		SPDX: spdx(
+			map[string]interface{}{"name": "musl", "versionInfo": "1.2.4-r2"},
+			map[string]interface{}{"name": "zlib", "versionInfo": "1.3-r0"},
+		),
+	}
+	newSBOM := sbomStub{
+		SPDX: spdx(
+			map[string]interface{}{"name": "musl", "versionInfo": "1.2.4-r3"},
+		),
+		AdditionalSPDXs: []interface{}{
+			spdx(map[string]interface{}{"name": "curl", "versionInfo": "8.5.0-r0"}),
+		},
+	}
+
+	require.Equal(t, []Change{
+		{Name: "curl", New: ptrstr("8.5.0-r0")},
+		{Name: "musl", Old: ptrstr("1.2.4-r2"), New: ptrstr("1.2.4-r3")},
+		{Name: "zlib", Old: ptrstr("1.3-r0")},
+	}, diffChanges(sbomPackages(oldSBOM), sbomPackages(newSBOM)))
+}
+
+func TestDiffPrint(t *testing.T) {
+	d := &Diff{
+		Old:              "app:1.4.0",
+		New:              "app:1.4.1",
+		AddedPlatforms:   []string{"linux/riscv64"},
+		RemovedPlatforms: []string{"linux/386"},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, d.Print("", buf))
+	require.Contains(t, buf.String(), "+ linux/riscv64")
+	require.Contains(t, buf.String(), "- linux/386")
+
+	buf.Reset()
+	require.NoError(t, d.Print("json", buf))
+	require.Contains(t, buf.String(), `"addedPlatforms": [`)
+
+	require.Error(t, d.Print("yaml", buf))
+}
+
+func ptrstr(s string) *string {
+	return &s
+}
@@ -44,6 +44,10 @@ func New(opt Opt) *Resolver {
 }
 
 func (r *Resolver) resolver() remotes.Resolver {
+	return &ociLayoutResolver{Resolver: r.registryResolver()}
+}
+
+func (r *Resolver) registryResolver() remotes.Resolver {
 	return docker.NewResolver(docker.ResolverOptions{
 		Hosts: func(domain string) ([]docker.RegistryHost, error) {
 			res, err := r.hosts(domain)
@@ -114,7 +118,7 @@ func (r *Resolver) GetDescriptor(ctx context.Context, in string, desc ocispec.De
 }
 
 func parseRef(s string) (reference.Named, error) {
-	ref, err := reference.ParseNormalizedNamed(s)
+	ref, err := ParseRef(s)
 	if err != nil {
 		return nil, err
 	}
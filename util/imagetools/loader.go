@@ -303,7 +303,7 @@ func (l *loader) scanSBOM(ctx context.Context, fetcher remotes.Fetcher, r *resul
 						return nil, err
 					}
 
-					dt, err = decodeDSSE(dt, layer.MediaType)
+					dt, _, err = decodeDSSE(dt, layer.MediaType)
 					if err != nil {
 						return nil, err
 					}
@@ -354,7 +354,7 @@ func (l *loader) scanProvenance(ctx context.Context, fetcher remotes.Fetcher, r
 						return nil, err
 					}
 
-					dt, err = decodeDSSE(dt, layer.MediaType)
+					dt, _, err = decodeDSSE(dt, layer.MediaType)
 					if err != nil {
 						return nil, err
 					}
@@ -445,24 +445,28 @@ func isInTotoDSSE(mime string) bool {
 	return isDSSE
 }
 
-func decodeDSSE(dt []byte, mime string) ([]byte, error) {
-	if isInTotoDSSE(mime) {
-		var dsse struct {
-			Payload string `json:"payload"`
-		}
-		if err := json.Unmarshal(dt, &dsse); err != nil {
-			return nil, err
-		}
-
-		decoded, err := base64.StdEncoding.DecodeString(dsse.Payload)
-		if err != nil {
-			return nil, err
-		}
+// dsseEnvelope is a DSSE envelope, as stored in the layers of the in-toto
+// DSSE media types.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
 
-		dt = decoded
+// decodeDSSE returns the payload of a DSSE envelope along with the envelope
+// if the media type is a DSSE one, and the data as it is otherwise.
+func decodeDSSE(dt []byte, mime string) ([]byte, *dsseEnvelope, error) {
+	if !isInTotoDSSE(mime) {
+		return dt, nil, nil
 	}
-
-	return dt, nil
+	var env dsseEnvelope
+	if err := json.Unmarshal(dt, &env); err != nil {
+		return nil, nil, err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decoded, &env, nil
 }
 
 func withIntotoMediaTypes(ctx context.Context) context.Context {
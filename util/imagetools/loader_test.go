@@ -174,20 +174,22 @@ func Test_isInTotoDSSE(t *testing.T) {
 
 func Test_decodeDSSE(t *testing.T) {
 	// Returns input when mime isn't a DSSE type
-	actual, err := decodeDSSE([]byte("foobar"), "application/vnd.in-toto+json")
+	actual, env, err := decodeDSSE([]byte("foobar"), "application/vnd.in-toto+json")
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("foobar"), actual)
+	assert.Nil(t, env)
 
-	// Returns the base64 decoded payload if is a DSSE
+	// Returns the base64 decoded payload and the envelope if is a DSSE
 	payload := base64.StdEncoding.EncodeToString([]byte("hello world"))
-	envelope := fmt.Sprintf("{\"payload\":\"%s\"}", payload)
-	actual, err = decodeDSSE([]byte(envelope), "application/vnd.in-toto.spdx+dsse")
+	envelope := fmt.Sprintf("{\"payloadType\":\"application/vnd.in-toto+json\",\"payload\":\"%s\"}", payload)
+	actual, env, err = decodeDSSE([]byte(envelope), "application/vnd.in-toto.spdx+dsse")
 	assert.NoError(t, err)
 	assert.Equal(t, "hello world", string(actual))
+	assert.Equal(t, &dsseEnvelope{PayloadType: "application/vnd.in-toto+json", Payload: payload}, env)
 
-	_, err = decodeDSSE([]byte("not a json"), "application/vnd.in-toto.spdx+dsse")
+	_, _, err = decodeDSSE([]byte("not a json"), "application/vnd.in-toto.spdx+dsse")
 	assert.Error(t, err)
 
-	_, err = decodeDSSE([]byte("{\"payload\": \"not base64\"}"), "application/vnd.in-toto.spdx+dsse")
+	_, _, err = decodeDSSE([]byte("{\"payload\": \"not base64\"}"), "application/vnd.in-toto.spdx+dsse")
 	assert.Error(t, err)
 }
@@ -0,0 +1,106 @@
+package imagetools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRef(t *testing.T) {
+	dgst := digest.FromString("foo")
+	tests := []struct {
+		in       string
+		expected string
+		layout   bool
+		err      bool
+	}{
+		{in: "alpine", expected: "docker.io/library/alpine"},
+		{in: "oci-layout:///tmp/layout", expected: "oci-layout:///tmp/layout", layout: true},
+		{in: "oci-layout://layout:v1", expected: "oci-layout://layout:v1", layout: true},
+		{in: "oci-layout://./out/layout:v1@" + dgst.String(), expected: "oci-layout://./out/layout:v1@" + dgst.String(), layout: true},
+		{in: "oci-layout://localhost:5000/layout", expected: "oci-layout://localhost:5000/layout", layout: true},
+		{in: "oci-layout://", err: true},
+		{in: "oci-layout://layout@sha256:foo", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			ref, err := ParseRef(tt.in)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ref.String())
+			require.Equal(t, tt.layout, IsOCILayout(ref))
+		})
+	}
+}
+
+func TestOCILayout(t *testing.T) {
+	ctx := context.Background()
+	src := t.TempDir()
+	dest := t.TempDir()
+
+	store, err := local.NewStore(src)
+	require.NoError(t, err)
+
+	configDt, err := json.Marshal(ocispec.Image{
+		Platform: ocispec.Platform{OS: "linux", Architecture: "arm64"},
+	})
+	require.NoError(t, err)
+	configDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageConfig,
+		Digest:    digest.FromBytes(configDt),
+		Size:      int64(len(configDt)),
+	}
+	require.NoError(t, content.WriteBlob(ctx, store, configDesc.Digest.String(), bytes.NewReader(configDt), configDesc))
+
+	mfstDt, err := json.Marshal(ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []ocispec.Descriptor{},
+	})
+	require.NoError(t, err)
+	mfstDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(mfstDt),
+		Size:      int64(len(mfstDt)),
+	}
+
+	r := New(Opt{})
+
+	srcRef, err := ParseRef("oci-layout://" + src + ":v1")
+	require.NoError(t, err)
+	require.NoError(t, r.Push(ctx, srcRef, mfstDesc, mfstDt))
+
+	_, desc, err := r.Resolve(ctx, srcRef.String())
+	require.NoError(t, err)
+	require.Equal(t, mfstDesc, desc)
+
+	dt, _, err := r.Get(ctx, "oci-layout://"+src+"@"+mfstDesc.Digest.String())
+	require.NoError(t, err)
+	require.Equal(t, mfstDt, dt)
+
+	res, err := newLoader(r.resolver()).Load(ctx, srcRef.String())
+	require.NoError(t, err)
+	require.Equal(t, []string{"linux/arm64"}, res.platforms)
+
+	destRef, err := ParseRef("oci-layout://" + dest + ":v2")
+	require.NoError(t, err)
+	require.NoError(t, r.Copy(ctx, &Source{Ref: srcRef, Desc: desc}, destRef))
+	require.NoError(t, r.Push(ctx, destRef, mfstDesc, mfstDt))
+
+	_, desc, err = r.Resolve(ctx, destRef.String())
+	require.NoError(t, err)
+	require.Equal(t, mfstDesc, desc)
+
+	_, _, err = r.Resolve(ctx, "oci-layout://"+t.TempDir()+":v1")
+	require.Error(t, err)
+}
@@ -162,6 +162,26 @@ func (p *Printer) Print(raw bool, out io.Writer) error {
 	return nil
 }
 
+// PrintTree writes the tree of the descriptors of the image, with their
+// sizes. The format of the printer is either empty for text output or json.
+func (p *Printer) PrintTree(out io.Writer) error {
+	t, err := p.resolver.Tree(p.ctx, p.name)
+	if err != nil {
+		return err
+	}
+	return t.Print(p.format, out)
+}
+
+// PrintSBOM writes the packages listed in the SBOM attestations of the image
+// that match the filter, or exports the SBOM in SPDX or CycloneDX format.
+func (p *Printer) PrintSBOM(format string, f PackageFilter, out io.Writer) error {
+	res, err := newLoader(p.resolver.resolver()).Load(p.ctx, p.name)
+	if err != nil {
+		return err
+	}
+	return printSBOM(res, p.ref.String(), format, f, out)
+}
+
 func (p *Printer) printManifestList(out io.Writer) error {
 	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
 	_, _ = fmt.Fprintf(w, "\t\n")
@@ -0,0 +1,236 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/moby/buildkit/client/ociindex"
+	"github.com/moby/buildkit/util/tracing"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// registryRequest sends a request to the registry API of the repository of
+// ref. p is the path relative to the repository, e.g. /manifests/latest,
+// and actions the comma-separated actions of the repository scope used to
+// authorize the request.
+func (r *Resolver) registryRequest(ctx context.Context, ref reference.Named, method, p string, header http.Header, actions string) (*http.Response, error) {
+	if IsOCILayout(ref) {
+		return nil, errors.Errorf("%s is not a registry reference", ref)
+	}
+	hosts, err := r.hosts(reference.Domain(ref))
+	if err != nil {
+		return nil, err
+	}
+	repo := reference.Path(ref)
+	ctx = docker.WithScope(ctx, "repository:"+repo+":"+actions)
+
+	// mirrors can't be used for the registry API, only the hosts of the
+	// registry itself have the push capability
+	var lastErr error
+	for _, h := range hosts {
+		if !h.Capabilities.Has(docker.HostCapabilityPush) {
+			continue
+		}
+		resp, err := r.doRegistryRequest(ctx, h, method, h.Scheme+"://"+h.Host+h.Path+"/"+repo+p, header)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return resp, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.Wrapf(errdefs.ErrNotFound, "no registry host for %s", ref)
+	}
+	return nil, lastErr
+}
+
+func (r *Resolver) doRegistryRequest(ctx context.Context, h docker.RegistryHost, method, u string, header http.Header) (*http.Response, error) {
+	client := h.Client
+	if client == nil {
+		client = tracing.DefaultClient
+	}
+	for i := 0; ; i++ {
+		req, err := http.NewRequestWithContext(ctx, method, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h.Header {
+			req.Header[k] = append(req.Header[k], v...)
+		}
+		for k, v := range header {
+			req.Header[k] = append(req.Header[k], v...)
+		}
+		if err := r.auth.Authorize(ctx, req); err != nil {
+			return nil, errors.Wrap(err, "failed to authorize request")
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || i > 0 {
+			return resp, nil
+		}
+		err = r.auth.AddResponses(ctx, []*http.Response{resp})
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to authorize request")
+		}
+	}
+}
+
+// Referrers returns the descriptors of the manifests whose subject is the
+// manifest with the given digest. The referrers API of the registry is used,
+// with a fallback to the referrers tag schema only if the registry reports
+// the API as unsupported.
+func (r *Resolver) Referrers(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]ocispec.Descriptor, error) {
+	tag := dgst.Algorithm().String() + "-" + dgst.Encoded()
+
+	if IsOCILayout(ref) {
+		p, _, _, err := parseOCILayoutRef(ref.String())
+		if err != nil {
+			return nil, err
+		}
+		// don't fall back to the single manifest of the layout like Resolve
+		desc, err := ociindex.NewStoreIndex(p).Get(tag)
+		if err != nil || desc == nil {
+			return nil, err
+		}
+		dt, err := r.GetDescriptor(ctx, ref.String(), *desc)
+		if err != nil {
+			return nil, err
+		}
+		return decodeReferrers(dt)
+	}
+
+	dt, err := r.referrersAPI(ctx, ref, dgst)
+	if err == nil {
+		return decodeReferrers(dt)
+	} else if !errors.Is(err, errdefs.ErrNotImplemented) {
+		return nil, err
+	}
+
+	tagged, err := reference.WithTag(reference.TrimNamed(ref), tag)
+	if err != nil {
+		return nil, err
+	}
+	dt, _, err = r.Get(ctx, tagged.String())
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decodeReferrers(dt)
+}
+
+// referrersAPI returns the index listing the referrers of the manifest with
+// the given digest from the referrers API of the registry. A registry without
+// the API answers with 404 Not Found, reported as errdefs.ErrNotImplemented.
+// Any other failure is returned as is, as falling back to the referrers tag
+// schema would hide the referrers known to the registry.
+func (r *Resolver) referrersAPI(ctx context.Context, ref reference.Named, dgst digest.Digest) ([]byte, error) {
+	resp, err := r.registryRequest(ctx, ref, http.MethodGet, "/referrers/"+dgst.String(), http.Header{
+		"Accept": []string{ocispec.MediaTypeImageIndex},
+	}, "pull")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	case http.StatusNotFound:
+		return nil, errors.Wrapf(errdefs.ErrNotImplemented, "referrers API not supported by %s", reference.Domain(ref))
+	default:
+		return nil, errors.Errorf("unexpected status fetching referrers of %s: %s", dgst, resp.Status)
+	}
+}
+
+func decodeReferrers(dt []byte) ([]ocispec.Descriptor, error) {
+	var idx ocispec.Index
+	if err := json.Unmarshal(dt, &idx); err != nil {
+		return nil, errors.Wrap(err, "failed to decode referrers")
+	}
+	return idx.Manifests, nil
+}
+
+// pushReferrer pushes a manifest with a subject in the repository of ref. On
+// registries reporting the referrers API as unsupported, and in OCI layouts, the manifest is
+// also added to the index tagged with the referrers tag schema so that
+// Referrers finds it.
+func (r *Resolver) pushReferrer(ctx context.Context, ref reference.Named, b Blob) error {
+	var mfst ocispec.Manifest
+	if err := json.Unmarshal(b.Data, &mfst); err != nil {
+		return errors.WithStack(err)
+	}
+	if mfst.Subject == nil {
+		return errors.Errorf("manifest %s has no subject", b.Desc.Digest)
+	}
+	named := reference.TrimNamed(ref)
+	dref, err := reference.WithDigest(named, b.Desc.Digest)
+	if err != nil {
+		return err
+	}
+	if err := r.Push(ctx, dref, b.Desc, b.Data); err != nil {
+		return err
+	}
+
+	subject := mfst.Subject.Digest
+	if !IsOCILayout(ref) {
+		if _, err := r.referrersAPI(ctx, ref, subject); err == nil {
+			// the registry tracks the referrers of the pushed manifests
+			return nil
+		} else if !errors.Is(err, errdefs.ErrNotImplemented) {
+			return err
+		}
+	}
+
+	tagged, err := reference.WithTag(named, subject.Algorithm().String()+"-"+subject.Encoded())
+	if err != nil {
+		return err
+	}
+	idx := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+	}
+	if dt, _, err := r.Get(ctx, tagged.String()); err == nil {
+		if err := json.Unmarshal(dt, &idx); err != nil {
+			return errors.Wrap(err, "failed to decode referrers")
+		}
+	} else if !errdefs.IsNotFound(err) {
+		return err
+	}
+	desc := ocispec.Descriptor{
+		MediaType:    b.Desc.MediaType,
+		Digest:       b.Desc.Digest,
+		Size:         b.Desc.Size,
+		ArtifactType: mfst.ArtifactType,
+		Annotations:  mfst.Annotations,
+	}
+	if desc.ArtifactType == "" {
+		desc.ArtifactType = mfst.Config.MediaType
+	}
+	for _, d := range idx.Manifests {
+		if d.Digest == desc.Digest {
+			return nil
+		}
+	}
+	idx.Manifests = append(idx.Manifests, desc)
+	dt, err := json.Marshal(idx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return r.Push(ctx, tagged, ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromBytes(dt),
+		Size:      int64(len(dt)),
+	}, dt)
+}
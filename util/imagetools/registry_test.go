@@ -0,0 +1,116 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/distribution/reference"
+	"github.com/docker/buildx/util/resolver"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferrers(t *testing.T) {
+	subject := digest.FromString("subject")
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: SignatureArtifactType,
+		Digest:       digest.FromString("referrer"),
+		Size:         8,
+	}
+	referrers, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{referrer},
+	})
+	require.NoError(t, err)
+	tag := "/v2/test/app/manifests/sha256-" + subject.Encoded()
+
+	tcs := []struct {
+		name     string
+		status   int
+		expected []ocispec.Descriptor
+		err      string
+		fallback bool
+	}{
+		{
+			name:     "api",
+			status:   http.StatusOK,
+			expected: []ocispec.Descriptor{referrer},
+		},
+		{
+			name:     "unsupported",
+			status:   http.StatusNotFound,
+			expected: []ocispec.Descriptor{referrer},
+			fallback: true,
+		},
+		{
+			name:   "forbidden",
+			status: http.StatusForbidden,
+			err:    "403 Forbidden",
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			err:    "500 Internal Server Error",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var tagged bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				switch {
+				case req.URL.Path == "/v2/test/app/referrers/"+subject.String():
+					w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+					w.WriteHeader(tc.status)
+					if tc.status == http.StatusOK {
+						_, _ = w.Write(referrers)
+					}
+				case req.URL.Path == tag, req.URL.Path == "/v2/test/app/manifests/"+digest.FromBytes(referrers).String():
+					mu.Lock()
+					tagged = true
+					mu.Unlock()
+					w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+					w.Header().Set("Docker-Content-Digest", digest.FromBytes(referrers).String())
+					w.Header().Set("Content-Length", strconv.Itoa(len(referrers)))
+					if req.Method == http.MethodGet {
+						_, _ = w.Write(referrers)
+					}
+				default:
+					http.NotFound(w, req)
+				}
+			}))
+			defer srv.Close()
+
+			host := strings.TrimPrefix(srv.URL, "http://")
+			plainHTTP := true
+			r := New(Opt{
+				RegistryConfig: map[string]resolver.RegistryConfig{
+					host: {PlainHTTP: &plainHTTP},
+				},
+			})
+			ref, err := reference.ParseNormalizedNamed(host + "/test/app:latest")
+			require.NoError(t, err)
+
+			descs, err := r.Referrers(context.Background(), ref, subject)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, descs)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			require.Equal(t, tc.fallback, tagged)
+		})
+	}
+}
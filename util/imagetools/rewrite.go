@@ -0,0 +1,336 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Rewrite is a change of the configs and manifests of the images of a new
+// manifest or index. Unset fields are left unchanged.
+type Rewrite struct {
+	// Labels are added to the labels of the config.
+	Labels map[string]string
+	// Env variables in KEY=VALUE form replace the variables with the same key.
+	Env []string
+	// User replaces the user of the config if set.
+	User *string
+	// Entrypoint replaces the entrypoint of the config if not nil. An empty
+	// entrypoint resets it.
+	Entrypoint []string
+	// Created sets the creation time of the config and the
+	// org.opencontainers.image.created annotation of OCI manifests.
+	Created *time.Time
+	// Annotations are manifest annotations, set on the manifests of the
+	// platform of the key or on all of them.
+	Annotations map[exptypes.AnnotationKey]string
+}
+
+// IsZero returns true if the rewrite doesn't change anything.
+func (rw *Rewrite) IsZero() bool {
+	return rw == nil || (len(rw.Labels) == 0 && len(rw.Env) == 0 && rw.User == nil && rw.Entrypoint == nil && rw.Created == nil && len(rw.Annotations) == 0)
+}
+
+// Rewrite applies rw to the image manifests of the manifest or index dt
+// combined from srcs. The new configs and manifests are returned as blobs
+// that must be pushed before the returned manifest or index. Attestation
+// manifests of the rewritten images are dropped from the index: the subject
+// of their in-toto statements is the digest of the original image, and
+// their provenance doesn't describe the rewritten one.
+func (r *Resolver) Rewrite(ctx context.Context, srcs []*Source, dt []byte, desc ocispec.Descriptor, rw *Rewrite) ([]byte, ocispec.Descriptor, []Blob, error) {
+	if len(srcs) == 0 {
+		return nil, ocispec.Descriptor{}, nil, errors.New("no sources to rewrite")
+	}
+
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		cfg, mfst, err := r.rewriteManifest(ctx, srcs[0].Ref, desc, dt, rw)
+		if err != nil {
+			return nil, ocispec.Descriptor{}, nil, err
+		}
+		return mfst.Data, mfst.Desc, []Blob{cfg}, nil
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+	default:
+		return nil, ocispec.Descriptor{}, nil, errors.Errorf("unsupported media type %q", desc.MediaType)
+	}
+
+	refs, err := r.manifestRefs(ctx, srcs)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, nil, err
+	}
+
+	var idx ocispec.Index
+	if err := json.Unmarshal(dt, &idx); err != nil {
+		return nil, ocispec.Descriptor{}, nil, errors.WithStack(err)
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	blobs := make([][]Blob, len(idx.Manifests))
+	for i, d := range idx.Manifests {
+		if attestationSubject(d) != "" {
+			continue
+		}
+		switch d.MediaType {
+		case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		default:
+			continue
+		}
+		ref, ok := refs[d.Digest]
+		if !ok {
+			return nil, ocispec.Descriptor{}, nil, errors.Errorf("no source found for manifest %s", d.Digest)
+		}
+		func(i int, ref reference.Named, d ocispec.Descriptor) {
+			eg.Go(func() error {
+				dt, err := r.GetDescriptor(ctx, ref.String(), d)
+				if err != nil {
+					return err
+				}
+				cfg, mfst, err := r.rewriteManifest(ctx, ref, d, dt, rw)
+				if err != nil {
+					return err
+				}
+				blobs[i] = []Blob{cfg, mfst}
+				return nil
+			})
+		}(i, ref, d)
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, ocispec.Descriptor{}, nil, err
+	}
+
+	var out []Blob
+	rewritten := make(map[digest.Digest]digest.Digest)
+	for i, bb := range blobs {
+		if bb == nil {
+			continue
+		}
+		mfst := bb[1].Desc
+		rewritten[idx.Manifests[i].Digest] = mfst.Digest
+		idx.Manifests[i].Digest = mfst.Digest
+		idx.Manifests[i].Size = mfst.Size
+		out = append(out, bb...)
+	}
+	manifests := idx.Manifests[:0]
+	for _, d := range idx.Manifests {
+		if _, ok := rewritten[attestationSubject(d)]; ok {
+			continue
+		}
+		manifests = append(manifests, d)
+	}
+	idx.Manifests = manifests
+
+	idxBytes, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return nil, ocispec.Descriptor{}, nil, errors.Wrap(err, "failed to marshal index")
+	}
+	return idxBytes, ocispec.Descriptor{
+		MediaType: desc.MediaType,
+		Size:      int64(len(idxBytes)),
+		Digest:    digest.FromBytes(idxBytes),
+	}, out, nil
+}
+
+// manifestRefs returns the references the manifests of the sources can be
+// fetched from.
+func (r *Resolver) manifestRefs(ctx context.Context, srcs []*Source) (map[digest.Digest]reference.Named, error) {
+	refs := make(map[digest.Digest]reference.Named)
+	for _, src := range srcs {
+		switch src.Desc.MediaType {
+		case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+			dt, err := r.GetDescriptor(ctx, src.Ref.String(), src.Desc)
+			if err != nil {
+				return nil, err
+			}
+			var idx ocispec.Index
+			if err := json.Unmarshal(dt, &idx); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			for _, d := range idx.Manifests {
+				refs[d.Digest] = src.Ref
+			}
+		default:
+			refs[src.Desc.Digest] = src.Ref
+		}
+	}
+	return refs, nil
+}
+
+// rewriteManifest returns the rewritten config and manifest of the image
+// manifest dt. Unknown fields of the manifest and config are preserved.
+func (r *Resolver) rewriteManifest(ctx context.Context, ref reference.Named, desc ocispec.Descriptor, dt []byte, rw *Rewrite) (Blob, Blob, error) {
+	var mfst map[string]json.RawMessage
+	if err := json.Unmarshal(dt, &mfst); err != nil {
+		return Blob{}, Blob{}, errors.WithStack(err)
+	}
+	var cfgDesc ocispec.Descriptor
+	if err := json.Unmarshal(mfst["config"], &cfgDesc); err != nil {
+		return Blob{}, Blob{}, errors.Wrapf(err, "failed to decode config of %s", desc.Digest)
+	}
+	cfgDt, err := r.GetDescriptor(ctx, ref.String(), cfgDesc)
+	if err != nil {
+		return Blob{}, Blob{}, err
+	}
+	cfgDt, err = rw.config(cfgDt)
+	if err != nil {
+		return Blob{}, Blob{}, errors.Wrapf(err, "failed to rewrite config of %s", desc.Digest)
+	}
+	cfgDesc.Digest = digest.FromBytes(cfgDt)
+	cfgDesc.Size = int64(len(cfgDt))
+	if err := setJSON(mfst, "config", cfgDesc); err != nil {
+		return Blob{}, Blob{}, err
+	}
+
+	// a single manifest has no platform in its descriptor, the one of its
+	// config is used to match the platform-scoped annotations
+	platform := desc.Platform
+	if platform == nil {
+		var img ocispec.Image
+		if err := json.Unmarshal(cfgDt, &img); err != nil {
+			return Blob{}, Blob{}, errors.Wrapf(err, "failed to decode config of %s", desc.Digest)
+		}
+		if img.OS != "" {
+			platform = &img.Platform
+		}
+	}
+	ann := make(map[string]string)
+	for k, v := range rw.Annotations {
+		if k.Platform == nil || (platform != nil && k.PlatformString() == platforms.Format(*platform)) {
+			ann[k.Key] = v
+		}
+	}
+	if len(ann) > 0 && desc.MediaType != ocispec.MediaTypeImageManifest {
+		return Blob{}, Blob{}, errors.Errorf("manifest annotations are only supported on OCI manifests, %s is %s", desc.Digest, desc.MediaType)
+	}
+	if rw.Created != nil && desc.MediaType == ocispec.MediaTypeImageManifest {
+		ann[ocispec.AnnotationCreated] = rw.Created.UTC().Format(time.RFC3339)
+	}
+	if len(ann) > 0 {
+		var existing map[string]string
+		if raw, ok := mfst["annotations"]; ok {
+			if err := json.Unmarshal(raw, &existing); err != nil {
+				return Blob{}, Blob{}, errors.WithStack(err)
+			}
+		}
+		if existing == nil {
+			existing = make(map[string]string)
+		}
+		maps.Copy(existing, ann)
+		if err := setJSON(mfst, "annotations", existing); err != nil {
+			return Blob{}, Blob{}, err
+		}
+	}
+
+	mfstDt, err := json.MarshalIndent(mfst, "", "  ")
+	if err != nil {
+		return Blob{}, Blob{}, errors.Wrap(err, "failed to marshal manifest")
+	}
+	mfstDesc := desc
+	mfstDesc.Digest = digest.FromBytes(mfstDt)
+	mfstDesc.Size = int64(len(mfstDt))
+	return Blob{Desc: cfgDesc, Data: cfgDt}, Blob{Desc: mfstDesc, Data: mfstDt}, nil
+}
+
+// config returns the image config dt with the changes of rw.
+func (rw *Rewrite) config(dt []byte) ([]byte, error) {
+	var img map[string]json.RawMessage
+	if err := json.Unmarshal(dt, &img); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cfg := make(map[string]json.RawMessage)
+	if raw, ok := img["config"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	if len(rw.Labels) > 0 {
+		var labels map[string]string
+		if raw, ok := cfg["Labels"]; ok {
+			if err := json.Unmarshal(raw, &labels); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		maps.Copy(labels, rw.Labels)
+		if err := setJSON(cfg, "Labels", labels); err != nil {
+			return nil, err
+		}
+	}
+	if len(rw.Env) > 0 {
+		var env []string
+		if raw, ok := cfg["Env"]; ok {
+			if err := json.Unmarshal(raw, &env); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		if err := setJSON(cfg, "Env", mergeEnv(env, rw.Env)); err != nil {
+			return nil, err
+		}
+	}
+	if rw.User != nil {
+		if err := setJSON(cfg, "User", *rw.User); err != nil {
+			return nil, err
+		}
+	}
+	if rw.Entrypoint != nil {
+		var entrypoint []string
+		if len(rw.Entrypoint) > 0 {
+			entrypoint = rw.Entrypoint
+		}
+		if err := setJSON(cfg, "Entrypoint", entrypoint); err != nil {
+			return nil, err
+		}
+	}
+	if err := setJSON(img, "config", cfg); err != nil {
+		return nil, err
+	}
+	if rw.Created != nil {
+		if err := setJSON(img, "created", rw.Created.UTC()); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(img)
+}
+
+// mergeEnv replaces the variables of env with the ones of the same key in
+// overrides and appends the others.
+func mergeEnv(env, overrides []string) []string {
+	out := append([]string{}, env...)
+	for _, o := range overrides {
+		k, _, _ := strings.Cut(o, "=")
+		found := false
+		for i, e := range out {
+			if ek, _, _ := strings.Cut(e, "="); ek == k {
+				out[i] = o
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func setJSON(m map[string]json.RawMessage, key string, v interface{}) error {
+	dt, err := json.Marshal(v)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	m[key] = dt
+	return nil
+}
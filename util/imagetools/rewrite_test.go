@@ -0,0 +1,156 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	ctx := context.Background()
+	r := New(Opt{})
+
+	ref := "oci-layout://" + t.TempDir() + ":v1"
+	writeTestIndex(t, r, ref, "linux/amd64", "linux/arm64")
+
+	stmt, err := json.Marshal(intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			PredicateType: "https://openvex.dev/ns/v0.2.0",
+		},
+		Predicate: map[string]interface{}{},
+	})
+	require.NoError(t, err)
+	u, err := r.AddAttestation(ctx, ref, stmt, []ocispec.Platform{platforms.MustParse("linux/arm64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+	srcDt, srcDesc, err := r.Get(ctx, ref)
+	require.NoError(t, err)
+	srcs := []*Source{{Ref: named, Desc: srcDesc}}
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	user := "nobody"
+	amd64 := platforms.MustParse("linux/amd64")
+	dt, desc, blobs, err := r.Rewrite(ctx, srcs, srcDt, srcDesc, &Rewrite{
+		Labels:     map[string]string{"org.opencontainers.image.version": "1.4.1"},
+		Env:        []string{"PATH=/app/bin", "MODE=release"},
+		User:       &user,
+		Entrypoint: []string{"/app/bin/server"},
+		Created:    &created,
+		Annotations: map[exptypes.AnnotationKey]string{
+			{Type: exptypes.AnnotationManifest, Key: "org.opencontainers.image.title"}:     "app",
+			{Type: exptypes.AnnotationManifest, Key: "org.example.arch", Platform: &amd64}: "amd64",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ocispec.MediaTypeImageIndex, desc.MediaType)
+	require.Len(t, blobs, 4)
+
+	var srcIdx, idx ocispec.Index
+	require.NoError(t, json.Unmarshal(srcDt, &srcIdx))
+	require.NoError(t, json.Unmarshal(dt, &idx))
+	require.Len(t, srcIdx.Manifests, 3)
+	// the attestation manifest of the original image is dropped
+	require.Len(t, idx.Manifests, 2)
+	for i := 0; i < 2; i++ {
+		require.NotEqual(t, srcIdx.Manifests[i].Digest, idx.Manifests[i].Digest)
+		require.Equal(t, srcIdx.Manifests[i].Platform, idx.Manifests[i].Platform)
+		require.Empty(t, attestationSubject(idx.Manifests[i]))
+	}
+
+	for _, b := range blobs {
+		pushTestBlob(t, r, ref, b)
+	}
+	require.NoError(t, r.Push(ctx, named, desc, dt))
+
+	mfstDt, err := r.GetDescriptor(ctx, ref, idx.Manifests[0])
+	require.NoError(t, err)
+	var mfst ocispec.Manifest
+	require.NoError(t, json.Unmarshal(mfstDt, &mfst))
+	require.Equal(t, map[string]string{
+		"org.opencontainers.image.title":   "app",
+		"org.example.arch":                 "amd64",
+		"org.opencontainers.image.created": "2024-05-01T12:00:00Z",
+	}, mfst.Annotations)
+
+	cfgDt, err := r.GetDescriptor(ctx, ref, mfst.Config)
+	require.NoError(t, err)
+	var img ocispec.Image
+	require.NoError(t, json.Unmarshal(cfgDt, &img))
+	require.Equal(t, "amd64", img.Architecture)
+	require.Equal(t, created, img.Created.UTC())
+	require.Equal(t, "1.4.1", img.Config.Labels["org.opencontainers.image.version"])
+	require.Equal(t, []string{"PATH=/app/bin", "MODE=release"}, img.Config.Env)
+	require.Equal(t, "nobody", img.Config.User)
+	require.Equal(t, []string{"/app/bin/server"}, img.Config.Entrypoint)
+
+	mfstDt, err = r.GetDescriptor(ctx, ref, idx.Manifests[1])
+	require.NoError(t, err)
+	mfst = ocispec.Manifest{}
+	require.NoError(t, json.Unmarshal(mfstDt, &mfst))
+	require.NotContains(t, mfst.Annotations, "org.example.arch")
+}
+
+func TestRewriteManifest(t *testing.T) {
+	ctx := context.Background()
+	r := New(Opt{})
+
+	ref := "oci-layout://" + t.TempDir() + ":v1"
+	writeTestIndex(t, r, ref, "linux/arm64")
+
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+	idxDt, _, err := r.Get(ctx, ref)
+	require.NoError(t, err)
+	var srcIdx ocispec.Index
+	require.NoError(t, json.Unmarshal(idxDt, &srcIdx))
+	// a single manifest has no platform in its descriptor
+	srcDesc := srcIdx.Manifests[0]
+	srcDesc.Platform = nil
+	srcDt, err := r.GetDescriptor(ctx, ref, srcDesc)
+	require.NoError(t, err)
+	srcs := []*Source{{Ref: named, Desc: srcDesc}}
+
+	arm64 := platforms.MustParse("linux/arm64")
+	amd64 := platforms.MustParse("linux/amd64")
+	dt, desc, blobs, err := r.Rewrite(ctx, srcs, srcDt, srcDesc, &Rewrite{
+		Annotations: map[exptypes.AnnotationKey]string{
+			{Type: exptypes.AnnotationManifest, Key: "org.example.arch", Platform: &arm64}:  "arm64",
+			{Type: exptypes.AnnotationManifest, Key: "org.example.other", Platform: &amd64}: "amd64",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ocispec.MediaTypeImageManifest, desc.MediaType)
+	require.Len(t, blobs, 1)
+
+	var mfst ocispec.Manifest
+	require.NoError(t, json.Unmarshal(dt, &mfst))
+	require.Equal(t, map[string]string{
+		"org.example.arch": "arm64",
+	}, mfst.Annotations)
+}
+
+func TestMergeEnv(t *testing.T) {
+	require.Equal(t,
+		[]string{"PATH=/app/bin", "HOME=/root", "MODE=release"},
+		mergeEnv([]string{"PATH=/usr/bin", "HOME=/root"}, []string{"PATH=/app/bin", "MODE=release"}),
+	)
+}
+
+func pushTestBlob(t *testing.T, r *Resolver, ref string, b Blob) {
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+	dref, err := reference.WithDigest(reference.TrimNamed(named), b.Desc.Digest)
+	require.NoError(t, err)
+	require.NoError(t, r.Push(context.Background(), dref, b.Desc, b.Data))
+}
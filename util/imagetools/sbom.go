@@ -0,0 +1,406 @@
+package imagetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SBOMFormatPackages      = "packages"
+	SBOMFormatPackagesJSON  = "packages-json"
+	SBOMFormatSPDXJSON      = "spdx-json"
+	SBOMFormatCycloneDXJSON = "cyclonedx-json"
+)
+
+// Package is a package listed in the SBOM attestation of an image.
+type Package struct {
+	Platform string `json:"platform"`
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	License  string `json:"license,omitempty"`
+	PURL     string `json:"purl,omitempty"`
+}
+
+// PackageFilter selects packages by name, version and license. Each field
+// is a shell pattern, empty fields match any value.
+type PackageFilter struct {
+	Name    string
+	Version string
+	License string
+}
+
+// ParsePackageFilter parses filters in the form key=pattern where key is
+// one of name, version or license.
+func ParsePackageFilter(in []string) (PackageFilter, error) {
+	var f PackageFilter
+	for _, v := range in {
+		key, pattern, ok := strings.Cut(v, "=")
+		if !ok {
+			return f, errors.Errorf("invalid SBOM filter %q, expected key=pattern", v)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return f, errors.Wrapf(err, "invalid SBOM filter %q", v)
+		}
+		switch key {
+		case "name":
+			f.Name = pattern
+		case "version":
+			f.Version = pattern
+		case "license":
+			f.License = pattern
+		default:
+			return f, errors.Errorf("invalid SBOM filter %q, unknown key %q", v, key)
+		}
+	}
+	return f, nil
+}
+
+// Match returns true if the package matches all the patterns of the filter.
+// Licenses are matched against the whole license expression and each of
+// its identifiers.
+func (f PackageFilter) Match(p Package) bool {
+	if !matchPattern(f.Name, p.Name) || !matchPattern(f.Version, p.Version) {
+		return false
+	}
+	if f.License == "" || matchPattern(f.License, p.License) {
+		return true
+	}
+	for _, l := range strings.FieldsFunc(p.License, func(r rune) bool {
+		return r == ' ' || r == '(' || r == ')'
+	}) {
+		if l != "AND" && l != "OR" && l != "WITH" && matchPattern(f.License, l) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(pattern, v string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, v)
+	return ok
+}
+
+type spdxDocument struct {
+	Packages []spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceType    string `json:"referenceType"`
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+func (p spdxPackage) toPackage(platform string) Package {
+	pkg := Package{
+		Platform: platform,
+		Name:     p.Name,
+		Version:  p.VersionInfo,
+	}
+	for _, l := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+		if l != "" && l != "NOASSERTION" && l != "NONE" {
+			pkg.License = l
+			break
+		}
+	}
+	for _, ref := range p.ExternalRefs {
+		if ref.ReferenceType == "purl" {
+			pkg.PURL = ref.ReferenceLocator
+			break
+		}
+	}
+	return pkg
+}
+
+// spdxDocuments returns the SPDX documents of an SBOM.
+func spdxDocuments(sbom sbomStub) []interface{} {
+	var docs []interface{}
+	for _, doc := range append([]interface{}{sbom.SPDX}, sbom.AdditionalSPDXs...) {
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// sbomPackageList returns the packages listed in the SPDX documents of an
+// SBOM.
+func sbomPackageList(platform string, sbom sbomStub) []Package {
+	var pkgs []Package
+	for _, doc := range spdxDocuments(sbom) {
+		dt, err := json.Marshal(doc)
+		if err != nil {
+			continue
+		}
+		var spdx spdxDocument
+		if err := json.Unmarshal(dt, &spdx); err != nil {
+			continue
+		}
+		for _, p := range spdx.Packages {
+			if p.Name == "" {
+				continue
+			}
+			pkgs = append(pkgs, p.toPackage(platform))
+		}
+	}
+	return pkgs
+}
+
+// Packages returns the packages listed in the SBOM attestations of the
+// images matching the filter, sorted by platform, name and version.
+func (r *result) Packages(f PackageFilter) ([]Package, error) {
+	sboms, err := r.SBOM()
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []Package
+	for platform, sbom := range sboms {
+		for _, p := range sbomPackageList(platform, sbom) {
+			if f.Match(p) {
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].Platform != pkgs[j].Platform {
+			return pkgs[i].Platform < pkgs[j].Platform
+		}
+		if pkgs[i].Name != pkgs[j].Name {
+			return pkgs[i].Name < pkgs[j].Name
+		}
+		return pkgs[i].Version < pkgs[j].Version
+	})
+	return pkgs, nil
+}
+
+// printSBOM writes the packages of the SBOM attestations of res matching
+// the filter in the given format.
+func printSBOM(res *result, name string, format string, f PackageFilter, out io.Writer) error {
+	switch format {
+	case SBOMFormatPackages, SBOMFormatPackagesJSON:
+		pkgs, err := res.Packages(f)
+		if err != nil {
+			return err
+		}
+		if format == SBOMFormatPackagesJSON {
+			return writeJSON(out, pkgs)
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		_, _ = fmt.Fprintln(w, "PLATFORM\tNAME\tVERSION\tLICENSE")
+		for _, p := range pkgs {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Platform, p.Name, p.Version, p.License)
+		}
+		return w.Flush()
+	case SBOMFormatSPDXJSON:
+		docs, err := spdxExport(res, f)
+		if err != nil {
+			return err
+		}
+		return writePerPlatform(out, docs)
+	case SBOMFormatCycloneDXJSON:
+		pkgs, err := res.Packages(f)
+		if err != nil {
+			return err
+		}
+		boms := make(map[string]interface{})
+		for _, platform := range res.platforms {
+			var platformPkgs []Package
+			for _, p := range pkgs {
+				if p.Platform == platform {
+					platformPkgs = append(platformPkgs, p)
+				}
+			}
+			boms[platform] = cycloneDXDocument(name, platform, platformPkgs)
+		}
+		return writePerPlatform(out, boms)
+	default:
+		return errors.Errorf("unsupported SBOM format %q", format)
+	}
+}
+
+// spdxExport returns the SPDX documents of each platform without the
+// packages that do not match the filter. Documents are merged when the SBOM
+// has been generated in several parts.
+func spdxExport(res *result, f PackageFilter) (map[string]interface{}, error) {
+	sboms, err := res.SBOM()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(sboms))
+	for platform, sbom := range sboms {
+		var merged map[string]interface{}
+		for _, doc := range spdxDocuments(sbom) {
+			m, ok := doc.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			m = filterSPDX(m, platform, f)
+			if merged == nil {
+				merged = make(map[string]interface{}, len(m))
+				for k, v := range m {
+					merged[k] = v
+				}
+				continue
+			}
+			for _, key := range []string{"packages", "files", "relationships"} {
+				if v, ok := m[key].([]interface{}); ok {
+					prev, _ := merged[key].([]interface{})
+					merged[key] = append(prev, v...)
+				}
+			}
+		}
+		if merged != nil {
+			out[platform] = merged
+		}
+	}
+	return out, nil
+}
+
+func filterSPDX(doc map[string]interface{}, platform string, f PackageFilter) map[string]interface{} {
+	if f == (PackageFilter{}) {
+		return doc
+	}
+	res := make(map[string]interface{}, len(doc))
+	for k, v := range doc {
+		res[k] = v
+	}
+
+	removed := make(map[string]struct{})
+	var pkgs []interface{}
+	for _, p := range asSlice(doc["packages"]) {
+		dt, err := json.Marshal(p)
+		if err != nil {
+			continue
+		}
+		var sp spdxPackage
+		if err := json.Unmarshal(dt, &sp); err != nil {
+			continue
+		}
+		if f.Match(sp.toPackage(platform)) {
+			pkgs = append(pkgs, p)
+		} else {
+			removed[sp.SPDXID] = struct{}{}
+		}
+	}
+	res["packages"] = pkgs
+
+	var rels []interface{}
+	for _, rel := range asSlice(doc["relationships"]) {
+		m, ok := rel.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		a, _ := m["spdxElementId"].(string)
+		b, _ := m["relatedSpdxElement"].(string)
+		if _, ok := removed[a]; ok {
+			continue
+		}
+		if _, ok := removed[b]; ok {
+			continue
+		}
+		rels = append(rels, rel)
+	}
+	res["relationships"] = rels
+	return res
+}
+
+func asSlice(v interface{}) []interface{} {
+	s, _ := v.([]interface{})
+	return s
+}
+
+type cycloneDXBOM struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Licenses   []cycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// cycloneDXDocument converts the packages of an image to a CycloneDX BOM.
+func cycloneDXDocument(name string, platform string, pkgs []Package) cycloneDXBOM {
+	bom := cycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Component: cycloneDXComponent{
+				Type: "container",
+				Name: name,
+				Properties: []cycloneDXProperty{
+					{Name: "platform", Value: platform},
+				},
+			},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	for _, p := range pkgs {
+		c := cycloneDXComponent{
+			Type:    "library",
+			Name:    p.Name,
+			Version: p.Version,
+			PURL:    p.PURL,
+		}
+		if p.License != "" {
+			c.Licenses = []cycloneDXLicense{{Expression: p.License}}
+		}
+		bom.Components = append(bom.Components, c)
+	}
+	return bom
+}
+
+// writePerPlatform writes the document of the single platform of an image,
+// or the documents by platform for multi-platform images.
+func writePerPlatform(out io.Writer, docs map[string]interface{}) error {
+	if len(docs) == 1 {
+		for _, doc := range docs {
+			return writeJSON(out, doc)
+		}
+	}
+	return writeJSON(out, docs)
+}
+
+func writeJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
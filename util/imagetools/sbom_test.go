@@ -0,0 +1,137 @@
+package imagetools
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePackageFilter(t *testing.T) {
+	f, err := ParsePackageFilter([]string{"name=openssl", "version=3.0.*", "license=Apache-2.0"})
+	require.NoError(t, err)
+	require.Equal(t, PackageFilter{Name: "openssl", Version: "3.0.*", License: "Apache-2.0"}, f)
+
+	_, err = ParsePackageFilter([]string{"openssl"})
+	require.Error(t, err)
+	_, err = ParsePackageFilter([]string{"arch=amd64"})
+	require.Error(t, err)
+	_, err = ParsePackageFilter([]string{"name=[a"})
+	require.Error(t, err)
+}
+
+func TestPackageFilterMatch(t *testing.T) {
+	p := Package{Name: "openssl", Version: "3.0.12-r1", License: "(Apache-2.0 OR MIT) AND OpenSSL"}
+
+	require.True(t, PackageFilter{}.Match(p))
+	require.True(t, PackageFilter{Name: "openssl", Version: "3.0.*"}.Match(p))
+	require.False(t, PackageFilter{Name: "openssl", Version: "3.1.*"}.Match(p))
+	require.True(t, PackageFilter{License: "MIT"}.Match(p))
+	require.True(t, PackageFilter{License: "(Apache-2.0 OR MIT) AND OpenSSL"}.Match(p))
+	require.False(t, PackageFilter{License: "GPL-*"}.Match(p))
+	require.False(t, PackageFilter{License: "AND"}.Match(p))
+}
+
+func TestPrintSBOM(t *testing.T) {
+	res := &result{
+		platforms: []string{"linux/amd64", "linux/arm64"},
+		assets: map[string]asset{
+			"linux/amd64": testSBOMAsset(&sbomStub{
+				SPDX: testSPDX(
+					map[string]interface{}{"SPDXID": "SPDXRef-openssl", "name": "openssl", "versionInfo": "3.0.12-r1", "licenseConcluded": "Apache-2.0"},
+					map[string]interface{}{"SPDXID": "SPDXRef-musl", "name": "musl", "versionInfo": "1.2.4-r2", "licenseDeclared": "MIT", "externalRefs": []interface{}{
+						map[string]interface{}{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:apk/alpine/musl@1.2.4-r2"},
+					}},
+				),
+			}),
+			"linux/arm64": testSBOMAsset(&sbomStub{
+				SPDX: testSPDX(
+					map[string]interface{}{"SPDXID": "SPDXRef-openssl", "name": "openssl", "versionInfo": "3.1.4-r0", "licenseConcluded": "NOASSERTION", "licenseDeclared": "Apache-2.0"},
+				),
+				AdditionalSPDXs: []interface{}{
+					testSPDX(map[string]interface{}{"SPDXID": "SPDXRef-curl", "name": "curl", "versionInfo": "8.5.0-r0"}),
+				},
+			}),
+		},
+	}
+
+	pkgs, err := res.Packages(PackageFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{Platform: "linux/amd64", Name: "musl", Version: "1.2.4-r2", License: "MIT", PURL: "pkg:apk/alpine/musl@1.2.4-r2"},
+		{Platform: "linux/amd64", Name: "openssl", Version: "3.0.12-r1", License: "Apache-2.0"},
+		{Platform: "linux/arm64", Name: "curl", Version: "8.5.0-r0"},
+		{Platform: "linux/arm64", Name: "openssl", Version: "3.1.4-r0", License: "Apache-2.0"},
+	}, pkgs)
+
+	f := PackageFilter{Name: "openssl", Version: "3.0.*"}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, printSBOM(res, "app", SBOMFormatPackages, f, buf))
+	require.Equal(t, "PLATFORM      NAME      VERSION     LICENSE\nlinux/amd64   openssl   3.0.12-r1   Apache-2.0\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printSBOM(res, "app", SBOMFormatSPDXJSON, f, buf))
+	var spdx map[string]struct {
+		Packages      []spdxPackage `json:"packages"`
+		Relationships []struct {
+			RelatedSpdxElement string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &spdx))
+	require.Len(t, spdx["linux/amd64"].Packages, 1)
+	require.Len(t, spdx["linux/amd64"].Relationships, 1)
+	require.Equal(t, "SPDXRef-openssl", spdx["linux/amd64"].Relationships[0].RelatedSpdxElement)
+	require.Empty(t, spdx["linux/arm64"].Packages)
+
+	// additional documents are merged without altering the loaded SBOM
+	buf.Reset()
+	require.NoError(t, printSBOM(res, "app", SBOMFormatSPDXJSON, PackageFilter{}, buf))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &spdx))
+	require.Len(t, spdx["linux/arm64"].Packages, 2)
+	sbom, err := res.assets["linux/arm64"].deferredSbom()
+	require.NoError(t, err)
+	require.Len(t, sbom.SPDX.(map[string]interface{})["packages"], 1)
+
+	buf.Reset()
+	require.NoError(t, printSBOM(res, "app", SBOMFormatCycloneDXJSON, PackageFilter{License: "MIT"}, buf))
+	var boms map[string]cycloneDXBOM
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &boms))
+	require.Equal(t, "CycloneDX", boms["linux/amd64"].BOMFormat)
+	require.Equal(t, []cycloneDXComponent{{
+		Type:     "library",
+		Name:     "musl",
+		Version:  "1.2.4-r2",
+		PURL:     "pkg:apk/alpine/musl@1.2.4-r2",
+		Licenses: []cycloneDXLicense{{Expression: "MIT"}},
+	}}, boms["linux/amd64"].Components)
+	require.Empty(t, boms["linux/arm64"].Components)
+
+	require.Error(t, printSBOM(res, "app", "syft-json", f, buf))
+}
+
+func testSPDX(pkgs ...map[string]interface{}) interface{} {
+	var pp, rels []interface{}
+	for _, p := range pkgs {
+		pp = append(pp, p)
+		rels = append(rels, map[string]interface{}{
+			"spdxElementId":      "SPDXRef-DOCUMENT",
+			"relatedSpdxElement": p["SPDXID"],
+			"relationshipType":   "DESCRIBES",
+		})
+	}
+	return map[string]interface{}{
+		"spdxVersion":   "SPDX-2.3",
+		"packages":      pp,
+		"relationships": rels,
+	}
+}
+
+func testSBOMAsset(sbom *sbomStub) asset {
+	return asset{
+		deferredSbom: func() (*sbomStub, error) {
+			return sbom, nil
+		},
+	}
+}
@@ -0,0 +1,400 @@
+package imagetools
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/containerd/containerd/images"
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	// SignaturePredicateType is the predicate type of the statements signed
+	// by Sign. The statement binds the image digest to the repository it has
+	// been signed for.
+	SignaturePredicateType = "https://github.com/docker/buildx/signature/v0.1"
+
+	// SignatureArtifactType is the artifact type of the manifests holding the
+	// signature of an index, pushed as referrers of the index.
+	SignatureArtifactType = "application/vnd.docker.buildx.signature.v0+json"
+
+	annotationSignatureKeyID = "dev.docker.buildx.signature.keyid"
+)
+
+// Key is a local key used to sign images or verify their signatures. Keys
+// loaded from a public key can only verify signatures.
+type Key struct {
+	priv crypto.Signer
+	pub  crypto.PublicKey
+	id   string
+}
+
+var _ dsse.SignVerifier = &Key{}
+
+// LoadKey loads an ECDSA, Ed25519 or RSA key from a PEM encoded private or
+// public key.
+func LoadKey(dt []byte) (*Key, error) {
+	block, _ := pem.Decode(dt)
+	if block == nil {
+		return nil, errors.New("failed to decode key, PEM block not found")
+	}
+	if x509.IsEncryptedPEMBlock(block) { //nolint:staticcheck // only used to return a readable error
+		return nil, errors.New("encrypted keys are not supported")
+	}
+
+	k := &Key{}
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse public key")
+		}
+		k.pub = pub
+	case "PRIVATE KEY", "EC PRIVATE KEY", "RSA PRIVATE KEY":
+		var priv interface{}
+		var err error
+		switch block.Type {
+		case "EC PRIVATE KEY":
+			priv, err = x509.ParseECPrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		default:
+			priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+		signer, ok := priv.(crypto.Signer)
+		if !ok {
+			return nil, errors.Errorf("unsupported private key type %T", priv)
+		}
+		k.priv = signer
+		k.pub = signer.Public()
+	default:
+		return nil, errors.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	switch k.pub.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, errors.Errorf("unsupported key type %T", k.pub)
+	}
+
+	id, err := dsse.SHA256KeyID(k.pub)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute key ID")
+	}
+	k.id = id
+	return k, nil
+}
+
+// IsPrivate returns true if the key can be used to sign.
+func (k *Key) IsPrivate() bool {
+	return k.priv != nil
+}
+
+// Sign implements dsse.Signer.
+func (k *Key) Sign(data []byte) ([]byte, error) {
+	if k.priv == nil {
+		return nil, errors.Errorf("key %s is a public key and cannot be used to sign", k.id)
+	}
+	switch priv := k.priv.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(priv, data), nil
+	case *ecdsa.PrivateKey:
+		h := sha256.Sum256(data)
+		return ecdsa.SignASN1(rand.Reader, priv, h[:])
+	case *rsa.PrivateKey:
+		h := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, h[:])
+	default:
+		return nil, errors.Errorf("unsupported key type %T", k.priv)
+	}
+}
+
+// Verify implements dsse.Verifier.
+func (k *Key) Verify(data, sig []byte) error {
+	switch pub := k.pub.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, data, sig) {
+			return errors.New("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		h := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(pub, h[:], sig) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		h := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, h[:], sig); err != nil {
+			return errors.Wrap(err, "invalid signature")
+		}
+	default:
+		return errors.Errorf("unsupported key type %T", k.pub)
+	}
+	return nil
+}
+
+// KeyID implements dsse.Verifier. It is the SHA256 fingerprint of the
+// public key.
+func (k *Key) KeyID() (string, error) {
+	return k.id, nil
+}
+
+// Public implements dsse.Verifier.
+func (k *Key) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs the images of an index matching the given platforms, or all of
+// them if none is given, then the updated index. The signature is a DSSE
+// envelope of an in-toto statement whose subject is the image, added to its
+// attestation manifest. A previous signature of an image with the same key
+// is replaced. As the index can't contain its own signature, it is signed
+// in a manifest pushed as a referrer of the index.
+func (r *Resolver) Sign(ctx context.Context, in string, k *Key, ps []ocispec.Platform) (*IndexUpdate, error) {
+	ai, err := r.loadAttestIndex(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	es, err := dsse.NewEnvelopeSigner(k)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	u := &IndexUpdate{
+		Source: &Source{Ref: ai.ref, Desc: ai.desc},
+	}
+	var found bool
+	for _, d := range ai.index.Manifests {
+		if attestationSubject(d) != "" || d.Platform == nil || !matchPlatform(*d.Platform, ps) {
+			continue
+		}
+		found = true
+
+		sig, err := signSubject(es, k, ai.ref, d.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if err := ai.attach(u, d.Digest, sig, func(l ocispec.Descriptor) bool {
+			return l.Annotations[annotationPredicateType] == SignaturePredicateType && l.Annotations[annotationSignatureKeyID] == k.id
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no image found in %s for the given platforms", in)
+	}
+
+	if err := ai.update(u); err != nil {
+		return nil, err
+	}
+
+	sig, err := signSubject(es, k, ai.ref, u.Desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	mfst, err := json.Marshal(ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: SignatureArtifactType,
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       []ocispec.Descriptor{sig.Desc},
+		Subject: &ocispec.Descriptor{
+			MediaType: u.Desc.MediaType,
+			Digest:    u.Desc.Digest,
+			Size:      u.Desc.Size,
+		},
+		Annotations: map[string]string{
+			annotationSignatureKeyID: k.id,
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	u.Blobs = append(u.Blobs, Blob{Desc: ocispec.DescriptorEmptyJSON, Data: ocispec.DescriptorEmptyJSON.Data}, sig)
+	u.Referrers = append(u.Referrers, Blob{
+		Desc: ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(mfst),
+			Size:      int64(len(mfst)),
+		},
+		Data: mfst,
+	})
+	return u, nil
+}
+
+// signSubject returns the DSSE envelope of a signature statement for the
+// manifest with the given digest in the repository of ref.
+func signSubject(es *dsse.EnvelopeSigner, k *Key, ref reference.Named, dgst digest.Digest) (Blob, error) {
+	payload, err := json.Marshal(intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			Type:          intoto.StatementInTotoV01,
+			PredicateType: SignaturePredicateType,
+			Subject: []intoto.Subject{{
+				Name:   reference.TrimNamed(ref).String(),
+				Digest: map[string]string{dgst.Algorithm().String(): dgst.Encoded()},
+			}},
+		},
+		Predicate: map[string]interface{}{},
+	})
+	if err != nil {
+		return Blob{}, errors.WithStack(err)
+	}
+	env, err := es.SignPayload(intoto.PayloadType, payload)
+	if err != nil {
+		return Blob{}, errors.Wrapf(err, "failed to sign %s", dgst)
+	}
+	dt, err := json.Marshal(env)
+	if err != nil {
+		return Blob{}, errors.WithStack(err)
+	}
+	return Blob{
+		Desc: ocispec.Descriptor{
+			MediaType: inTotoStatementDSSEMime,
+			Digest:    digest.FromBytes(dt),
+			Size:      int64(len(dt)),
+			Annotations: map[string]string{
+				annotationPredicateType:  SignaturePredicateType,
+				annotationSignatureKeyID: k.id,
+			},
+		},
+		Data: dt,
+	}, nil
+}
+
+// verifySignatures checks that one of the signature attestations of the
+// image with the given digest has been signed by the key.
+func (r *Resolver) verifySignatures(ctx context.Context, ref string, res *result, dgst digest.Digest, k *Key) (string, error) {
+	var found bool
+	for _, ad := range res.refs[dgst] {
+		mfst, ok := res.manifests[ad]
+		if !ok {
+			continue
+		}
+		for _, l := range mfst.manifest.Layers {
+			if l.Annotations[annotationPredicateType] != SignaturePredicateType {
+				continue
+			}
+			found = true
+			dt, err := r.GetDescriptor(ctx, ref, l)
+			if err != nil {
+				return "", err
+			}
+			if verifySignature(dt, dgst, k) == nil {
+				return "", nil
+			}
+		}
+	}
+	if !found {
+		return "image is not signed", nil
+	}
+	return fmt.Sprintf("no valid signature for key %s", k.id), nil
+}
+
+// verifyIndexSignature checks that one of the signature referrers of the
+// index referenced by ref has been signed by the key. It does nothing if ref
+// is not an index.
+func (r *Resolver) verifyIndexSignature(ctx context.Context, ref string, v *Verification, k *Key) error {
+	named, err := parseRef(ref)
+	if err != nil {
+		return err
+	}
+	_, desc, err := r.Resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+	default:
+		return nil
+	}
+	v.Digest = desc.Digest
+
+	refs, err := r.Referrers(ctx, named, desc.Digest)
+	if err != nil {
+		return err
+	}
+	var found bool
+	for _, d := range refs {
+		if d.ArtifactType != SignatureArtifactType {
+			continue
+		}
+		found = true
+		dt, err := r.GetDescriptor(ctx, ref, d)
+		if err != nil {
+			return err
+		}
+		var mfst ocispec.Manifest
+		if err := json.Unmarshal(dt, &mfst); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, l := range mfst.Layers {
+			if l.Annotations[annotationPredicateType] != SignaturePredicateType {
+				continue
+			}
+			dt, err := r.GetDescriptor(ctx, ref, l)
+			if err != nil {
+				return err
+			}
+			if verifySignature(dt, desc.Digest, k) == nil {
+				return nil
+			}
+		}
+	}
+	if !found {
+		v.Violations = append(v.Violations, "index is not signed")
+	} else {
+		v.Violations = append(v.Violations, fmt.Sprintf("no valid signature for key %s", k.id))
+	}
+	return nil
+}
+
+func verifySignature(dt []byte, subject digest.Digest, k *Key) error {
+	var env dsse.Envelope
+	if err := json.Unmarshal(dt, &env); err != nil {
+		return errors.Wrap(err, "failed to parse DSSE envelope")
+	}
+	ev, err := dsse.NewEnvelopeVerifier(k)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := ev.Verify(&env); err != nil {
+		return err
+	}
+	if env.PayloadType != intoto.PayloadType {
+		return errors.Errorf("unexpected payload type %q", env.PayloadType)
+	}
+	payload, _, err := decodeDSSE(dt, inTotoStatementDSSEMime)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode DSSE payload")
+	}
+	var stmt intoto.Statement
+	if err := json.Unmarshal(payload, &stmt); err != nil {
+		return errors.Wrap(err, "failed to parse in-toto statement")
+	}
+	if stmt.PredicateType != SignaturePredicateType {
+		return errors.Errorf("unexpected predicate type %q", stmt.PredicateType)
+	}
+	for _, s := range stmt.Subject {
+		if s.Digest[subject.Algorithm().String()] == subject.Encoded() {
+			return nil
+		}
+	}
+	return errors.Errorf("signature does not apply to %s", subject)
+}
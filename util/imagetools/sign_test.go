@@ -0,0 +1,144 @@
+package imagetools
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKey(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for name, priv := range map[string]interface{}{
+		"ecdsa":   testECDSAKey(t),
+		"ed25519": edKey,
+		"rsa":     rsaKey,
+	} {
+		t.Run(name, func(t *testing.T) {
+			privDt, err := x509.MarshalPKCS8PrivateKey(priv)
+			require.NoError(t, err)
+			k, err := LoadKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDt}))
+			require.NoError(t, err)
+
+			pubDt, err := x509.MarshalPKIXPublicKey(k.Public())
+			require.NoError(t, err)
+			pub, err := LoadKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDt}))
+			require.NoError(t, err)
+			require.Equal(t, k.id, pub.id)
+
+			sig, err := k.Sign([]byte("foo"))
+			require.NoError(t, err)
+			require.NoError(t, pub.Verify([]byte("foo"), sig))
+			require.Error(t, pub.Verify([]byte("bar"), sig))
+
+			_, err = pub.Sign([]byte("foo"))
+			require.Error(t, err)
+		})
+	}
+
+	_, err = LoadKey([]byte("foo"))
+	require.Error(t, err)
+}
+
+func TestSign(t *testing.T) {
+	ctx := context.Background()
+	r := New(Opt{})
+
+	ref := "oci-layout://" + t.TempDir() + ":v1"
+	writeTestIndex(t, r, ref, "linux/amd64", "linux/arm64")
+
+	k := testKey(t)
+	other := testKey(t)
+
+	v, err := r.Verify(ctx, ref, VerifyOpt{Key: k})
+	require.NoError(t, err)
+	require.False(t, v.OK())
+	require.Equal(t, []string{"index is not signed"}, v.Violations)
+	require.Equal(t, []string{"image is not signed"}, v.Platforms[0].Violations)
+
+	u, err := r.Sign(ctx, ref, k, []ocispec.Platform{platforms.MustParse("linux/arm64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	v, err = r.Verify(ctx, ref, VerifyOpt{Key: k})
+	require.NoError(t, err)
+	require.Empty(t, v.Violations)
+	require.Equal(t, u.Desc.Digest, v.Digest)
+	require.Len(t, v.Platforms, 2)
+	for _, p := range v.Platforms {
+		if p.Platform == "linux/arm64" {
+			require.Empty(t, p.Violations)
+		} else {
+			require.NotEmpty(t, p.Violations)
+		}
+	}
+
+	u, err = r.Sign(ctx, ref, k, nil)
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	v, err = r.Verify(ctx, ref, VerifyOpt{Key: k})
+	require.NoError(t, err)
+	require.True(t, v.OK())
+
+	// signing again with the same key replaces the signature
+	atts, err := r.ListAttestations(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, atts, 2)
+
+	v, err = r.Verify(ctx, ref, VerifyOpt{Key: other})
+	require.NoError(t, err)
+	require.False(t, v.OK())
+	require.Contains(t, v.Violations[0], "no valid signature for key")
+	require.Contains(t, v.Platforms[0].Violations[0], "no valid signature for key")
+
+	// the index is signed in a referrer as it can't contain its own signature
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+	refs, err := r.Referrers(ctx, named, u.Desc.Digest)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	require.Equal(t, SignatureArtifactType, refs[0].ArtifactType)
+
+	// an index updated after signing is not signed anymore
+	u, err = r.AddAttestation(ctx, ref, []byte(`{"predicateType": "https://openvex.dev/ns/v0.2.0", "predicate": {}}`), nil)
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+	v, err = r.Verify(ctx, ref, VerifyOpt{Key: k})
+	require.NoError(t, err)
+	require.Equal(t, []string{"index is not signed"}, v.Violations)
+	require.Empty(t, v.Platforms[0].Violations)
+
+	// signature of another image is rejected
+	dt, err := r.GetDescriptor(ctx, ref, atts[0].Layer)
+	require.NoError(t, err)
+	require.NoError(t, verifySignature(dt, atts[0].Subject, k))
+	require.ErrorContains(t, verifySignature(dt, atts[1].Subject, k), "does not apply")
+}
+
+func testECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return priv
+}
+
+func testKey(t *testing.T) *Key {
+	dt, err := x509.MarshalECPrivateKey(testECDSAKey(t))
+	require.NoError(t, err)
+	k, err := LoadKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: dt}))
+	require.NoError(t, err)
+	return k
+}
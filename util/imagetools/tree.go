@@ -0,0 +1,245 @@
+package imagetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/log"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/docker/go-units"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	TreeNodeIndex               = "index"
+	TreeNodeManifest            = "manifest"
+	TreeNodeConfig              = "config"
+	TreeNodeLayer               = "layer"
+	TreeNodeAttestationManifest = "attestation-manifest"
+	TreeNodeAttestation         = "attestation"
+	TreeNodeReferrer            = "referrer"
+)
+
+// TreeNode is a descriptor of the tree of an image with the descriptors it
+// refers to.
+type TreeNode struct {
+	Kind       string             `json:"kind"`
+	Descriptor ocispec.Descriptor `json:"descriptor"`
+	Platform   string             `json:"platform,omitempty"`
+	// PredicateType is set for attestations.
+	PredicateType string `json:"predicateType,omitempty"`
+	// Shared is set for blobs referred to by several manifests of the tree.
+	Shared bool `json:"shared,omitempty"`
+	// TotalSize is the size of an image manifest with its config and layers.
+	TotalSize int64       `json:"totalSize,omitempty"`
+	Children  []*TreeNode `json:"children,omitempty"`
+}
+
+// Tree is the tree of the descriptors of an image or index, from the root
+// manifest down to the layers, attestation manifests and referrers.
+type Tree struct {
+	Name string    `json:"name"`
+	Root *TreeNode `json:"root"`
+	// Size is the size of all the blobs of the tree, counting the blobs
+	// referred to several times once.
+	Size int64 `json:"size"`
+	// SharedSize is the size saved by the deduplication of shared blobs.
+	SharedSize int64 `json:"sharedSize,omitempty"`
+}
+
+// Tree loads the tree of the image or index referenced by in.
+func (r *Resolver) Tree(ctx context.Context, in string) (*Tree, error) {
+	ref, err := parseRef(in)
+	if err != nil {
+		return nil, err
+	}
+	dt, desc, err := r.Get(ctx, ref.String())
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := r.treeNode(ctx, ref, desc, dt)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tree{Name: ref.String(), Root: root}
+	t.account()
+	return t, nil
+}
+
+func (r *Resolver) treeNode(ctx context.Context, ref reference.Named, desc ocispec.Descriptor, dt []byte) (*TreeNode, error) {
+	n := &TreeNode{
+		Kind:       TreeNodeManifest,
+		Descriptor: desc,
+	}
+	if desc.Platform != nil {
+		n.Platform = platforms.Format(*desc.Platform)
+	}
+
+	if dt == nil {
+		var err error
+		if dt, err = r.GetDescriptor(ctx, ref.String(), desc); err != nil {
+			return nil, err
+		}
+	}
+
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		n.Kind = TreeNodeIndex
+		var idx ocispec.Index
+		if err := json.Unmarshal(dt, &idx); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		nodes := make([]*TreeNode, len(idx.Manifests))
+		subjects := make(map[digest.Digest]*TreeNode)
+		for i, d := range idx.Manifests {
+			child, err := r.treeNode(ctx, ref, d, nil)
+			if err != nil {
+				return nil, err
+			}
+			nodes[i] = child
+			if attestationSubject(d) == "" {
+				subjects[d.Digest] = child
+			}
+		}
+		// attestation manifests are nested in the image they refer to
+		for i, d := range idx.Manifests {
+			if subject := attestationSubject(d); subject != "" {
+				nodes[i].Kind = TreeNodeAttestationManifest
+				if s, ok := subjects[subject]; ok {
+					s.Children = append(s.Children, nodes[i])
+					continue
+				}
+			}
+			n.Children = append(n.Children, nodes[i])
+		}
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		var mfst ocispec.Manifest
+		if err := json.Unmarshal(dt, &mfst); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		attestation := desc.Annotations[annotationReferenceType] == attestationManifestType
+		n.Children = append(n.Children, &TreeNode{
+			Kind:       TreeNodeConfig,
+			Descriptor: mfst.Config,
+		})
+		n.TotalSize = desc.Size + mfst.Config.Size
+		for _, l := range mfst.Layers {
+			child := &TreeNode{
+				Kind:       TreeNodeLayer,
+				Descriptor: l,
+			}
+			if attestation {
+				child.Kind = TreeNodeAttestation
+				child.PredicateType = l.Annotations[annotationPredicateType]
+			}
+			n.Children = append(n.Children, child)
+			n.TotalSize += l.Size
+		}
+		if attestation {
+			n.TotalSize = 0
+		}
+	default:
+		return n, nil
+	}
+
+	referrers, err := r.Referrers(ctx, ref, desc.Digest)
+	if err != nil {
+		// referrers are informative, the tree is still valid without them
+		log.G(ctx).WithError(err).Debugf("failed to fetch referrers of %s", desc.Digest)
+	}
+	for _, d := range referrers {
+		child, err := r.treeNode(ctx, ref, d, nil)
+		if err != nil {
+			return nil, err
+		}
+		child.Kind = TreeNodeReferrer
+		n.Children = append(n.Children, child)
+	}
+	return n, nil
+}
+
+// account computes the size of the tree and marks the shared blobs.
+func (t *Tree) account() {
+	counts := make(map[digest.Digest]int)
+	sizes := make(map[digest.Digest]int64)
+	t.Root.walk(func(n *TreeNode) {
+		counts[n.Descriptor.Digest]++
+		sizes[n.Descriptor.Digest] = n.Descriptor.Size
+	})
+	t.Size, t.SharedSize = 0, 0
+	for dgst, size := range sizes {
+		t.Size += size
+		t.SharedSize += size * int64(counts[dgst]-1)
+	}
+	t.Root.walk(func(n *TreeNode) {
+		switch n.Kind {
+		case TreeNodeConfig, TreeNodeLayer:
+			n.Shared = counts[n.Descriptor.Digest] > 1
+		}
+	})
+}
+
+func (n *TreeNode) walk(fn func(*TreeNode)) {
+	fn(n)
+	for _, c := range n.Children {
+		c.walk(fn)
+	}
+}
+
+// Print writes the tree in text or json format.
+func (t *Tree) Print(format string, out io.Writer) error {
+	switch format {
+	case "json":
+		return writeJSON(out, t)
+	case "", "text":
+	default:
+		return errors.Errorf("unsupported format %q", format)
+	}
+
+	_, _ = fmt.Fprintf(out, "%s\n", t.Name)
+	t.Root.print(out, "", "")
+	_, _ = fmt.Fprintf(out, "\nTotal size: %s", units.HumanSize(float64(t.Size)))
+	if t.SharedSize > 0 {
+		_, _ = fmt.Fprintf(out, " (%s deduplicated)", units.HumanSize(float64(t.SharedSize)))
+	}
+	_, _ = fmt.Fprintln(out)
+	return nil
+}
+
+func (n *TreeNode) print(out io.Writer, pfx, childPfx string) {
+	label := n.Kind
+	if n.Kind == TreeNodeManifest && n.Platform != "" {
+		label = n.Platform
+	}
+	fields := []string{label, n.Descriptor.Digest.String(), n.Descriptor.MediaType}
+	if n.Descriptor.ArtifactType != "" {
+		fields = append(fields, n.Descriptor.ArtifactType)
+	}
+	if n.PredicateType != "" {
+		fields = append(fields, n.PredicateType)
+	}
+	fields = append(fields, units.HumanSize(float64(n.Descriptor.Size)))
+	if n.TotalSize > 0 {
+		fields = append(fields, "(total "+units.HumanSize(float64(n.TotalSize))+")")
+	}
+	if n.Shared {
+		fields = append(fields, "(shared)")
+	}
+	_, _ = fmt.Fprintf(out, "%s%s\n", pfx, strings.Join(fields, " "))
+
+	for i, c := range n.Children {
+		if i == len(n.Children)-1 {
+			c.print(out, childPfx+"└── ", childPfx+"    ")
+		} else {
+			c.print(out, childPfx+"├── ", childPfx+"│   ")
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package imagetools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/moby/buildkit/client/ociindex"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTree(t *testing.T) {
+	ctx := context.Background()
+	r := New(Opt{})
+
+	dir := t.TempDir()
+	ref := "oci-layout://" + dir + ":v1"
+	writeTestIndex(t, r, ref, "linux/amd64", "linux/arm64")
+
+	stmt, err := json.Marshal(intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			PredicateType: "https://openvex.dev/ns/v0.2.0",
+		},
+		Predicate: map[string]interface{}{},
+	})
+	require.NoError(t, err)
+	u, err := r.AddAttestation(ctx, ref, stmt, []ocispec.Platform{platforms.MustParse("linux/arm64")})
+	require.NoError(t, err)
+	pushTestUpdate(t, r, u, ref)
+
+	// referrer of the index with the referrers tag schema
+	named, err := parseRef(ref)
+	require.NoError(t, err)
+	_, indexDesc, err := r.Resolve(ctx, ref)
+	require.NoError(t, err)
+	emptyDesc := ocispec.DescriptorEmptyJSON
+	emptyDesc.Data = nil
+	referrerDt, err := json.Marshal(ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.example.signature",
+		Config:       emptyDesc,
+		Layers:       []ocispec.Descriptor{},
+		Subject:      &indexDesc,
+	})
+	require.NoError(t, err)
+	referrerDesc := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.example.signature",
+		Digest:       digest.FromBytes(referrerDt),
+		Size:         int64(len(referrerDt)),
+	}
+	for _, b := range []Blob{{Desc: emptyDesc, Data: []byte("{}")}, {Desc: referrerDesc, Data: referrerDt}} {
+		dref, err := reference.WithDigest(reference.TrimNamed(named), b.Desc.Digest)
+		require.NoError(t, err)
+		require.NoError(t, r.Push(ctx, dref, b.Desc, b.Data))
+	}
+	referrersDt, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{referrerDesc},
+	})
+	require.NoError(t, err)
+	referrersDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromBytes(referrersDt),
+		Size:      int64(len(referrersDt)),
+	}
+	dref, err := reference.WithDigest(reference.TrimNamed(named), referrersDesc.Digest)
+	require.NoError(t, err)
+	require.NoError(t, r.Push(ctx, dref, referrersDesc, referrersDt))
+	require.NoError(t, ociindex.NewStoreIndex(dir).Put("sha256-"+indexDesc.Digest.Encoded(), referrersDesc))
+
+	tree, err := r.Tree(ctx, ref)
+	require.NoError(t, err)
+
+	root := tree.Root
+	require.Equal(t, TreeNodeIndex, root.Kind)
+	require.Len(t, root.Children, 3)
+
+	amd64 := root.Children[0]
+	require.Equal(t, TreeNodeManifest, amd64.Kind)
+	require.Equal(t, "linux/amd64", amd64.Platform)
+	require.Len(t, amd64.Children, 1)
+	require.Equal(t, TreeNodeConfig, amd64.Children[0].Kind)
+	require.Equal(t, amd64.Descriptor.Size+amd64.Children[0].Descriptor.Size, amd64.TotalSize)
+
+	arm64 := root.Children[1]
+	require.Equal(t, "linux/arm64", arm64.Platform)
+	require.Len(t, arm64.Children, 2)
+	att := arm64.Children[1]
+	require.Equal(t, TreeNodeAttestationManifest, att.Kind)
+	require.Zero(t, att.TotalSize)
+	require.Len(t, att.Children, 2)
+	require.Equal(t, TreeNodeAttestation, att.Children[1].Kind)
+	require.Equal(t, "https://openvex.dev/ns/v0.2.0", att.Children[1].PredicateType)
+
+	referrer := root.Children[2]
+	require.Equal(t, TreeNodeReferrer, referrer.Kind)
+	require.Equal(t, referrerDesc.Digest, referrer.Descriptor.Digest)
+
+	var size int64
+	seen := map[digest.Digest]struct{}{}
+	root.walk(func(n *TreeNode) {
+		if _, ok := seen[n.Descriptor.Digest]; !ok {
+			size += n.Descriptor.Size
+			seen[n.Descriptor.Digest] = struct{}{}
+		}
+	})
+	require.Equal(t, size, tree.Size)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, tree.Print("", buf))
+	lines := strings.Split(buf.String(), "\n")
+	require.True(t, strings.HasPrefix(lines[2], "├── linux/amd64 "+amd64.Descriptor.Digest.String()))
+	require.True(t, strings.HasPrefix(lines[5], "│   ├── config "))
+	require.True(t, strings.HasPrefix(lines[6], "│   └── attestation-manifest "))
+	require.Contains(t, buf.String(), "└── referrer "+referrerDesc.Digest.String()+" "+ocispec.MediaTypeImageManifest+" application/vnd.example.signature")
+	require.Contains(t, buf.String(), "Total size: ")
+}
+
+func TestTreeAccount(t *testing.T) {
+	layer := ocispec.Descriptor{Digest: digest.FromString("layer"), Size: 100}
+	tree := &Tree{Root: &TreeNode{
+		Kind:       TreeNodeIndex,
+		Descriptor: ocispec.Descriptor{Digest: digest.FromString("index"), Size: 10},
+		Children: []*TreeNode{
+			{
+				Kind:       TreeNodeManifest,
+				Descriptor: ocispec.Descriptor{Digest: digest.FromString("amd64"), Size: 5},
+				Children: []*TreeNode{
+					{Kind: TreeNodeLayer, Descriptor: layer},
+				},
+			},
+			{
+				Kind:       TreeNodeManifest,
+				Descriptor: ocispec.Descriptor{Digest: digest.FromString("arm64"), Size: 5},
+				Children: []*TreeNode{
+					{Kind: TreeNodeLayer, Descriptor: layer},
+					{Kind: TreeNodeLayer, Descriptor: ocispec.Descriptor{Digest: digest.FromString("other"), Size: 50}},
+				},
+			},
+		},
+	}}
+	tree.account()
+	require.Equal(t, int64(170), tree.Size)
+	require.Equal(t, int64(100), tree.SharedSize)
+	require.True(t, tree.Root.Children[0].Children[0].Shared)
+	require.True(t, tree.Root.Children[1].Children[0].Shared)
+	require.False(t, tree.Root.Children[1].Children[1].Shared)
+}
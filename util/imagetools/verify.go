@@ -0,0 +1,332 @@
+package imagetools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	provenancetypes "github.com/moby/buildkit/solver/llbsolver/provenance/types"
+	"github.com/moby/buildkit/solver/pb"
+	"github.com/moby/buildkit/util/entitlements"
+	"github.com/moby/buildkit/util/gitutil"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy describes the requirements the provenance of each image of an index
+// must satisfy.
+type Policy struct {
+	// BuilderIDs is the list of allowed builder IDs. Any builder is allowed
+	// if empty.
+	BuilderIDs []string `yaml:"builderIDs,omitempty" json:"builderIDs,omitempty"`
+	// Source restricts the git repository and reference the image has been
+	// built from.
+	Source *SourcePolicy `yaml:"source,omitempty" json:"source,omitempty"`
+	// DisallowedEntitlements is the list of entitlements (e.g. network.host,
+	// security.insecure) the build must not have used.
+	DisallowedEntitlements []string `yaml:"disallowedEntitlements,omitempty" json:"disallowedEntitlements,omitempty"`
+	// AllowDirty accepts builds from a git context with uncommitted changes.
+	AllowDirty bool `yaml:"allowDirty,omitempty" json:"allowDirty,omitempty"`
+	// RequirePinnedMaterials requires all the materials of the build to be
+	// pinned by digest.
+	RequirePinnedMaterials bool `yaml:"requirePinnedMaterials,omitempty" json:"requirePinnedMaterials,omitempty"`
+}
+
+// SourcePolicy matches the git source of a build. Repo is matched against the
+// host and path of the repository (e.g. github.com/docker/buildx) and Ref
+// against the git reference or commit. Both accept shell patterns.
+type SourcePolicy struct {
+	Repo string `yaml:"repo,omitempty" json:"repo,omitempty"`
+	Ref  string `yaml:"ref,omitempty" json:"ref,omitempty"`
+}
+
+// ParsePolicy parses a policy in YAML or JSON format.
+func ParsePolicy(dt []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(dt))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to parse policy")
+	}
+	for _, e := range p.DisallowedEntitlements {
+		if _, err := entitlements.Parse(e); err != nil {
+			return nil, errors.Wrap(err, "failed to parse policy")
+		}
+	}
+	if p.Source != nil {
+		for _, pattern := range []string{p.Source.Repo, p.Source.Ref} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse policy: invalid pattern %q", pattern)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// Verification is the result of the verification of an image or index.
+// Digest and Violations are set for the signature of an index.
+type Verification struct {
+	Ref        string                 `json:"ref"`
+	Digest     digest.Digest          `json:"digest,omitempty"`
+	Violations []string               `json:"violations,omitempty"`
+	Platforms  []PlatformVerification `json:"platforms"`
+}
+
+// PlatformVerification lists the policy violations of the image of a
+// platform.
+type PlatformVerification struct {
+	Platform   string        `json:"platform"`
+	Digest     digest.Digest `json:"digest"`
+	Violations []string      `json:"violations,omitempty"`
+}
+
+// OK returns true if neither the index nor any of the images violates the
+// policy.
+func (v *Verification) OK() bool {
+	if len(v.Violations) > 0 {
+		return false
+	}
+	for _, p := range v.Platforms {
+		if len(p.Violations) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyOpt configures the checks done by Verify.
+type VerifyOpt struct {
+	// Policy is the policy the provenance of each image must satisfy.
+	Policy *Policy
+	// Key is the key each image must be signed with.
+	Key *Key
+}
+
+// Verify checks the provenance attestation and the signature of each image
+// referenced by ref.
+func (r *Resolver) Verify(ctx context.Context, ref string, opt VerifyOpt) (*Verification, error) {
+	res, err := newLoader(r.resolver()).Load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var provenance map[string]provenanceStub
+	if opt.Policy != nil {
+		if provenance, err = res.Provenance(); err != nil {
+			return nil, err
+		}
+	}
+
+	v := &Verification{Ref: ref}
+	if opt.Key != nil {
+		if err := r.verifyIndexSignature(ctx, ref, v, opt.Key); err != nil {
+			return nil, errors.Wrap(err, "failed to verify signature of index")
+		}
+	}
+	for _, platform := range res.platforms {
+		pv := PlatformVerification{
+			Platform: platform,
+			Digest:   res.images[platform],
+		}
+		if opt.Key != nil {
+			violation, err := r.verifySignatures(ctx, ref, res, pv.Digest, opt.Key)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to verify signature of %s", platform)
+			}
+			if violation != "" {
+				pv.Violations = append(pv.Violations, violation)
+			}
+		}
+		if opt.Policy != nil {
+			if stub, ok := provenance[platform]; ok && stub.SLSA != nil {
+				pred, err := parseProvenance(stub.SLSA)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to parse provenance of %s", platform)
+				}
+				pv.Violations = append(pv.Violations, verifyProvenance(opt.Policy, pred)...)
+			} else {
+				pv.Violations = append(pv.Violations, "no provenance attestation")
+			}
+		}
+		v.Platforms = append(v.Platforms, pv)
+	}
+	return v, nil
+}
+
+func parseProvenance(slsa interface{}) (*provenancetypes.ProvenancePredicate, error) {
+	dt, err := json.Marshal(slsa)
+	if err != nil {
+		return nil, err
+	}
+	var pred provenancetypes.ProvenancePredicate
+	if err := json.Unmarshal(dt, &pred); err != nil {
+		return nil, err
+	}
+	return &pred, nil
+}
+
+func verifyProvenance(p *Policy, pred *provenancetypes.ProvenancePredicate) []string {
+	var violations []string
+
+	if len(p.BuilderIDs) > 0 {
+		allowed := false
+		for _, id := range p.BuilderIDs {
+			if pred.Builder.ID == id {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("builder ID %q is not allowed", pred.Builder.ID))
+		}
+	}
+
+	var vcs map[string]string
+	if pred.Metadata != nil {
+		vcs = pred.Metadata.BuildKitMetadata.VCS
+	}
+	repo, ref := provenanceSource(pred)
+
+	if p.Source != nil {
+		if p.Source.Repo != "" {
+			if repo == "" {
+				violations = append(violations, "git source repository is unknown")
+			} else if ok, _ := path.Match(p.Source.Repo, repo); !ok {
+				violations = append(violations, fmt.Sprintf("git source repository %q does not match %q", repo, p.Source.Repo))
+			}
+		}
+		if p.Source.Ref != "" {
+			revision := strings.TrimSuffix(vcs["revision"], "-dirty")
+			okRef, _ := path.Match(p.Source.Ref, ref)
+			okRevision, _ := path.Match(p.Source.Ref, revision)
+			switch {
+			case ref == "" && revision == "":
+				violations = append(violations, "git source reference is unknown")
+			case ref != "" && !okRef && !okRevision:
+				violations = append(violations, fmt.Sprintf("git source reference %q does not match %q", ref, p.Source.Ref))
+			case ref == "" && !okRevision:
+				violations = append(violations, fmt.Sprintf("git source revision %q does not match %q", revision, p.Source.Ref))
+			}
+		}
+	}
+
+	if len(p.DisallowedEntitlements) > 0 {
+		if pred.BuildConfig == nil {
+			violations = append(violations, "entitlements cannot be checked without build definition, provenance must be generated with mode=max")
+		} else {
+			used := buildEntitlements(pred.BuildConfig)
+			for _, e := range p.DisallowedEntitlements {
+				if _, ok := used[entitlements.Entitlement(e)]; ok {
+					violations = append(violations, fmt.Sprintf("entitlement %s is not allowed", e))
+				}
+			}
+		}
+	}
+
+	if !p.AllowDirty && strings.HasSuffix(vcs["revision"], "-dirty") {
+		violations = append(violations, fmt.Sprintf("git context has uncommitted changes (revision %s)", vcs["revision"]))
+	}
+
+	if p.RequirePinnedMaterials {
+		for _, m := range pred.Materials {
+			if len(m.Digest) == 0 {
+				violations = append(violations, fmt.Sprintf("material %s is not pinned by digest", m.URI))
+			}
+		}
+	}
+
+	return violations
+}
+
+// provenanceSource returns the git repository, as host and path, and the
+// git reference the image has been built from. The build context takes
+// precedence over the VCS metadata of a local context.
+func provenanceSource(pred *provenancetypes.ProvenancePredicate) (repo string, ref string) {
+	if u, err := gitutil.ParseURL(pred.Invocation.ConfigSource.URI); err == nil {
+		if u.Fragment != nil {
+			ref = u.Fragment.Ref
+		}
+		return gitRepoName(u), ref
+	}
+	if pred.Metadata != nil {
+		if u, err := gitutil.ParseURL(pred.Metadata.BuildKitMetadata.VCS["source"]); err == nil {
+			return gitRepoName(u), ""
+		}
+	}
+	return "", ""
+}
+
+func gitRepoName(u *gitutil.GitURL) string {
+	return strings.TrimSuffix(path.Join(u.Host, u.Path), ".git")
+}
+
+// buildEntitlements returns the entitlements required by the exec ops of the
+// build definition.
+func buildEntitlements(bc *provenancetypes.BuildConfig) map[entitlements.Entitlement]struct{} {
+	res := make(map[entitlements.Entitlement]struct{})
+	for _, step := range bc.Definition {
+		if step.Op == nil {
+			continue
+		}
+		exec := step.Op.GetExec()
+		if exec == nil {
+			continue
+		}
+		if exec.Network == pb.NetMode_HOST {
+			res[entitlements.EntitlementNetworkHost] = struct{}{}
+		}
+		if exec.Security == pb.SecurityMode_INSECURE {
+			res[entitlements.EntitlementSecurityInsecure] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Print writes the verification report in text or json format.
+func (v *Verification) Print(format string, out io.Writer) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "", "text":
+	default:
+		return errors.Errorf("unsupported format %q", format)
+	}
+
+	platforms := make([]PlatformVerification, len(v.Platforms))
+	copy(platforms, v.Platforms)
+	sort.SliceStable(platforms, func(i, j int) bool {
+		return platforms[i].Platform < platforms[j].Platform
+	})
+
+	_, _ = fmt.Fprintf(out, "Name: %s\n", v.Ref)
+	if v.Digest != "" {
+		status := "PASS"
+		if len(v.Violations) > 0 {
+			status = "FAIL"
+		}
+		_, _ = fmt.Fprintf(out, "\nIndex: %s\n", status)
+		_, _ = fmt.Fprintf(out, "%sDigest: %s\n", defaultPfx, v.Digest)
+		for _, violation := range v.Violations {
+			_, _ = fmt.Fprintf(out, "%s- %s\n", defaultPfx, violation)
+		}
+	}
+	for _, p := range platforms {
+		status := "PASS"
+		if len(p.Violations) > 0 {
+			status = "FAIL"
+		}
+		_, _ = fmt.Fprintf(out, "\n%s: %s\n", p.Platform, status)
+		_, _ = fmt.Fprintf(out, "%sDigest: %s\n", defaultPfx, p.Digest)
+		for _, violation := range p.Violations {
+			_, _ = fmt.Fprintf(out, "%s- %s\n", defaultPfx, violation)
+		}
+	}
+	return nil
+}
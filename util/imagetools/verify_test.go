@@ -0,0 +1,178 @@
+package imagetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy([]byte(`
+builderIDs:
+  - https://github.com/docker/buildx/actions
+source:
+  repo: github.com/docker/*
+  ref: refs/tags/v*
+disallowedEntitlements:
+  - network.host
+requirePinnedMaterials: true
+`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://github.com/docker/buildx/actions"}, p.BuilderIDs)
+	require.Equal(t, &SourcePolicy{Repo: "github.com/docker/*", Ref: "refs/tags/v*"}, p.Source)
+	require.Equal(t, []string{"network.host"}, p.DisallowedEntitlements)
+	require.False(t, p.AllowDirty)
+	require.True(t, p.RequirePinnedMaterials)
+
+	p, err = ParsePolicy([]byte(`{"allowDirty": true}`))
+	require.NoError(t, err)
+	require.True(t, p.AllowDirty)
+
+	p, err = ParsePolicy(nil)
+	require.NoError(t, err)
+	require.Equal(t, &Policy{}, p)
+
+	_, err = ParsePolicy([]byte(`builderIds: [foo]`))
+	require.Error(t, err)
+
+	_, err = ParsePolicy([]byte(`disallowedEntitlements: [foo]`))
+	require.Error(t, err)
+
+	_, err = ParsePolicy([]byte(`source: {repo: "[a"}`))
+	require.Error(t, err)
+}
+
+func TestVerifyProvenance(t *testing.T) {
+	slsa := map[string]interface{}{
+		"builder": map[string]interface{}{
+			"id": "https://github.com/docker/buildx/actions",
+		},
+		"buildType": "https://mobyproject.org/buildkit@v1",
+		"materials": []interface{}{
+			map[string]interface{}{
+				"uri":    "pkg:docker/alpine@latest?platform=linux%2Famd64",
+				"digest": map[string]interface{}{"sha256": "0a1b2c"},
+			},
+			map[string]interface{}{
+				"uri": "https://example.com/archive.tar.gz",
+			},
+		},
+		"invocation": map[string]interface{}{
+			"configSource": map[string]interface{}{
+				"uri":        "https://github.com/docker/buildx.git#refs/tags/v0.13.0",
+				"entryPoint": "Dockerfile",
+			},
+		},
+		"buildConfig": map[string]interface{}{
+			"llbDefinition": []interface{}{
+				map[string]interface{}{
+					"id": "step0",
+					"op": map[string]interface{}{
+						"Op": map[string]interface{}{
+							"exec": map[string]interface{}{
+								"meta":    map[string]interface{}{"args": []interface{}{"/bin/sh", "-c", "true"}},
+								"network": 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		"metadata": map[string]interface{}{
+			"https://mobyproject.org/buildkit@v1#metadata": map[string]interface{}{
+				"vcs": map[string]interface{}{
+					"source":   "https://github.com/docker/buildx.git",
+					"revision": "5f3b7c4-dirty",
+				},
+			},
+		},
+	}
+	pred, err := parseProvenance(slsa)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		policy     Policy
+		violations []string
+	}{
+		{
+			name:   "empty",
+			policy: Policy{AllowDirty: true},
+		},
+		{
+			name: "builder",
+			policy: Policy{
+				BuilderIDs: []string{"https://ci.example.com", "https://github.com/docker/buildx/actions"},
+				AllowDirty: true,
+			},
+		},
+		{
+			name: "builder not allowed",
+			policy: Policy{
+				BuilderIDs: []string{"https://ci.example.com"},
+				AllowDirty: true,
+			},
+			violations: []string{`builder ID "https://github.com/docker/buildx/actions" is not allowed`},
+		},
+		{
+			name: "source",
+			policy: Policy{
+				Source:     &SourcePolicy{Repo: "github.com/docker/*", Ref: "refs/tags/v*"},
+				AllowDirty: true,
+			},
+		},
+		{
+			name: "source mismatch",
+			policy: Policy{
+				Source:     &SourcePolicy{Repo: "github.com/moby/*", Ref: "refs/heads/main"},
+				AllowDirty: true,
+			},
+			violations: []string{
+				`git source repository "github.com/docker/buildx" does not match "github.com/moby/*"`,
+				`git source reference "refs/tags/v0.13.0" does not match "refs/heads/main"`,
+			},
+		},
+		{
+			name: "entitlements",
+			policy: Policy{
+				DisallowedEntitlements: []string{"network.host", "security.insecure"},
+				AllowDirty:             true,
+			},
+			violations: []string{"entitlement network.host is not allowed"},
+		},
+		{
+			name:       "dirty",
+			policy:     Policy{},
+			violations: []string{"git context has uncommitted changes (revision 5f3b7c4-dirty)"},
+		},
+		{
+			name: "pinned materials",
+			policy: Policy{
+				RequirePinnedMaterials: true,
+				AllowDirty:             true,
+			},
+			violations: []string{"material https://example.com/archive.tar.gz is not pinned by digest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.violations, verifyProvenance(&tt.policy, pred))
+		})
+	}
+
+	// local context only has the vcs metadata
+	pred.Invocation.ConfigSource.URI = ""
+	violations := verifyProvenance(&Policy{
+		Source:     &SourcePolicy{Repo: "github.com/docker/buildx", Ref: "5f3b7c4"},
+		AllowDirty: true,
+	}, pred)
+	require.Empty(t, violations)
+
+	pred.BuildConfig = nil
+	violations = verifyProvenance(&Policy{
+		DisallowedEntitlements: []string{"network.host"},
+		AllowDirty:             true,
+	}, pred)
+	require.Len(t, violations, 1)
+	require.Contains(t, violations[0], "mode=max")
+}